@@ -0,0 +1,259 @@
+package approval
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	// actionApprove and actionSkip are the action IDs of the buttons of a proposal
+	actionApprove = "approve"
+	actionSkip    = "skip"
+	// maxRequestAge is the maximum age of an interaction request, to prevent replay attacks
+	maxRequestAge = 5 * time.Minute
+)
+
+// Approver decides whether the given victims may be terminated.
+type Approver interface {
+	// Approve returns true if the victims may be terminated.
+	Approve(ctx context.Context, victims []v1.Pod) (bool, error)
+}
+
+// interaction is the part of Slack's block actions payload we care about.
+type interaction struct {
+	Type string `json:"type"`
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+}
+
+// Slack proposes victims in a Slack channel with Approve and Skip buttons and waits for a
+// decision. It is an http.Handler that must be configured as the interactivity request URL of
+// the Slack app owning the webhook.
+type Slack struct {
+	logger        log.FieldLogger
+	webhook       string
+	signingSecret string
+	timeout       time.Duration
+	// the decision if nobody decides within the timeout
+	defaultApprove bool
+	client         *http.Client
+	now            func() time.Time
+
+	mutex sync.Mutex
+	// the pending proposals by ID
+	pending map[string]chan bool
+}
+
+// NewSlack creates and returns a Slack approver posting to the given incoming webhook and
+// verifying interactions with the given signing secret of the Slack app.
+func NewSlack(logger log.FieldLogger, webhook, signingSecret string, timeout time.Duration, defaultApprove bool) *Slack {
+	return &Slack{
+		logger:         logger.WithField("approver", "Slack"),
+		webhook:        webhook,
+		signingSecret:  signingSecret,
+		timeout:        timeout,
+		defaultApprove: defaultApprove,
+		client:         &http.Client{Timeout: 10 * time.Second},
+		now:            time.Now,
+		pending:        map[string]chan bool{},
+	}
+}
+
+// Approve posts a proposal of the given victims and blocks until it is approved, skipped, timed
+// out or the given context is canceled.
+func (s *Slack) Approve(ctx context.Context, victims []v1.Pod) (bool, error) {
+	id := string(uuid.NewUUID())
+
+	decision := make(chan bool, 1)
+	s.mutex.Lock()
+	s.pending[id] = decision
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		delete(s.pending, id)
+		s.mutex.Unlock()
+	}()
+
+	if err := s.propose(id, victims); err != nil {
+		return false, err
+	}
+
+	select {
+	case approved := <-decision:
+		return approved, nil
+	case <-time.After(s.timeout):
+		s.logger.WithFields(log.Fields{
+			"id":       id,
+			"approved": s.defaultApprove,
+		}).Info("proposal timed out, using default")
+		return s.defaultApprove, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// propose posts the message with the victims and the Approve and Skip buttons.
+func (s *Slack) propose(id string, victims []v1.Pod) error {
+	names := make([]string, 0, len(victims))
+	for _, victim := range victims {
+		names = append(names, fmt.Sprintf("• `%s/%s`", victim.Namespace, victim.Name))
+	}
+
+	fallback := "skip"
+	if s.defaultApprove {
+		fallback = "approve"
+	}
+
+	message := map[string]interface{}{
+		"text": "chaoskube proposes to terminate pods",
+		"blocks": []interface{}{
+			map[string]interface{}{
+				"type": "section",
+				"text": map[string]string{
+					"type": "mrkdwn",
+					"text": fmt.Sprintf("chaoskube proposes to terminate:\n%s\nDefaults to %s in %s.", strings.Join(names, "\n"), fallback, s.timeout),
+				},
+			},
+			map[string]interface{}{
+				"type": "actions",
+				"elements": []interface{}{
+					button(actionApprove, "Approve", id, "danger"),
+					button(actionSkip, "Skip", id, "primary"),
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.client.Post(s.webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from slack webhook", res.StatusCode)
+	}
+	return nil
+}
+
+func button(actionID, text, value, style string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":      "button",
+		"action_id": actionID,
+		"text":      map[string]string{"type": "plain_text", "text": text},
+		"value":     value,
+		"style":     style,
+	}
+}
+
+// ServeHTTP accepts the interactions of Slack with the buttons of a proposal.
+func (s *Slack) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, 1<<20))
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.verify(req.Header, body); err != nil {
+		s.logger.WithField("err", err).Warn("rejected interaction")
+		http.Error(res, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var payload interaction
+	if err := json.Unmarshal([]byte(form.Get("payload")), &payload); err != nil {
+		http.Error(res, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	for _, action := range payload.Actions {
+		if action.ActionID != actionApprove && action.ActionID != actionSkip {
+			continue
+		}
+
+		s.mutex.Lock()
+		decision, ok := s.pending[action.Value]
+		s.mutex.Unlock()
+
+		logger := s.logger.WithFields(log.Fields{
+			"id":     action.Value,
+			"action": action.ActionID,
+			"user":   payload.User.Username,
+		})
+		if !ok {
+			logger.Info("ignored interaction with expired proposal")
+			continue
+		}
+
+		select {
+		case decision <- action.ActionID == actionApprove:
+			logger.Info("received decision")
+		default:
+			// a decision was made already
+		}
+	}
+
+	res.WriteHeader(http.StatusOK)
+}
+
+// verify checks the signature of a request as described in
+// https://api.slack.com/authentication/verifying-requests-from-slack.
+func (s *Slack) verify(header http.Header, body []byte) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %q", timestamp)
+	}
+	if math.Abs(s.now().Sub(time.Unix(seconds, 0)).Seconds()) > maxRequestAge.Seconds() {
+		return fmt.Errorf("request too old: %s", timestamp)
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.signingSecret))
+	fmt.Fprintf(mac, "v0:%s:%s", timestamp, body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature"))) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
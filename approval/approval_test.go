@@ -0,0 +1,127 @@
+package approval
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const signingSecret = "secret"
+
+type ApprovalSuite struct {
+	testutil.TestSuite
+}
+
+// proposalServer returns a fake Slack webhook that sends the ID of each proposal to the returned channel.
+func (suite *ApprovalSuite) proposalServer() (*httptest.Server, <-chan string) {
+	ids := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		suite.Require().NoError(err)
+
+		var message struct {
+			Blocks []struct {
+				Elements []struct {
+					Value string `json:"value"`
+				} `json:"elements"`
+			} `json:"blocks"`
+		}
+		suite.Require().NoError(json.Unmarshal(body, &message))
+
+		ids <- message.Blocks[1].Elements[0].Value
+	}))
+	return server, ids
+}
+
+// interact sends a signed interaction with the given action to the approver.
+func interact(approver *Slack, actionID, id string, signature string) *httptest.ResponseRecorder {
+	payload := fmt.Sprintf(`{"type":"block_actions","user":{"username":"jane"},"actions":[{"action_id":%q,"value":%q}]}`, actionID, id)
+	body := url.Values{"payload": {payload}}.Encode()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	if signature == "" {
+		mac := hmac.New(sha256.New, []byte(signingSecret))
+		fmt.Fprintf(mac, "v0:%s:%s", timestamp, body)
+		signature = "v0=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/slack/interactions", strings.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", signature)
+
+	res := httptest.NewRecorder()
+	approver.ServeHTTP(res, req)
+	return res
+}
+
+func (suite *ApprovalSuite) TestApprove() {
+	victims := []v1.Pod{util.NewPod("default", "foo", v1.PodRunning)}
+
+	for _, tt := range []struct {
+		action   string
+		expected bool
+	}{
+		{actionApprove, true},
+		{actionSkip, false},
+	} {
+		server, ids := suite.proposalServer()
+		approver := NewSlack(log.StandardLogger(), server.URL, signingSecret, time.Minute, false)
+
+		go func() {
+			res := interact(approver, tt.action, <-ids, "")
+			suite.Equal(http.StatusOK, res.Code)
+		}()
+
+		approved, err := approver.Approve(context.Background(), victims)
+		suite.Require().NoError(err)
+		suite.Equal(tt.expected, approved, tt.action)
+
+		server.Close()
+	}
+}
+
+func (suite *ApprovalSuite) TestApproveTimeout() {
+	victims := []v1.Pod{util.NewPod("default", "foo", v1.PodRunning)}
+
+	for _, defaultApprove := range []bool{true, false} {
+		server, _ := suite.proposalServer()
+		approver := NewSlack(log.StandardLogger(), server.URL, signingSecret, 10*time.Millisecond, defaultApprove)
+
+		approved, err := approver.Approve(context.Background(), victims)
+		suite.Require().NoError(err)
+		suite.Equal(defaultApprove, approved)
+
+		server.Close()
+	}
+}
+
+func (suite *ApprovalSuite) TestInvalidSignature() {
+	approver := NewSlack(log.StandardLogger(), "", signingSecret, time.Minute, false)
+
+	res := interact(approver, actionApprove, "id", "v0=invalid")
+	suite.Equal(http.StatusUnauthorized, res.Code)
+}
+
+func TestApprovalSuite(t *testing.T) {
+	suite.Run(t, new(ApprovalSuite))
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	// ErrUnauthenticated is returned when the caller's identity can't be established.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is returned when the caller isn't allowed to cause chaos in a namespace.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Authorizer decides whether the caller identified by a bearer token may cause chaos in a
+// namespace. An empty namespace stands for all namespaces.
+type Authorizer interface {
+	// Authorize returns the name of the caller or ErrUnauthenticated or ErrForbidden.
+	Authorize(ctx context.Context, token, namespace string) (string, error)
+}
+
+// StaticToken authorizes callers presenting a single shared token for all namespaces.
+type StaticToken struct {
+	token string
+}
+
+// NewStaticToken creates and returns a StaticToken object.
+func NewStaticToken(token string) *StaticToken {
+	return &StaticToken{token: token}
+}
+
+// Authorize accepts the configured token and rejects everything else.
+func (s *StaticToken) Authorize(ctx context.Context, token, namespace string) (string, error) {
+	if s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		return "", ErrUnauthenticated
+	}
+	return "token", nil
+}
+
+// Kubernetes authenticates callers with a TokenReview and checks with a SubjectAccessReview
+// whether they may delete pods in the requested namespace. This way teams can only cause
+// chaos where they could delete pods themselves.
+type Kubernetes struct {
+	client kubernetes.Interface
+}
+
+// NewKubernetes creates and returns a Kubernetes object.
+func NewKubernetes(client kubernetes.Interface) *Kubernetes {
+	return &Kubernetes{client: client}
+}
+
+// Authorize reviews the token and the caller's permissions against the Kubernetes API.
+func (k *Kubernetes) Authorize(ctx context.Context, token, namespace string) (string, error) {
+	if token == "" {
+		return "", ErrUnauthenticated
+	}
+
+	review, err := k.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to review token: %v", err)
+	}
+	if !review.Status.Authenticated {
+		return "", ErrUnauthenticated
+	}
+
+	user := review.Status.User
+
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	access, err := k.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "delete",
+				Resource:  "pods",
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to review access: %v", err)
+	}
+	if !access.Status.Allowed {
+		return user.Username, ErrForbidden
+	}
+
+	return user.Username, nil
+}
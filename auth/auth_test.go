@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *AuthSuite) TestStaticToken() {
+	for _, tt := range []struct {
+		name       string
+		configured string
+		token      string
+		err        error
+	}{
+		{"matching token", "secret", "secret", nil},
+		{"wrong token", "secret", "wrong", ErrUnauthenticated},
+		{"missing token", "secret", "", ErrUnauthenticated},
+		{"no token configured", "", "", ErrUnauthenticated},
+	} {
+		_, err := NewStaticToken(tt.configured).Authorize(context.Background(), tt.token, "default")
+		suite.Equal(tt.err, err, tt.name)
+	}
+}
+
+func (suite *AuthSuite) TestKubernetes() {
+	// team-a may delete pods in namespace team-a only
+	for _, tt := range []struct {
+		name      string
+		token     string
+		namespace string
+		user      string
+		err       error
+	}{
+		{"allowed namespace", "team-a-token", "team-a", "team-a", nil},
+		{"foreign namespace", "team-a-token", "team-b", "team-a", ErrForbidden},
+		{"all namespaces", "team-a-token", "", "team-a", ErrForbidden},
+		{"invalid token", "invalid", "team-a", "", ErrUnauthenticated},
+		{"missing token", "", "team-a", "", ErrUnauthenticated},
+	} {
+		client := fake.NewSimpleClientset()
+		client.PrependReactor("create", "tokenreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+			review := action.(ktesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+			if review.Spec.Token == "team-a-token" {
+				review.Status.Authenticated = true
+				review.Status.User = authenticationv1.UserInfo{Username: "team-a", Groups: []string{"developers"}}
+			}
+			return true, review, nil
+		})
+		client.PrependReactor("create", "subjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+			review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			suite.Equal([]string{"developers"}, review.Spec.Groups)
+			suite.Equal("delete", review.Spec.ResourceAttributes.Verb)
+			suite.Equal("pods", review.Spec.ResourceAttributes.Resource)
+			review.Status.Allowed = review.Spec.User == "team-a" && review.Spec.ResourceAttributes.Namespace == "team-a"
+			return true, review, nil
+		})
+
+		user, err := NewKubernetes(client).Authorize(context.Background(), tt.token, tt.namespace)
+		suite.Equal(tt.err, err, tt.name)
+		suite.Equal(tt.user, user, tt.name)
+	}
+}
+
+func TestAuthSuite(t *testing.T) {
+	suite.Run(t, new(AuthSuite))
+}
@@ -0,0 +1,127 @@
+package budget
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Budget limits the number of terminations within the last hour and the last day, e.g. to bound
+// the total disruption regardless of the interval and the number of victims per interval. It
+// optionally persists the times of the terminations to a file, so that restarts don't reset it.
+type Budget struct {
+	perHour int
+	perDay  int
+	path    string
+
+	mutex sync.Mutex
+	kills []time.Time
+}
+
+// New creates and returns a Budget allowing the given number of terminations per hour and per
+// day, where zero means unlimited. If a path is given, the terminations recorded there are
+// loaded and later terminations are persisted there.
+func New(perHour, perDay int, path string) (*Budget, error) {
+	b := &Budget{perHour: perHour, perDay: perDay, path: path}
+	if path == "" {
+		return b, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &b.kills); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Remaining returns how many terminations are left at the given time, within both the last hour
+// and the last day. It returns -1 if there is no limit.
+func (b *Budget) Remaining(now time.Time) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	remaining := -1
+	for _, window := range []struct {
+		limit    int
+		duration time.Duration
+	}{
+		{b.perHour, time.Hour},
+		{b.perDay, 24 * time.Hour},
+	} {
+		if window.limit <= 0 {
+			continue
+		}
+		left := max(0, window.limit-b.since(now.Add(-window.duration)))
+		if remaining < 0 || left < remaining {
+			remaining = left
+		}
+	}
+	return remaining
+}
+
+// Spend records a termination at the given time and persists all terminations of the last day,
+// if a path is given.
+func (b *Budget) Spend(now time.Time) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// older terminations don't count against any window anymore
+	dayAgo := now.Add(-24 * time.Hour)
+	kills := b.kills[:0]
+	for _, kill := range b.kills {
+		if kill.After(dayAgo) {
+			kills = append(kills, kill)
+		}
+	}
+	b.kills = append(kills, now)
+
+	if b.path == "" {
+		return nil
+	}
+	return b.persist()
+}
+
+// since returns the number of terminations after the given time.
+func (b *Budget) since(t time.Time) int {
+	count := 0
+	for _, kill := range b.kills {
+		if kill.After(t) {
+			count++
+		}
+	}
+	return count
+}
+
+// persist atomically writes the recorded terminations to the file at path.
+func (b *Budget) persist() error {
+	data, err := json.Marshal(b.kills)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), b.path)
+}
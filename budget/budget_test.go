@@ -0,0 +1,69 @@
+package budget
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BudgetSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *BudgetSuite) TestRemaining() {
+	now := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+
+	unlimited, err := New(0, 0, "")
+	suite.Require().NoError(err)
+	suite.Require().NoError(unlimited.Spend(now))
+	suite.Equal(-1, unlimited.Remaining(now))
+
+	budget, err := New(2, 3, "")
+	suite.Require().NoError(err)
+	suite.Equal(2, budget.Remaining(now))
+
+	suite.Require().NoError(budget.Spend(now.Add(-2 * time.Hour)))
+	suite.Equal(2, budget.Remaining(now))
+
+	suite.Require().NoError(budget.Spend(now.Add(-30 * time.Minute)))
+	suite.Equal(1, budget.Remaining(now))
+
+	suite.Require().NoError(budget.Spend(now))
+	suite.Equal(0, budget.Remaining(now))
+
+	// an hour later, the daily limit still applies
+	suite.Equal(0, budget.Remaining(now.Add(time.Hour)))
+
+	// terminations older than a day don't count anymore
+	suite.Equal(1, budget.Remaining(now.Add(22*time.Hour+time.Minute)))
+	suite.Equal(2, budget.Remaining(now.Add(24*time.Hour)))
+}
+
+func (suite *BudgetSuite) TestPersist() {
+	now := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(suite.T().TempDir(), "budget.json")
+
+	budget, err := New(0, 2, path)
+	suite.Require().NoError(err)
+	suite.Require().NoError(budget.Spend(now.Add(-25 * time.Hour)))
+	suite.Require().NoError(budget.Spend(now))
+
+	// a restart continues with the persisted terminations of the last day only
+	restarted, err := New(0, 2, path)
+	suite.Require().NoError(err)
+	suite.Equal(1, restarted.Remaining(now))
+	suite.Len(restarted.kills, 1)
+
+	suite.Require().NoError(os.WriteFile(path, []byte("garbage"), 0644))
+	_, err = New(0, 2, path)
+	suite.Error(err)
+}
+
+func TestBudgetSuite(t *testing.T) {
+	suite.Run(t, new(BudgetSuite))
+}
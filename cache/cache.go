@@ -0,0 +1,205 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"github.com/linki/chaoskube/util"
+)
+
+// DefaultResync is the interval in which the informers replay their cached objects.
+var DefaultResync = 10 * time.Minute
+
+var (
+	podsResource       = v1.SchemeGroupVersion.WithResource("pods")
+	namespacesResource = v1.SchemeGroupVersion.WithResource("namespaces")
+)
+
+// Cache serves pods and namespaces from shared informers instead of listing them from the
+// API server on every tick, which reduces API server load considerably on large clusters.
+type Cache struct {
+	// functions that start the informers and wait for their caches to be filled
+	starters       []func(stop <-chan struct{}) error
+	listPods       func() ([]v1.Pod, error)
+	listNamespaces func(selector labels.Selector) ([]v1.Namespace, error)
+}
+
+// New creates and returns a Cache object watching the pods in the given namespaces, an empty
+// namespace meaning all namespaces, that match the given label and field selectors. Namespaces
+// are only watched if withNamespaces is true.
+func New(client kubernetes.Interface, namespaces []string, selector labels.Selector, fieldSelector fields.Selector, withNamespaces bool) *Cache {
+	c := &Cache{}
+
+	podListers := []listerscorev1.PodLister{}
+	for _, namespace := range namespaces {
+		podFactory := informers.NewSharedInformerFactoryWithOptions(client, DefaultResync,
+			informers.WithNamespace(namespace),
+			informers.WithTweakListOptions(tweakListOptions(selector, fieldSelector)),
+		)
+		podListers = append(podListers, podFactory.Core().V1().Pods().Lister())
+		c.starters = append(c.starters, typedStarter(podFactory))
+	}
+
+	c.listPods = func() ([]v1.Pod, error) {
+		result := []v1.Pod{}
+		for _, pods := range podListers {
+			cached, err := pods.List(labels.Everything())
+			if err != nil {
+				return nil, err
+			}
+			for _, pod := range cached {
+				result = append(result, *pod)
+			}
+		}
+		return result, nil
+	}
+
+	if withNamespaces {
+		namespaceFactory := informers.NewSharedInformerFactory(client, DefaultResync)
+		namespaces := namespaceFactory.Core().V1().Namespaces().Lister()
+
+		c.starters = append(c.starters, typedStarter(namespaceFactory))
+		c.listNamespaces = func(selector labels.Selector) ([]v1.Namespace, error) {
+			cached, err := namespaces.List(selector)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make([]v1.Namespace, 0, len(cached))
+			for _, namespace := range cached {
+				result = append(result, *namespace)
+			}
+			return result, nil
+		}
+	}
+
+	return c
+}
+
+// NewMetadata works like New but only caches the metadata of pods and namespaces, which uses
+// considerably less memory. The field selector must restrict pods to the given phase.
+func NewMetadata(client metadata.Interface, namespaces []string, selector labels.Selector, fieldSelector fields.Selector, phase v1.PodPhase, withNamespaces bool) *Cache {
+	c := &Cache{}
+
+	podListers := []toolscache.GenericLister{}
+	for _, namespace := range namespaces {
+		podFactory := metadatainformer.NewFilteredSharedInformerFactory(client, DefaultResync, namespace, tweakListOptions(selector, fieldSelector))
+		podListers = append(podListers, podFactory.ForResource(podsResource).Lister())
+		c.starters = append(c.starters, metadataStarter(podFactory))
+	}
+
+	c.listPods = func() ([]v1.Pod, error) {
+		result := []v1.Pod{}
+		for _, pods := range podListers {
+			cached, err := pods.List(labels.Everything())
+			if err != nil {
+				return nil, err
+			}
+			for _, object := range cached {
+				result = append(result, util.PodFromMetadata(*object.(*metav1.PartialObjectMetadata), phase))
+			}
+		}
+		return result, nil
+	}
+
+	if withNamespaces {
+		namespaceFactory := metadatainformer.NewSharedInformerFactory(client, DefaultResync)
+		namespaces := namespaceFactory.ForResource(namespacesResource).Lister()
+
+		c.starters = append(c.starters, metadataStarter(namespaceFactory))
+		c.listNamespaces = func(selector labels.Selector) ([]v1.Namespace, error) {
+			cached, err := namespaces.List(selector)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make([]v1.Namespace, 0, len(cached))
+			for _, object := range cached {
+				result = append(result, v1.Namespace{ObjectMeta: object.(*metav1.PartialObjectMetadata).ObjectMeta})
+			}
+			return result, nil
+		}
+	}
+
+	return c
+}
+
+// Start starts the informers and waits until their caches are filled. The informers stop
+// when the given context is canceled.
+func (c *Cache) Start(ctx context.Context) error {
+	for _, start := range c.starters {
+		if err := start(ctx.Done()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListPods returns all cached pods ordered by namespace and name.
+func (c *Cache) ListPods(ctx context.Context) ([]v1.Pod, error) {
+	pods, err := c.listPods()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	return pods, nil
+}
+
+// ListNamespaces returns all cached namespaces that match the given label selector.
+func (c *Cache) ListNamespaces(ctx context.Context, selector labels.Selector) ([]v1.Namespace, error) {
+	if c.listNamespaces == nil {
+		return nil, fmt.Errorf("namespaces aren't cached")
+	}
+	return c.listNamespaces(selector)
+}
+
+func tweakListOptions(selector labels.Selector, fieldSelector fields.Selector) func(options *metav1.ListOptions) {
+	return func(options *metav1.ListOptions) {
+		options.LabelSelector = selector.String()
+		options.FieldSelector = fieldSelector.String()
+	}
+}
+
+func typedStarter(factory informers.SharedInformerFactory) func(stop <-chan struct{}) error {
+	return func(stop <-chan struct{}) error {
+		factory.Start(stop)
+		for informer, synced := range factory.WaitForCacheSync(stop) {
+			if !synced {
+				return fmt.Errorf("failed to sync informer cache for %v", informer)
+			}
+		}
+		return nil
+	}
+}
+
+func metadataStarter(factory metadatainformer.SharedInformerFactory) func(stop <-chan struct{}) error {
+	return func(stop <-chan struct{}) error {
+		factory.Start(stop)
+		for informer, synced := range factory.WaitForCacheSync(stop) {
+			if !synced {
+				return fmt.Errorf("failed to sync informer cache for %v", informer)
+			}
+		}
+		return nil
+	}
+}
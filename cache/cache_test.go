@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *CacheSuite) TestListPods() {
+	foo := util.NewPod("default", "foo", v1.PodRunning)
+	bar := util.NewPod("testing", "bar", v1.PodRunning)
+	baz := util.NewPod("default", "baz", v1.PodRunning)
+	client := fake.NewSimpleClientset(&foo, &bar, &baz)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cache := New(client, []string{v1.NamespaceAll}, labels.Everything(), fields.Everything(), false)
+	suite.Require().NoError(cache.Start(ctx))
+
+	pods, err := cache.ListPods(ctx)
+	suite.Require().NoError(err)
+	suite.AssertPods(pods, []map[string]string{
+		{"namespace": "default", "name": "baz"},
+		{"namespace": "default", "name": "foo"},
+		{"namespace": "testing", "name": "bar"},
+	})
+
+	// the cache follows changes without listing again
+	suite.Require().NoError(client.CoreV1().Pods("default").Delete(ctx, "foo", metav1.DeleteOptions{}))
+	suite.Eventually(func() bool {
+		pods, err := cache.ListPods(ctx)
+		return err == nil && len(pods) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (suite *CacheSuite) TestListPodsNamespaceScope() {
+	foo := util.NewPod("default", "foo", v1.PodRunning)
+	bar := util.NewPod("testing", "bar", v1.PodRunning)
+	client := fake.NewSimpleClientset(&foo, &bar)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, tt := range []struct {
+		namespaces []string
+		expected   []map[string]string
+	}{
+		{[]string{"testing"}, []map[string]string{{"namespace": "testing", "name": "bar"}}},
+		{[]string{"testing", "default"}, []map[string]string{{"namespace": "default", "name": "foo"}, {"namespace": "testing", "name": "bar"}}},
+	} {
+		cache := New(client, tt.namespaces, labels.Everything(), fields.Everything(), false)
+		suite.Require().NoError(cache.Start(ctx))
+
+		pods, err := cache.ListPods(ctx)
+		suite.Require().NoError(err)
+		suite.AssertPods(pods, tt.expected)
+	}
+}
+
+func (suite *CacheSuite) TestListNamespaces() {
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"env": "dev"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "production", Labels: map[string]string{"env": "prod"}}},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cache := New(client, []string{v1.NamespaceAll}, labels.Everything(), fields.Everything(), true)
+	suite.Require().NoError(cache.Start(ctx))
+
+	selector, err := labels.Parse("env=dev")
+	suite.Require().NoError(err)
+
+	namespaces, err := cache.ListNamespaces(ctx, selector)
+	suite.Require().NoError(err)
+	suite.Require().Len(namespaces, 1)
+	suite.Equal("default", namespaces[0].Name)
+}
+
+func (suite *CacheSuite) TestListPodsMetadata() {
+	newMeta := func(kind, namespace, name string, labels map[string]string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: kind},
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		}
+	}
+
+	scheme := metadatafake.NewTestScheme()
+	suite.Require().NoError(metav1.AddMetaToScheme(scheme))
+	client := metadatafake.NewSimpleMetadataClient(scheme,
+		newMeta("Pod", "default", "foo", nil),
+		newMeta("Pod", "testing", "bar", nil),
+		newMeta("Namespace", "", "default", map[string]string{"env": "dev"}),
+		newMeta("Namespace", "", "testing", map[string]string{"env": "test"}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cache := NewMetadata(client, []string{v1.NamespaceAll}, labels.Everything(), fields.Everything(), v1.PodRunning, true)
+	suite.Require().NoError(cache.Start(ctx))
+
+	pods, err := cache.ListPods(ctx)
+	suite.Require().NoError(err)
+	suite.AssertPods(pods, []map[string]string{
+		{"namespace": "default", "name": "foo"},
+		{"namespace": "testing", "name": "bar"},
+	})
+	suite.Equal(v1.PodRunning, pods[0].Status.Phase)
+
+	selector, err := labels.Parse("env=test")
+	suite.Require().NoError(err)
+
+	namespaces, err := cache.ListNamespaces(ctx, selector)
+	suite.Require().NoError(err)
+	suite.Require().Len(namespaces, 1)
+	suite.Equal("testing", namespaces[0].Name)
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
@@ -0,0 +1,266 @@
+package calendar
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultTimeout is the timeout for fetching calendars over HTTP.
+var DefaultTimeout = 30 * time.Second
+
+const (
+	// dateFormat is the format of iCalendar DATE values
+	dateFormat = "20060102"
+	// dateTimeFormat is the format of iCalendar DATE-TIME values, with a trailing Z in UTC
+	dateTimeFormat = "20060102T150405"
+	// dayFormat is the format of the days kept by a Calendar
+	dayFormat = "2006-01-02"
+)
+
+// Calendar holds the days covered by the events of an iCalendar feed, e.g. company holidays or
+// change freezes, read from a URL or a file.
+type Calendar struct {
+	source   string
+	location *time.Location
+	client   *http.Client
+	logger   log.FieldLogger
+
+	mutex sync.RWMutex
+	days  map[string]bool
+}
+
+// New creates and returns an empty Calendar reading from the given URL or file. Events without
+// a time zone are interpreted in the given location.
+func New(source string, location *time.Location, logger log.FieldLogger) *Calendar {
+	return &Calendar{
+		source:   source,
+		location: location,
+		client:   &http.Client{Timeout: DefaultTimeout},
+		logger:   logger.WithField("calendar", source),
+		days:     map[string]bool{},
+	}
+}
+
+// Includes returns true iff the day of the given time in its location is covered by an event.
+func (c *Calendar) Includes(t time.Time) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.days[t.Format(dayFormat)]
+}
+
+// Days returns the number of days covered by events.
+func (c *Calendar) Days() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return len(c.days)
+}
+
+// Run refreshes the calendar at the given interval until the given context is canceled. Failed
+// refreshes are logged and keep the previous days.
+func (c *Calendar) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil {
+				c.logger.WithField("err", err).Error("failed to refresh calendar")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Refresh reads the calendar again and replaces the days covered by its events.
+func (c *Calendar) Refresh(ctx context.Context) error {
+	reader, err := c.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	days, err := parse(reader, c.location)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	c.days = days
+	c.mutex.Unlock()
+
+	c.logger.WithField("days", len(days)).Debug("refreshed calendar")
+
+	return nil
+}
+
+// open opens the source of the calendar, fetching it if it's a URL.
+func (c *Calendar) open(ctx context.Context) (io.ReadCloser, error) {
+	if !strings.HasPrefix(c.source, "http://") && !strings.HasPrefix(c.source, "https://") {
+		return os.Open(c.source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	return res.Body, nil
+}
+
+// parse returns the days covered by the events of the given iCalendar feed. Recurring events
+// are only taken into account with their first occurrence.
+func parse(reader io.Reader, location *time.Location) (map[string]bool, error) {
+	lines, err := unfold(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	days := map[string]bool{}
+
+	var (
+		inEvent    bool
+		start, end string
+		startTZ    string
+		endTZ      string
+	)
+	for _, line := range lines {
+		name, params, value := property(line)
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent = true
+			start, end, startTZ, endTZ = "", "", "", ""
+		case name == "END" && value == "VEVENT":
+			inEvent = false
+			if start == "" {
+				continue
+			}
+			from, until, err := eventDays(start, startTZ, end, endTZ, location)
+			if err != nil {
+				return nil, err
+			}
+			for day := from; day.Before(until); day = day.AddDate(0, 0, 1) {
+				days[day.Format(dayFormat)] = true
+			}
+		case inEvent && name == "DTSTART":
+			start, startTZ = value, params["TZID"]
+		case inEvent && name == "DTEND":
+			end, endTZ = value, params["TZID"]
+		}
+	}
+
+	return days, nil
+}
+
+// eventDays returns the first day covered by an event and the day after its last one, both at
+// midnight in the given location.
+func eventDays(start, startTZ, end, endTZ string, location *time.Location) (time.Time, time.Time, error) {
+	from, allDay, err := parseTime(start, startTZ, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	from = midnight(from)
+
+	// events without an end cover the day they start on
+	if end == "" {
+		return from, from.AddDate(0, 0, 1), nil
+	}
+
+	until, _, err := parseTime(end, endTZ, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	// the end of all-day events is exclusive already, other events cover the day they end on
+	// unless they end at midnight
+	if !allDay && !until.Equal(midnight(until)) {
+		until = midnight(until).AddDate(0, 0, 1)
+	}
+	return from, midnight(until), nil
+}
+
+// parseTime parses an iCalendar DATE or DATE-TIME value in the given time zone, if any, and
+// returns it in the given location along with whether it was a DATE.
+func parseTime(value, tzid string, location *time.Location) (time.Time, bool, error) {
+	if len(value) == len(dateFormat) {
+		t, err := time.ParseInLocation(dateFormat, value, location)
+		return t, true, err
+	}
+
+	zone := location
+	if strings.HasSuffix(value, "Z") {
+		value, zone = strings.TrimSuffix(value, "Z"), time.UTC
+	} else if tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			zone = tz
+		}
+	}
+
+	t, err := time.ParseInLocation(dateTimeFormat, value, zone)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t.In(location), false, nil
+}
+
+// midnight returns the start of the day of the given time in its location.
+func midnight(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// unfold reads the content lines of an iCalendar feed, joining lines continued on the next
+// line by a leading space or tab.
+func unfold(reader io.Reader) ([]string, error) {
+	lines := []string{}
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// property splits a content line into its upper-cased name, parameters and value, e.g.
+// DTSTART;TZID=Europe/Berlin:20241224T120000.
+func property(line string) (string, map[string]string, string) {
+	head, value, _ := strings.Cut(line, ":")
+
+	parts := strings.Split(head, ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+
+	return strings.ToUpper(parts[0]), params, strings.TrimSpace(value)
+}
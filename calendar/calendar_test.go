@@ -0,0 +1,112 @@
+package calendar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CalendarSuite struct {
+	testutil.TestSuite
+}
+
+var logger, logOutput = test.NewNullLogger()
+
+func (suite *CalendarSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+const feed = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Christmas\r\n" +
+	"DTSTART;VALUE=DATE:20241224\r\n" +
+	"DTEND;VALUE=DATE:20241227\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Release freeze, folded\r\n" +
+	"  across two lines\r\n" +
+	"DTSTART;TZID=America/New_York:20241105T\r\n" +
+	" 200000\r\n" +
+	"DTEND;TZID=America/New_York:20241105T230000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Maintenance until midnight\r\n" +
+	"DTSTART:20241001T080000Z\r\n" +
+	"DTEND:20241002T000000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Company day\r\n" +
+	"DTSTART;VALUE=DATE:20240614\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func (suite *CalendarSuite) TestIncludes() {
+	path := filepath.Join(suite.T().TempDir(), "holidays.ics")
+	suite.Require().NoError(os.WriteFile(path, []byte(feed), 0644))
+
+	calendar := New(path, time.UTC, logger)
+	suite.Require().NoError(calendar.Refresh(context.Background()))
+	suite.Equal(6, calendar.Days())
+
+	for _, tt := range []struct {
+		day      time.Time
+		expected bool
+	}{
+		{time.Date(2024, 12, 23, 12, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 12, 26, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 12, 27, 12, 0, 0, 0, time.UTC), false},
+		// 20:00 to 23:00 in New York is the next day in UTC
+		{time.Date(2024, 11, 5, 12, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 11, 6, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 10, 2, 12, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 6, 14, 12, 0, 0, 0, time.UTC), true},
+		// the same day next year isn't covered
+		{time.Date(2025, 12, 24, 12, 0, 0, 0, time.UTC), false},
+	} {
+		suite.Equal(tt.expected, calendar.Includes(tt.day), tt.day.String())
+	}
+}
+
+func (suite *CalendarSuite) TestRefreshFromURL() {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(status)
+		_, err := res.Write([]byte(feed))
+		suite.Require().NoError(err)
+	}))
+	defer server.Close()
+
+	calendar := New(server.URL, time.UTC, logger)
+	suite.Require().NoError(calendar.Refresh(context.Background()))
+	suite.True(calendar.Includes(time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC)))
+
+	// failed refreshes keep the previous days
+	status = http.StatusInternalServerError
+	suite.EqualError(calendar.Refresh(context.Background()), "unexpected status code: 500")
+	suite.True(calendar.Includes(time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC)))
+}
+
+func (suite *CalendarSuite) TestRefreshMissingFile() {
+	calendar := New(filepath.Join(suite.T().TempDir(), "missing.ics"), time.UTC, logger)
+	suite.Error(calendar.Refresh(context.Background()))
+	suite.Zero(calendar.Days())
+}
+
+func TestCalendarSuite(t *testing.T) {
+	suite.Run(t, new(CalendarSuite))
+}
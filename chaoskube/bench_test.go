@@ -0,0 +1,169 @@
+package chaoskube
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/notifier"
+	"github.com/linki/chaoskube/terminator"
+	"github.com/linki/chaoskube/util"
+)
+
+// benchmarkSizes are the cluster sizes, in pods, to run the benchmarks against.
+var benchmarkSizes = []int{1000, 10000, 100000}
+
+// generatePods returns a synthetic cluster of n pods spread over 100 namespaces and owners of
+// ten pods each, with a mix of phases, labels, annotations and ages.
+func generatePods(n int) []v1.Pod {
+	tiers := []string{"frontend", "backend", "database"}
+	old := metav1.NewTime(time.Now().Add(-24 * time.Hour))
+	young := metav1.NewTime(time.Now())
+
+	pods := make([]v1.Pod, 0, n)
+	for i := 0; i < n; i++ {
+		namespace := fmt.Sprintf("namespace-%d", i%100)
+		owner := types.UID(fmt.Sprintf("owner-%d", i/10))
+
+		pod := util.NewPodWithOwner(namespace, fmt.Sprintf("pod-%d", i), v1.PodRunning, owner)
+		pod.Labels = map[string]string{"app": fmt.Sprintf("app-%d", i/10), "tier": tiers[i%len(tiers)]}
+		pod.Annotations = map[string]string{"chaos.alpha.kubernetes.io/enabled": fmt.Sprintf("%t", i%4 != 0)}
+
+		pod.CreationTimestamp = old
+		if i%10 == 0 {
+			pod.CreationTimestamp = young
+		}
+		if i%20 == 0 {
+			pod.Status.Phase = v1.PodPending
+		}
+
+		pods = append(pods, pod)
+	}
+
+	return pods
+}
+
+// newBenchmarkChaoskube returns a Chaoskube that lists the given pods without an API server and
+// applies a typical set of filters.
+func newBenchmarkChaoskube(pods []v1.Pod) *Chaoskube {
+	client := fake.NewSimpleClientset()
+	nullLogger, _ := test.NewNullLogger()
+
+	chaoskube := New(
+		client,
+		labels.Everything(),
+		labels.SelectorFromSet(labels.Set{"chaos.alpha.kubernetes.io/enabled": "true"}),
+		labels.Everything(),
+		parseBenchmarkSelector("!namespace-0"),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Hour,
+		nullLogger,
+		true,
+		terminator.NewDeletePodTerminator(client, nullLogger, 10*time.Second),
+		1,
+		&notifier.Noop{},
+		v1.NamespaceAll,
+		false,
+		1.0,
+		10*time.Minute,
+	)
+	chaoskube.Lister = staticLister(pods)
+
+	return chaoskube
+}
+
+func parseBenchmarkSelector(str string) labels.Selector {
+	selector, err := labels.Parse(str)
+	if err != nil {
+		panic(err)
+	}
+	return selector
+}
+
+func BenchmarkCandidates(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("pods=%d", size), func(b *testing.B) {
+			chaoskube := newBenchmarkChaoskube(generatePods(size))
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := chaoskube.Candidates(context.Background()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkVictims(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("pods=%d", size), func(b *testing.B) {
+			chaoskube := newBenchmarkChaoskube(generatePods(size))
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := chaoskube.Victims(context.Background()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFilters(b *testing.B) {
+	annotations := labels.SelectorFromSet(labels.Set{"chaos.alpha.kubernetes.io/enabled": "true"})
+	namespaces := parseBenchmarkSelector("!namespace-0")
+	kinds := parseBenchmarkSelector("testkind")
+	ctx := context.Background()
+	now := time.Now()
+	rnd := util.NewRand(0)
+
+	for _, size := range benchmarkSizes {
+		pods := generatePods(size)
+
+		for _, filter := range []struct {
+			name   string
+			filter func(pods []v1.Pod) []v1.Pod
+		}{
+			{"namespaces", func(pods []v1.Pod) []v1.Pod { pods, _ = filterByNamespaces(pods, namespaces); return pods }},
+			{"kinds", func(pods []v1.Pod) []v1.Pod { pods, _ = filterByKinds(ctx, pods, kinds, nil); return pods }},
+			{"annotations", func(pods []v1.Pod) []v1.Pod { return filterByAnnotations(pods, annotations) }},
+			{"phase", func(pods []v1.Pod) []v1.Pod { return filterByPhase(pods, v1.PodRunning) }},
+			{"min-age", func(pods []v1.Pod) []v1.Pod { return filterByMinimumAge(pods, time.Hour, now) }},
+			{"owner-ref", func(pods []v1.Pod) []v1.Pod { return filterByOwnerReference(pods, 1, false, rnd) }},
+			{"static-pods", filterStaticPods},
+		} {
+			b.Run(fmt.Sprintf("filter=%s/pods=%d", filter.name, size), func(b *testing.B) {
+				// filters work in place, so each iteration gets its own copy
+				input := make([]v1.Pod, len(pods))
+
+				b.ReportAllocs()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					copy(input, pods)
+					filter.filter(input)
+				}
+			})
+		}
+	}
+}
@@ -4,29 +4,54 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
+	"math/rand"
+	"reflect"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	multierror "github.com/hashicorp/go-multierror"
 
 	log "github.com/sirupsen/logrus"
 
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/tools/reference"
 
+	"github.com/linki/chaoskube/approval"
+	"github.com/linki/chaoskube/budget"
+	"github.com/linki/chaoskube/history"
 	"github.com/linki/chaoskube/metrics"
 	"github.com/linki/chaoskube/notifier"
+	"github.com/linki/chaoskube/replay"
+	"github.com/linki/chaoskube/snapshot"
+	"github.com/linki/chaoskube/strategy"
 	"github.com/linki/chaoskube/terminator"
+	"github.com/linki/chaoskube/trigger"
 	"github.com/linki/chaoskube/util"
+	"github.com/linki/chaoskube/workload"
 )
 
 // Chaoskube represents an instance of chaoskube
@@ -35,24 +60,52 @@ type Chaoskube struct {
 	Client kubernetes.Interface
 	// a label selector which restricts the pods to choose from
 	Labels labels.Selector
+	// label selectors of which at least one must match, evaluated by chaoskube since the API
+	// server has no notion of OR-ed selectors
+	AnyLabels []labels.Selector
+	// the name of a Helm release whose pods to target, by their app.kubernetes.io/instance label
+	HelmRelease string
+	// the name of a Helm chart whose pods to target, by their helm.sh/chart label
+	HelmChart string
 	// an annotation selector which restricts the pods to choose from
 	Annotations labels.Selector
-	// a kind label selector which restricts the kinds to choose from
+	// regular expressions the values of the given annotation keys must match, e.g. owner-team=payments-.*
+	AnnotationPatterns map[string]*regexp.Regexp
+	// a kind label selector which restricts the kinds to choose from, see ParseKinds
 	Kinds labels.Selector
 	// a namespace selector which restricts the pods to choose from
 	Namespaces labels.Selector
 	// a namespace label selector which restricts the namespaces to choose from
 	NamespaceLabels labels.Selector
+	// scale the selection probability of namespaces by their chaos level annotation
+	NamespaceLevels bool
+	// skip pods whose own, owner's or namespace's snooze annotation lies in the future
+	Snooze bool
+	// a field selector which restricts the pods to choose from, e.g. spec.nodeName=node-1
+	FieldSelector fields.Selector
+	// a regular expression for namespace names to include
+	IncludedNamespaceNames *regexp.Regexp
+	// a regular expression for namespace names to exclude
+	ExcludedNamespaceNames *regexp.Regexp
 	// a regular expression for pod names to include
 	IncludedPodNames *regexp.Regexp
 	// a regular expression for pod names to exclude
 	ExcludedPodNames *regexp.Regexp
+	// a regular expression for container images to include, e.g. to target a library rollout
+	IncludedImages *regexp.Regexp
+	// a regular expression for container images to exclude
+	ExcludedImages *regexp.Regexp
 	// a list of weekdays when termination is suspended
 	ExcludedWeekdays []time.Weekday
 	// a list of time periods of a day when termination is suspended
 	ExcludedTimesOfDay []util.TimePeriod
 	// a list of days of a year when termination is suspended
 	ExcludedDaysOfYear []time.Time
+	// an optional calendar of days when termination is suspended, e.g. holidays from an iCal feed
+	ExcludedDaysCalendar DayCalendar
+	// if any, the weekdays and time periods of a day outside of which termination is suspended
+	IncludedWeekdays   []time.Weekday
+	IncludedTimesOfDay []util.TimePeriod
 	// the timezone to apply when detecting the current weekday
 	Timezone *time.Location
 	// minimum age of pods to consider
@@ -63,23 +116,370 @@ type Chaoskube struct {
 	Terminator terminator.Terminator
 	// dry run will not allow any pod terminations
 	DryRun bool
+	// in dry-run mode, still send deletions to the terminator, which must issue them as server-side dry runs
+	ServerDryRun bool
 	// grace period to terminate the pods
 	GracePeriod time.Duration
 	// event recorder allows to publish events to Kubernetes
 	EventRecorder record.EventRecorder
 	// a function to retrieve the current time
 	Now func() time.Time
+	// the random source used to select victims, see util.NewRand
+	Rand *rand.Rand
+	// the random source used to draw Poisson intervals, kept apart from Rand since the ticker
+	// draws concurrently to the selection, which would otherwise not be reproducible by seed
+	IntervalRand *rand.Rand
 
 	MaxKill int
+	// percentage of the candidates to terminate per interval, rounded up, overrides MaxKill if positive
+	MaxKillPercent float64
+	// bounds of the number of victims per interval resulting from MaxKillPercent, where the lower
+	// bound is at least one and zero means no upper bound
+	MaxKillMin int
+	MaxKillMax int
+	// maximum number of pods of the same owner to terminate per interval, defaults to one. It's
+	// ignored by strategies that take care of owners themselves.
+	MaxKillPerOwner int
+	// prefer the pods of an owner with the lowest pod deletion cost, like the ReplicaSet
+	// controller does when scaling down
+	PreferLowDeletionCost bool
+	// number of further candidates on the same node to terminate along with each victim
+	ColocatedBlast int
+	// the strategy to select victims from the candidates, defaults to uniformly at random
+	Strategy strategy.Strategy
+	// number of victims to terminate concurrently
+	TerminationWorkers int
 	// chaos events notifier
 	Notifier notifier.Notifier
-	// namespace scope for the Kubernetes client
+	// namespace scope for the Kubernetes client, a comma-separated list of namespaces
 	ClientNamespaceScope string
+	// number of namespaces in scope to list concurrently
+	ListParallelism int
 
 	// Dynamic interval configuration
 	DynamicInterval       bool
 	DynamicIntervalFactor float64
 	BaseInterval          time.Duration
+	// the working window of the dynamic interval, i.e. the days per week and hours per day chaos
+	// happens, and the fraction of the candidates to terminate within a window
+	DynamicIntervalWorkingDays  float64
+	DynamicIntervalWorkingHours float64
+	DynamicIntervalTarget       float64
+	// the weight of each new pod count in the moving average the dynamic interval is based on,
+	// between zero and one, where zero disables smoothing
+	DynamicIntervalSmoothing float64
+	// optional intervals for individual weekdays in Timezone, replacing the fixed or dynamic interval
+	IntervalOverrides map[time.Weekday]time.Duration
+	// list calls slower than this stretch the interval, zero means only throttled calls do
+	SlowListThreshold time.Duration
+	// an optional cron schedule in Timezone to terminate victims at instead of every interval
+	Schedule *util.Schedule
+	// whether to draw the time between terminations from an exponential distribution with the
+	// interval as its mean, so that terminations follow a Poisson process
+	Poisson bool
+
+	// an optional external query that restricts the pods to choose from
+	CandidateQuery PodQuerier
+	// an optional per-pod check whether a pod remains a candidate, e.g. a templated PromQL expression
+	CandidateMatcher PodMatcher
+
+	// an optional provider of pod costs used during victim selection
+	CostProvider CostProvider
+	// prefer expensive pods when selecting victims
+	CostWeighting bool
+	// maximum total cost of pods to kill per day, zero means unlimited
+	MaxCostPerDay float64
+
+	// a dynamic kubernetes client object to access custom resources
+	DynamicClient dynamic.Interface
+	// how to treat pods managed by Argo Rollouts
+	ArgoRollouts string
+	// pause chaos for workloads while a Flagger canary analysis is in progress
+	PauseDuringCanary bool
+	// how to treat pods on virtual nodes, e.g. virtual-kubelet or AWS Fargate
+	VirtualNodes string
+	// only target pods on nodes matching this label selector, e.g. of a spot node pool
+	NodeLabels labels.Selector
+	// only target pods on nodes whose name matches this regular expression
+	NodeNames *regexp.Regexp
+	// skip pods whose top-level workload has fewer ready replicas than this, zero disables
+	MinOwnerReady int
+	// never take the last pod of an owner in a topology zone, so owners keep covering their zones
+	PreserveZoneCoverage bool
+	// skip pods whose top-level workload is rolling out a change
+	SkipRollouts bool
+	// skip pods mounting PersistentVolumeClaims, of the given access modes if any
+	ExcludePVCs    bool
+	PVCAccessModes []v1.PersistentVolumeAccessMode
+	// skip pods that are the only ready endpoint of a Service
+	ProtectSoleEndpoints bool
+	// also target pods of DaemonSets, which are excluded by default
+	IncludeDaemonSets bool
+	// leave pods alone unless they or their namespace opt in with the enabled annotation,
+	// instead of targeting them unless they opt out
+	DefaultOptOut bool
+	// also target static pods (mirror pods), which are excluded by default
+	IncludeStaticPods bool
+	// also target pods that the cluster autoscaler or Karpenter must not disrupt
+	IgnoreDisruptionAnnotations bool
+	// delete pods older than the maximum lifetime they declare with the max-lifetime annotation
+	// in each interval, in addition to the randomly selected victims
+	EnforceMaxLifetime bool
+	// a selector of lower-case QoS classes to include or exclude, e.g. !guaranteed
+	QOSClasses labels.Selector
+	// only target pods of these priority classes, if any, and never pods of the excluded ones
+	IncludedPriorityClasses []string
+	ExcludedPriorityClasses []string
+	// only target pods running as these service accounts, if any, and never as the excluded ones
+	IncludedServiceAccounts []string
+	ExcludedServiceAccounts []string
+	// only target pods whose Ready condition is true
+	OnlyReady bool
+	// only target pods requesting at least and at most these resources in total, e.g. cpu=500m
+	MinRequests v1.ResourceList
+	MaxRequests v1.ResourceList
+	// skip crash-looping pods and pods with a container that restarted more than
+	// MaxContainerRestarts times and last terminated within RestartWindow, zero disables
+	MaxContainerRestarts int
+	RestartWindow        time.Duration
+	// in which order to target the pods of StatefulSets
+	StatefulSets string
+	// skip pods of Jobs that progressed this far towards their completions or deadline, zero disables
+	JobProgressThreshold float64
+
+	// an optional lister, e.g. an informer cache, to use instead of listing from the API server
+	Lister Lister
+	// an optional metadata client to list only the metadata of pods and namespaces
+	MetadataClient metadata.Interface
+
+	// an optional history of recent terminations
+	History *history.History
+	// an optional limit of terminations per hour and day across intervals, triggers and restarts
+	Budget *budget.Budget
+	// minimum time between terminations of pods of the same owner, requires History
+	Cooldown time.Duration
+	// honor the minimum interval annotation of top-level workloads, requires History
+	WorkloadIntervals bool
+	// number of log lines per container to fetch from victims before terminating them, zero disables it
+	LogTailLines int64
+	// stamp the time, run ID and result of each termination onto the victim's top-level workload
+	StampOwners bool
+	// identifies this run of chaoskube in the stamped annotations
+	RunID string
+
+	// an optional recorder of the candidates and victims of each interval
+	Recorder *replay.Recorder
+	// an optional player of recorded candidates to select victims from instead of the cluster
+	Player *replay.Player
+	// an optional store for the manifests and events of victims, captured before their termination
+	Snapshots snapshot.Store
+	// an optional approver that must approve victims before they are terminated
+	Approver approval.Approver
+
+	// caches the top-level workloads of pods for the kind filter across ticks
+	kindCache *workload.Resolver
+
+	// guards the interval stretching below
+	pressureMutex sync.Mutex
+	// the number of consecutive slow or throttled list calls
+	slowLists int
+	// the factor the interval is currently stretched by, zero meaning one
+	intervalStretch float64
+
+	// guards the pod count smoothing below
+	podCountMutex sync.Mutex
+	// the moving average of the pod counts the dynamic interval is based on
+	smoothedPodCount float64
+	// the number of pod counts folded into the moving average
+	podCountSamples int
+
+	// guards the StatefulSet ordinals below
+	ordinalMutex sync.Mutex
+	// the ordinal to target next per StatefulSet UID, in descending order
+	nextOrdinals map[types.UID]int
+
+	// guards the daily cost accounting below
+	costMutex sync.Mutex
+	// the cost of pods killed so far on costDay
+	costSpent float64
+	costDay   string
+	// the costs of the last selected victims, spent once they are terminated
+	victimCosts map[string]float64
+
+	// guards the status below
+	statusMutex sync.Mutex
+	// the keys of the candidates found in the last interval
+	lastCandidates []string
+	// the time of the next scheduled termination
+	nextTick time.Time
+	// suspends all terminations while set
+	paused bool
+	// the time the last interval completed
+	lastTick time.Time
+	// the time the last pod was terminated
+	lastKill time.Time
+}
+
+// Status is a snapshot of the state of a Chaoskube instance, e.g. for display.
+type Status struct {
+	// the namespace/name of the candidates found in the last interval
+	Candidates []string
+	// the time of the next scheduled termination, zero if not scheduled by a ticker
+	NextTick time.Time
+	// the most recent terminations, oldest first, if a History is kept
+	Victims []history.Entry
+	// whether terminations are suspended
+	Paused bool
+}
+
+// PodQuerier returns a set of pods, keyed by namespace/name, selected by an external system,
+// e.g. the result of a PromQL expression.
+type PodQuerier interface {
+	Pods(ctx context.Context) (map[string]struct{}, error)
+}
+
+// PodMatcher decides for a single pod whether it remains a candidate, e.g. by evaluating a PromQL
+// expression templated with the pod.
+type PodMatcher interface {
+	Matches(ctx context.Context, pod v1.Pod) (bool, error)
+}
+
+// Lister lists the pods, as restricted by the namespace scope, label and field selectors, and
+// the namespaces chaoskube chooses from. The returned pods are filtered in place, so each call
+// must return a new slice.
+type Lister interface {
+	ListPods(ctx context.Context) ([]v1.Pod, error)
+	ListNamespaces(ctx context.Context, selector labels.Selector) ([]v1.Namespace, error)
+}
+
+// apiLister is a Lister that queries the API server on every call.
+type apiLister struct {
+	client      kubernetes.Interface
+	namespaces  []string
+	parallelism int
+	labels      labels.Selector
+	fields      fields.Selector
+}
+
+func (l apiLister) ListPods(ctx context.Context) ([]v1.Pod, error) {
+	listOptions := metav1.ListOptions{LabelSelector: l.labels.String(), FieldSelector: l.fields.String()}
+
+	return listInParallel(ctx, l.namespaces, l.parallelism, func(ctx context.Context, namespace string) ([]v1.Pod, error) {
+		podList, err := l.client.CoreV1().Pods(namespace).List(ctx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		return podList.Items, nil
+	})
+}
+
+func (l apiLister) ListNamespaces(ctx context.Context, selector labels.Selector) ([]v1.Namespace, error) {
+	listOptions := metav1.ListOptions{LabelSelector: selector.String()}
+
+	namespaceList, err := l.client.CoreV1().Namespaces().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return namespaceList.Items, nil
+}
+
+// metadataLister is a Lister that only queries the metadata of pods and namespaces on every call.
+// Since pods are always listed with a field selector on their phase, they are marked as running.
+type metadataLister struct {
+	client      metadata.Interface
+	namespaces  []string
+	parallelism int
+	labels      labels.Selector
+	fields      fields.Selector
+}
+
+func (l metadataLister) ListPods(ctx context.Context) ([]v1.Pod, error) {
+	listOptions := metav1.ListOptions{LabelSelector: l.labels.String(), FieldSelector: l.fields.String()}
+
+	return listInParallel(ctx, l.namespaces, l.parallelism, func(ctx context.Context, namespace string) ([]v1.Pod, error) {
+		podList, err := l.client.Resource(podsResource).Namespace(namespace).List(ctx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		pods := make([]v1.Pod, 0, len(podList.Items))
+		for _, item := range podList.Items {
+			pods = append(pods, util.PodFromMetadata(item, v1.PodRunning))
+		}
+		return pods, nil
+	})
+}
+
+// listInParallel calls list for each of the given namespaces, with at most parallelism calls at
+// a time, and returns the pods of all namespaces in the order of the namespaces.
+func listInParallel(ctx context.Context, namespaces []string, parallelism int, list func(ctx context.Context, namespace string) ([]v1.Pod, error)) ([]v1.Pod, error) {
+	if len(namespaces) == 1 {
+		return list(ctx, namespaces[0])
+	}
+
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results := make([][]v1.Pod, len(namespaces))
+	errs := make([]error, len(namespaces))
+	slots := make(chan struct{}, parallelism)
+
+	var wg sync.WaitGroup
+	for i, namespace := range namespaces {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i], errs[i] = list(ctx, namespace)
+		}()
+	}
+	wg.Wait()
+
+	var result *multierror.Error
+	total := 0
+	for i := range namespaces {
+		result = multierror.Append(result, errs[i])
+		total += len(results[i])
+	}
+	if err := result.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	pods := make([]v1.Pod, 0, total)
+	for _, namespacePods := range results {
+		pods = append(pods, namespacePods...)
+	}
+
+	return pods, nil
+}
+
+func (l metadataLister) ListNamespaces(ctx context.Context, selector labels.Selector) ([]v1.Namespace, error) {
+	listOptions := metav1.ListOptions{LabelSelector: selector.String()}
+
+	namespaceList, err := l.client.Resource(namespacesResource).List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]v1.Namespace, 0, len(namespaceList.Items))
+	for _, item := range namespaceList.Items {
+		namespaces = append(namespaces, v1.Namespace{ObjectMeta: item.ObjectMeta})
+	}
+
+	return namespaces, nil
+}
+
+// DayCalendar tells whether the day of a given time is covered by a calendar, e.g. a holiday.
+type DayCalendar interface {
+	Includes(t time.Time) bool
+}
+
+// CostProvider returns the cost of pods, keyed by namespace/name, e.g. as reported by OpenCost.
+type CostProvider interface {
+	PodCosts(ctx context.Context) (map[string]float64, error)
 }
 
 var (
@@ -89,14 +489,113 @@ var (
 	msgVictimNotFound = "no victim found"
 	// msgWeekdayExcluded is the log message when termination is suspended due to the weekday filter
 	msgWeekdayExcluded = "weekday excluded"
+	// msgWeekdayNotIncluded is the log message when termination is suspended since the weekday isn't included
+	msgWeekdayNotIncluded = "weekday not included"
 	// msgTimeOfDayExcluded is the log message when termination is suspended due to the time of day filter
 	msgTimeOfDayExcluded = "time of day excluded"
+	// msgTimeOfDayNotIncluded is the log message when termination is suspended since the time of day isn't included
+	msgTimeOfDayNotIncluded = "time of day not included"
+	// msgPodReplaced is the log message when a victim was replaced by a pod of the same name before its termination
+	msgPodReplaced = "pod was replaced since its selection, skipping"
+	// msgNotApproved is the log message when the victims weren't approved for termination
+	msgNotApproved = "victims not approved"
+	// msgStalled is the log message when the watchdog detects a stall
+	msgStalled = "chaoskube stalled"
+	// msgBudgetExhausted is the log message when victims are spared since the kill budget is exhausted
+	msgBudgetExhausted = "kill budget exhausted"
+	// msgKindsUnresolved is the log message when the workloads of some pods couldn't be resolved for the kind filter
+	msgKindsUnresolved = "failed to resolve workloads for kind filter, leaving their pods out"
+	// msgPaused is the log message when termination is suspended by a pause
+	msgPaused = "chaos paused"
 	// msgDayOfYearExcluded is the log message when termination is suspended due to the day of year filter
 	msgDayOfYearExcluded = "day of year excluded"
+	// msgCalendarExcluded is the log message when termination is suspended due to the calendar of excluded days
+	msgCalendarExcluded = "day excluded by calendar"
+	// maxLogTailBytes is the maximum size of the logs fetched per container of a victim
+	maxLogTailBytes = int64(16 * 1024)
+	// kindResolutionTTL is how long the top-level workloads resolved for the kind filter are cached
+	kindResolutionTTL = 5 * time.Minute
+	// maxIntervalStretch is the maximum factor the interval is stretched by under API server pressure
+	maxIntervalStretch = 8.0
+	// levelAnnotation is the annotation key for the chaos level of a namespace
+	levelAnnotation = "chaos.alpha.kubernetes.io/level"
+	// probabilityAnnotation is the annotation key for the chance of a pod to remain a candidate
+	probabilityAnnotation = "chaos.alpha.kubernetes.io/probability"
+	// enabledAnnotation is the annotation key for pods and namespaces to opt in or out of chaos
+	enabledAnnotation = "chaos.alpha.kubernetes.io/enabled"
+	// maxLifetimeAnnotation is the annotation key for the maximum age of a pod, e.g. 72h
+	maxLifetimeAnnotation = "chaos.alpha.kubernetes.io/max-lifetime"
+	// snoozeAnnotation is the annotation key for the time until which chaos is suspended
+	snoozeAnnotation = "chaos.alpha.kubernetes.io/snooze-until"
+	// minIntervalAnnotation is the annotation key for the minimum time between terminations of a workload
+	minIntervalAnnotation = "chaos.alpha.kubernetes.io/min-interval"
+	// lastChaosAtAnnotation is the annotation key for the time a workload's pod was last terminated
+	lastChaosAtAnnotation = "chaos.alpha.kubernetes.io/last-chaos-at"
+	// lastChaosRunIDAnnotation is the annotation key for the run of chaoskube that last terminated a workload's pod
+	lastChaosRunIDAnnotation = "chaos.alpha.kubernetes.io/last-chaos-run-id"
+	// lastChaosResultAnnotation is the annotation key for the result of the last termination of a workload's pod
+	lastChaosResultAnnotation = "chaos.alpha.kubernetes.io/last-chaos-result"
+	// helmReleaseLabel is the label key for the Helm release of a pod
+	helmReleaseLabel = "app.kubernetes.io/instance"
+	// helmChartLabel is the label key for the Helm chart and its version of a pod
+	helmChartLabel = "helm.sh/chart"
+	// helmChartVersion matches the version at the end of a chart label, e.g. -1.2.3 or -1.2.3-rc.1
+	helmChartVersion = regexp.MustCompile(`-v?[0-9]+\.[0-9]+\.[0-9]+.*$`)
+	// safeToEvictAnnotation is the annotation key the cluster autoscaler checks before evicting a pod
+	safeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+	// doNotDisruptAnnotation is the annotation key Karpenter checks before disrupting a pod
+	doNotDisruptAnnotation = "karpenter.sh/do-not-disrupt"
+	// deletionCostAnnotation is the annotation key for the cost of deleting a pod of a ReplicaSet
+	deletionCostAnnotation = "controller.kubernetes.io/pod-deletion-cost"
 	// mirrorPodAnnotation is the annotation key for static pods
 	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+	// rolloutsPodTemplateHashLabel is the label Argo Rollouts puts on the pods it manages
+	rolloutsPodTemplateHashLabel = "rollouts-pod-template-hash"
+	// rolloutsResource is the resource of Argo Rollouts
+	rolloutsResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+	// podsResource is the resource of pods
+	podsResource = v1.SchemeGroupVersion.WithResource("pods")
+	// namespacesResource is the resource of namespaces
+	namespacesResource = v1.SchemeGroupVersion.WithResource("namespaces")
+	// canariesResource is the resource of Flagger canaries
+	canariesResource = schema.GroupVersionResource{Group: "flagger.app", Version: "v1beta1", Resource: "canaries"}
+	// canaryPhasesInProgress are the phases of a Flagger canary during which an analysis is running
+	canaryPhasesInProgress = map[string]bool{
+		"Progressing":      true,
+		"Promoting":        true,
+		"Finalising":       true,
+		"Waiting":          true,
+		"WaitingPromotion": true,
+	}
 )
 
+const (
+	// ArgoRolloutsIgnore treats pods managed by Argo Rollouts like any other pod.
+	ArgoRolloutsIgnore = "ignore"
+	// ArgoRolloutsSkipCanary skips canary and preview pods while a rollout is in progress.
+	ArgoRolloutsSkipCanary = "skip-canary"
+	// ArgoRolloutsStableOnly only targets pods of a rollout's stable ReplicaSet.
+	ArgoRolloutsStableOnly = "stable-only"
+
+	// VirtualNodesInclude treats pods on virtual nodes like any other pod.
+	VirtualNodesInclude = "include"
+	// VirtualNodesExclude skips pods on virtual nodes, e.g. virtual-kubelet or AWS Fargate.
+	VirtualNodesExclude = "exclude"
+	// VirtualNodesOnly only targets pods on virtual nodes.
+	VirtualNodesOnly = "only"
+
+	// StatefulSetsAny treats pods of StatefulSets like any other pod.
+	StatefulSetsAny = "any"
+	// StatefulSetsHighestOrdinal only targets the pod with the highest ordinal of a StatefulSet.
+	StatefulSetsHighestOrdinal = "highest-ordinal"
+	// StatefulSetsDescending targets the pods of a StatefulSet from the highest to the lowest ordinal.
+	StatefulSetsDescending = "descending"
+)
+
+// janitorReasonStuck is the reason the janitor cleans up pods stuck terminating. Pods lingering
+// in a terminal phase are cleaned up with their lower-cased phase as reason.
+const janitorReasonStuck = "terminating"
+
 // New returns a new instance of Chaoskube. It expects:
 // * a Kubernetes client to connect to a Kubernetes API
 // * label, annotation and/or namespace selectors to reduce the amount of possible target pods
@@ -107,7 +606,13 @@ var (
 // * whether to enable/disable dry-run mode
 func New(client kubernetes.Interface, labels, annotations, kinds, namespaces, namespaceLabels labels.Selector, includedPodNames, excludedPodNames *regexp.Regexp, excludedWeekdays []time.Weekday, excludedTimesOfDay []util.TimePeriod, excludedDaysOfYear []time.Time, timezone *time.Location, minimumAge time.Duration, logger log.FieldLogger, dryRun bool, terminator terminator.Terminator, maxKill int, notifier notifier.Notifier, clientNamespaceScope string, dynamicInterval bool, dynamicIntervalFactor float64, baseInterval time.Duration) *Chaoskube {
 	broadcaster := record.NewBroadcaster()
-	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events(clientNamespaceScope)})
+	// events can only be recorded in a single namespace or all of them
+	eventNamespace := v1.NamespaceAll
+	if scope := util.ParseNamespaceScope(clientNamespaceScope); len(scope) == 1 {
+		eventNamespace = scope[0]
+	}
+
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events(eventNamespace)})
 	recorder := broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "chaoskube"})
 
 	return &Chaoskube{
@@ -129,25 +634,26 @@ func New(client kubernetes.Interface, labels, annotations, kinds, namespaces, na
 		Terminator:            terminator,
 		EventRecorder:         recorder,
 		Now:                   time.Now,
+		Rand:                  util.NewRand(time.Now().UnixNano()),
+		IntervalRand:          util.NewRand(time.Now().UnixNano()),
 		MaxKill:               maxKill,
 		Notifier:              notifier,
 		ClientNamespaceScope:  clientNamespaceScope,
 		DynamicInterval:       dynamicInterval,
 		DynamicIntervalFactor: dynamicIntervalFactor,
 		BaseInterval:          baseInterval,
+
+		DynamicIntervalWorkingDays:  5,
+		DynamicIntervalWorkingHours: 8,
+		DynamicIntervalTarget:       0.5,
+
+		kindCache: workload.NewCachingResolver(client, kindResolutionTTL),
 	}
 }
 
 // NewTicker creates a ticker channel that handles both fixed and dynamic intervals.
 // It returns a channel that sends ticks and a stop function to clean up resources.
 func (c *Chaoskube) NewTicker(ctx context.Context) (<-chan time.Time, func()) {
-	if !c.DynamicInterval {
-		// Use fixed interval ticker
-		ticker := time.NewTicker(c.BaseInterval)
-		return ticker.C, ticker.Stop
-	}
-
-	// Use dynamic interval
 	tickerChan := make(chan time.Time)
 	stopChan := make(chan struct{})
 
@@ -155,10 +661,14 @@ func (c *Chaoskube) NewTicker(ctx context.Context) (<-chan time.Time, func()) {
 		defer close(tickerChan)
 
 		for {
-			// Calculate current dynamic interval
-			waitDuration := c.CalculateDynamicInterval(ctx)
+			// Calculate current interval
+			waitDuration := c.nextInterval(ctx)
 			metrics.CurrentIntervalSeconds.Set(float64(waitDuration.Seconds()))
 
+			c.statusMutex.Lock()
+			c.nextTick = time.Now().Add(waitDuration)
+			c.statusMutex.Unlock()
+
 			select {
 			case <-time.After(waitDuration):
 				select {
@@ -183,41 +693,170 @@ func (c *Chaoskube) NewTicker(ctx context.Context) (<-chan time.Time, func()) {
 	return tickerChan, stopFunc
 }
 
-// CalculateDynamicInterval calculates a dynamic interval based on current pod count
+// nextInterval returns the time to wait for the next termination: the time until the next run of
+// the schedule, or the interval of the current weekday or else the fixed or dynamic interval,
+// stretched while the API server is under pressure and, in Poisson mode, drawn at random with
+// that mean.
+func (c *Chaoskube) nextInterval(ctx context.Context) time.Duration {
+	if c.Schedule != nil {
+		now := c.Now()
+		return c.Schedule.Next(now.In(c.Timezone)).Sub(now)
+	}
+
+	interval, overridden := c.intervalOverride()
+	if !overridden {
+		interval = c.BaseInterval
+		if c.DynamicInterval {
+			interval = c.CalculateDynamicInterval(ctx)
+		}
+	}
+
+	c.pressureMutex.Lock()
+	stretch := math.Max(1, c.intervalStretch)
+	c.pressureMutex.Unlock()
+
+	mean := float64(interval) * stretch
+	if c.Poisson {
+		rnd := c.IntervalRand
+		if rnd == nil {
+			rnd = c.Rand
+		}
+		return time.Duration(rnd.ExpFloat64() * mean)
+	}
+	return time.Duration(mean)
+}
+
+// intervalOverride returns the interval configured for the current weekday in Timezone, if any.
+func (c *Chaoskube) intervalOverride() (time.Duration, bool) {
+	if len(c.IntervalOverrides) == 0 {
+		return 0, false
+	}
+	interval, ok := c.IntervalOverrides[c.Now().In(c.Timezone).Weekday()]
+	return interval, ok
+}
+
+// observeList keeps track of slow and throttled list calls. From the second one in a row on, it
+// doubles the interval stretch, and halves it again for each list call that went fine.
+func (c *Chaoskube) observeList(duration time.Duration, err error) {
+	c.pressureMutex.Lock()
+	defer c.pressureMutex.Unlock()
+
+	previous := math.Max(1, c.intervalStretch)
+
+	if apierrors.IsTooManyRequests(err) || (c.SlowListThreshold > 0 && duration > c.SlowListThreshold) {
+		c.slowLists++
+		if c.slowLists >= 2 {
+			c.intervalStretch = math.Min(previous*2, maxIntervalStretch)
+		}
+	} else {
+		c.slowLists = 0
+		c.intervalStretch = math.Max(1, previous/2)
+	}
+
+	if c.intervalStretch == previous {
+		return
+	}
+
+	metrics.IntervalStretchFactor.Set(c.intervalStretch)
+
+	logger := c.Logger.WithFields(log.Fields{
+		"listDuration": duration,
+		"stretch":      c.intervalStretch,
+	})
+	if c.intervalStretch > previous {
+		logger.Warn("API server under pressure, stretching interval")
+	} else {
+		logger.Info("API server recovering, shortening interval")
+	}
+}
+
+// listPods lists pods with the configured Lister and observes how long that took.
+func (c *Chaoskube) listPods(ctx context.Context) ([]v1.Pod, error) {
+	start := time.Now()
+	pods, err := c.lister().ListPods(ctx)
+	c.observeList(time.Since(start), err)
+
+	return pods, err
+}
+
+// CalculateDynamicInterval calculates a dynamic interval based on the current pod count, or its
+// exponentially weighted moving average if DynamicIntervalSmoothing is set.
 func (c *Chaoskube) CalculateDynamicInterval(ctx context.Context) time.Duration {
 
 	// Get total number of pods
-	listOptions := metav1.ListOptions{LabelSelector: c.Labels.String()}
-	podList, err := c.Client.CoreV1().Pods(c.ClientNamespaceScope).List(ctx, listOptions)
-
+	currentCount, err := c.dynamicPodCount(ctx)
 	if err != nil {
-		c.Logger.WithField("err", err).Error("failed to get list of pods, using base interval")
+		c.Logger.WithField("err", err).Error("failed to count pods, using base interval")
 		return c.BaseInterval
 	}
 
-	pods, err := filterByNamespaces(podList.Items, c.Namespaces)
-	if err != nil {
-		c.Logger.WithField("err", err).Error("failed to filterByNamespaces, using base interval")
+	// Smooth out sudden scale events, if enabled
+	podCount := c.smoothPodCount(currentCount)
+
+	// Guard against division by zero, pods could be all filtered!
+	if podCount < 1 {
+		c.Logger.WithField("podCount", podCount).Info("no pods found, using base interval")
 		return c.BaseInterval
 	}
+	// As a simple reference, we assume that the target fraction of the pods should be killed
+	// during a working week, by default half of them within 5 days of 8 hours
+	totalWorkingMinutes := c.DynamicIntervalWorkingDays * c.DynamicIntervalWorkingHours * 60
+
+	// Calculate raw interval in minutes
+	// Higher pod counts = shorter intervals, lower pod counts = longer intervals
+	rawIntervalMinutes := totalWorkingMinutes / (podCount * c.DynamicIntervalTarget * c.DynamicIntervalFactor)
+
+	// Round to nearest minute and ensure minimum of 1 minute
+	minutes := int(math.Max(1, math.Round(rawIntervalMinutes)))
+	roundedInterval := time.Duration(minutes) * time.Minute
+
+	// Provide detailed logging about the calculation
+	c.Logger.WithFields(log.Fields{
+		"podCount":         currentCount,
+		"smoothedPodCount": podCount,
+		"totalWorkMinutes": totalWorkingMinutes,
+		"target":           c.DynamicIntervalTarget,
+		"factor":           c.DynamicIntervalFactor,
+		"rawIntervalMins":  rawIntervalMinutes,
+		"roundedInterval":  roundedInterval,
+	}).Info("calculated dynamic interval")
+
+	return roundedInterval
+}
+
+// dynamicPodCount returns the number of pods the dynamic interval is based on.
+func (c *Chaoskube) dynamicPodCount(ctx context.Context) (int, error) {
+	podList, err := c.listPods(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get list of pods: %v", err)
+	}
 
-	pods, err = filterPodsByNamespaceLabels(ctx, pods, c.NamespaceLabels, c.Client)
+	pods, err := filterByNamespaces(podList, c.Namespaces)
 	if err != nil {
-		c.Logger.WithField("err", err).Error("failed to filterPodsByNamespaceLabels, using base interval")
-		return c.BaseInterval
+		return 0, fmt.Errorf("failed to filterByNamespaces: %v", err)
 	}
 
-	pods, err = filterByKinds(pods, c.Kinds)
+	pods, err = filterPodsByNamespaceLabels(ctx, pods, c.NamespaceLabels, c.lister())
 	if err != nil {
-		c.Logger.WithField("err", err).Error("failed to filterByKinds, using base interval")
-		return c.BaseInterval
+		return 0, fmt.Errorf("failed to filterPodsByNamespaceLabels: %v", err)
+	}
+
+	pods = filterByNamespaceNames(pods, c.IncludedNamespaceNames, c.ExcludedNamespaceNames)
+
+	pods, err = filterByKinds(ctx, pods, c.Kinds, c.kindResolver())
+	if err != nil {
+		c.Logger.WithField("err", err).Warn(msgKindsUnresolved)
 	}
 
 	pods = filterByAnnotations(pods, c.Annotations)
 
-	pods = filterStaticPods(pods)
+	if !c.IncludeDaemonSets {
+		pods = filterDaemonSetPods(pods)
+	}
 
-	podCount := len(pods)
+	if !c.IncludeStaticPods {
+		pods = filterStaticPods(pods)
+	}
 
 	c.Logger.Debug("Listing candidate pods for dynamic interval calculation:")
 	for i, pod := range pods {
@@ -230,43 +869,72 @@ func (c *Chaoskube) CalculateDynamicInterval(ctx context.Context) time.Duration
 		}).Debug("candidate pod")
 	}
 
-	// Guard against division by zero, pods could be all filtered!
-	if podCount == 0 {
-		c.Logger.WithField("podCount", 0).Info("no pods found, using base interval")
-		return c.BaseInterval
+	return len(pods), nil
+}
+
+// smoothPodCount folds the given pod count into an exponentially weighted moving average of the
+// pod counts seen so far, if DynamicIntervalSmoothing is set, and returns the pod count to base
+// the dynamic interval on.
+func (c *Chaoskube) smoothPodCount(count int) float64 {
+	c.podCountMutex.Lock()
+	defer c.podCountMutex.Unlock()
+
+	if c.DynamicIntervalSmoothing <= 0 || c.podCountSamples == 0 {
+		c.smoothedPodCount = float64(count)
+	} else {
+		c.smoothedPodCount += c.DynamicIntervalSmoothing * (float64(count) - c.smoothedPodCount)
 	}
-	// As a simple reference, we asume that every pod should be killed during 10 working days (9-17h)
-	totalWorkingMinutes := 10 * 8 * 60
+	c.podCountSamples++
 
-	// Calculate raw interval in minutes
-	// Higher pod counts = shorter intervals, lower pod counts = longer intervals
-	rawIntervalMinutes := float64(totalWorkingMinutes) / (float64(podCount) * c.DynamicIntervalFactor)
+	metrics.DynamicIntervalPodCount.Set(c.smoothedPodCount)
 
-	// Round to nearest minute and ensure minimum of 1 minute
-	minutes := int(math.Max(1, math.Round(rawIntervalMinutes)))
-	roundedInterval := time.Duration(minutes) * time.Minute
+	return c.smoothedPodCount
+}
 
-	// Provide detailed logging about the calculation
-	c.Logger.WithFields(log.Fields{
-		"podCount":         podCount,
-		"totalWorkMinutes": totalWorkingMinutes,
-		"factor":           c.DynamicIntervalFactor,
-		"rawIntervalMins":  rawIntervalMinutes,
-		"roundedInterval":  roundedInterval,
-	}).Info("calculated dynamic interval")
+// RunPodCountSampler counts the pods the dynamic interval is based on at the given period until
+// the given context is canceled, so that the moving average of DynamicIntervalSmoothing follows
+// the pod count between terminations, too.
+func (c *Chaoskube) RunPodCountSampler(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
 
-	return roundedInterval
+	for {
+		select {
+		case <-ticker.C:
+			count, err := c.dynamicPodCount(ctx)
+			if err != nil {
+				c.Logger.WithField("err", err).Warn("failed to sample pod count")
+				continue
+			}
+			c.smoothPodCount(count)
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 // Run continuously picks and terminates a victim pod at a given interval
 // described by channel next. It returns when the given context is canceled.
+// With a schedule, it waits for the first scheduled run instead of terminating right away.
 func (c *Chaoskube) Run(ctx context.Context, next <-chan time.Time) {
+	if c.Schedule != nil {
+		select {
+		case <-next:
+		case <-ctx.Done():
+			return
+		}
+	}
+
 	for {
 		if err := c.TerminateVictims(ctx); err != nil {
 			c.Logger.WithField("err", err).Error("failed to terminate victim")
 			metrics.ErrorsTotal.Inc()
 		}
 
+		c.statusMutex.Lock()
+		c.lastTick = c.Now()
+		c.statusMutex.Unlock()
+
 		c.Logger.Debug("sleeping...")
 		metrics.IntervalsTotal.Inc()
 
@@ -282,30 +950,22 @@ func (c *Chaoskube) Run(ctx context.Context, next <-chan time.Time) {
 // TerminateVictims picks and deletes a victim.
 // It respects the configured excluded weekdays, times of day and days of a year filters.
 func (c *Chaoskube) TerminateVictims(ctx context.Context) error {
-	now := c.Now().In(c.Timezone)
-
-	for _, wd := range c.ExcludedWeekdays {
-		if wd == now.Weekday() {
-			c.Logger.WithField("weekday", now.Weekday()).Debug(msgWeekdayExcluded)
-			return nil
-		}
-	}
-
-	for _, tp := range c.ExcludedTimesOfDay {
-		if tp.Includes(now) {
-			c.Logger.WithField("timeOfDay", now.Format(util.Kitchen24)).Debug(msgTimeOfDayExcluded)
-			return nil
-		}
+	if c.excluded(c.Now().In(c.Timezone)) || c.isPaused() {
+		return nil
 	}
 
-	for _, d := range c.ExcludedDaysOfYear {
-		if d.Day() == now.Day() && d.Month() == now.Month() {
-			c.Logger.WithField("dayOfYear", now.Format(util.YearDay)).Debug(msgDayOfYearExcluded)
-			return nil
+	// expired pods count towards the maximum number of victims, which are still picked on failure
+	expired := 0
+	if c.EnforceMaxLifetime {
+		var err error
+		expired, err = c.terminateExpired(ctx)
+		if err != nil {
+			c.Logger.WithField("err", err).Error("failed to terminate expired pods")
+			metrics.ErrorsTotal.Inc()
 		}
 	}
 
-	victims, err := c.Victims(ctx)
+	victims, err := c.victims(ctx, expired)
 	if err == errPodNotFound {
 		c.Logger.Debug(msgVictimNotFound)
 		return nil
@@ -314,270 +974,2452 @@ func (c *Chaoskube) TerminateVictims(ctx context.Context) error {
 		return err
 	}
 
-	var result *multierror.Error
-	for _, victim := range victims {
-		err = c.DeletePod(ctx, victim)
-		result = multierror.Append(result, err)
-	}
-
-	return result.ErrorOrNil()
+	return c.terminate(ctx, victims)
 }
 
-// Victims returns up to N pods as configured by MaxKill flag
-func (c *Chaoskube) Victims(ctx context.Context) ([]v1.Pod, error) {
-	pods, err := c.Candidates(ctx)
-	if err != nil {
-		return []v1.Pod{}, err
+// terminateExpired terminates the pods that exceeded the maximum lifetime they declare, oldest
+// first and at most as many per owner at once as are allowed to be killed per owner, so that
+// replicas created together aren't all recycled at the same time. The maximum number of victims
+// and the safety filters apply to them just as to regular victims. It returns the number of pods
+// it tried to terminate.
+func (c *Chaoskube) terminateExpired(ctx context.Context) (int, error) {
+	if c.isPaused() {
+		return 0, nil
 	}
 
-	c.Logger.WithField("count", len(pods)).Debug("found candidates")
-
-	if len(pods) == 0 {
-		return []v1.Pod{}, errPodNotFound
+	pods, err := c.listPods(ctx)
+	if err != nil {
+		return 0, err
 	}
 
-	pods = util.RandomPodSubSlice(pods, c.MaxKill)
-
-	c.Logger.WithField("count", len(pods)).Debug("found victims")
-	return pods, nil
-}
-
-// Candidates returns the list of pods that are available for termination.
-// It returns all pods that match the configured label, annotation and namespace selectors.
-func (c *Chaoskube) Candidates(ctx context.Context) ([]v1.Pod, error) {
-	listOptions := metav1.ListOptions{LabelSelector: c.Labels.String()}
-
-	podList, err := c.Client.CoreV1().Pods(c.ClientNamespaceScope).List(ctx, listOptions)
+	pods, err = c.filterByScope(ctx, pods)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	filterCounts := fmt.Sprintf("initial:%d", len(podList.Items))
-
-	pods, err := filterByNamespaces(podList.Items, c.Namespaces)
-	if err != nil {
-		return nil, err
+	pods = filterTerminatingPods(pods)
+	if !c.IgnoreDisruptionAnnotations {
+		pods = filterByDisruptionAnnotations(pods)
 	}
-	filterCounts += fmt.Sprintf(" → namespaces:%d", len(pods))
 
-	pods, err = filterPodsByNamespaceLabels(ctx, pods, c.NamespaceLabels, c.Client)
-	if err != nil {
-		return nil, err
+	expired := filterByMaxLifetime(pods, c.Now())
+	if len(expired) == 0 {
+		return 0, nil
 	}
-	filterCounts += fmt.Sprintf(" → ns-labels:%d", len(pods))
 
-	pods, err = filterByKinds(pods, c.Kinds)
+	expired = oldestPerOwner(expired, max(1, c.MaxKillPerOwner))
+
+	expired, err = c.filterBySafety(ctx, expired)
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+	if limit := c.maxKill(len(expired)); len(expired) > limit {
+		expired = expired[:limit]
 	}
-	filterCounts += fmt.Sprintf(" → kinds:%d", len(pods))
 
-	pods = filterByAnnotations(pods, c.Annotations)
-	filterCounts += fmt.Sprintf(" → annotations:%d", len(pods))
+	c.Logger.WithField("count", len(expired)).Info("terminating pods exceeding their maximum lifetime")
 
-	pods = filterByPhase(pods, v1.PodRunning)
-	filterCounts += fmt.Sprintf(" → running:%d", len(pods))
+	return len(expired), c.terminate(ctx, expired)
+}
 
-	pods = filterTerminatingPods(pods)
-	filterCounts += fmt.Sprintf(" → non-terminating:%d", len(pods))
+// filterBySafety filters a list of pods by the filters protecting workloads from too much
+// disruption: the cooldown, the minimum interval of workloads, the minimum number of ready
+// replicas and the sole endpoints of services, as far as they are configured.
+func (c *Chaoskube) filterBySafety(ctx context.Context, pods []v1.Pod) ([]v1.Pod, error) {
+	var err error
 
-	pods = filterByMinimumAge(pods, c.MinimumAge, c.Now())
-	filterCounts += fmt.Sprintf(" → min-age:%d", len(pods))
+	resolver := workload.NewResolver(c.Client)
 
-	pods = filterByPodName(pods, c.IncludedPodNames, c.ExcludedPodNames)
-	filterCounts += fmt.Sprintf(" → pod-names:%d", len(pods))
+	if c.History != nil && c.Cooldown > 0 {
+		pods = filterByCooldown(pods, c.History.LastTerminations(), c.Cooldown, c.Now())
+	}
 
-	pods = filterByOwnerReference(pods)
-	filterCounts += fmt.Sprintf(" → owner-ref:%d", len(pods))
+	if c.History != nil && c.WorkloadIntervals {
+		pods, err = filterByWorkloadIntervals(ctx, pods, resolver, c.History.LastWorkloadTerminations(), c.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	pods = filterStaticPods(pods)
-	filterCounts += fmt.Sprintf(" → static-pods:%d", len(pods))
+	if c.MinOwnerReady > 0 {
+		pods, err = filterByOwnerReadiness(ctx, pods, resolver, c.MinOwnerReady)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	c.Logger.Debug("Pod filtering: " + filterCounts)
+	if c.ProtectSoleEndpoints {
+		pods, err = filterBySoleEndpoints(ctx, pods, c.Client, c.namespaceScope())
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return pods, nil
 }
 
-// DeletePod deletes the given pod with the selected terminator.
-// It will not delete the pod if dry-run mode is enabled.
-func (c *Chaoskube) DeletePod(ctx context.Context, victim v1.Pod) error {
-	c.Logger.WithFields(log.Fields{
-		"namespace": victim.Namespace,
-		"name":      victim.Name,
-	}).Info("terminating pod")
+// RunTriggers terminates victims for each request received on the given channel.
+// It returns when the given context is canceled or the channel is closed.
+func (c *Chaoskube) RunTriggers(ctx context.Context, requests <-chan trigger.Request) {
+	for {
+		select {
+		case request, ok := <-requests:
+			if !ok {
+				return
+			}
+			if err := c.TerminateTriggeredVictims(ctx, request); err != nil {
+				c.Logger.WithFields(log.Fields{
+					"reason": request.Reason,
+					"err":    err,
+				}).Error("failed to terminate triggered victim")
+				metrics.ErrorsTotal.Inc()
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-	// return early if we're running in dryRun mode.
-	if c.DryRun {
+// TerminateTriggeredVictims picks and deletes a victim among the candidates matching the given
+// trigger request. It respects the same time-based filters as TerminateVictims.
+func (c *Chaoskube) TerminateTriggeredVictims(ctx context.Context, request trigger.Request) error {
+	if c.excluded(c.Now().In(c.Timezone)) || c.isPaused() {
 		return nil
 	}
 
-	start := time.Now()
-	err := c.Terminator.Terminate(ctx, victim)
-	metrics.TerminationDurationSeconds.Observe(time.Since(start).Seconds())
+	pods, err := c.Candidates(ctx)
 	if err != nil {
 		return err
 	}
 
-	metrics.PodsDeletedTotal.WithLabelValues(victim.Namespace).Inc()
+	pods = filterByTriggerRequest(pods, request)
 
-	ref, err := reference.GetReference(scheme.Scheme, &victim)
+	c.Logger.WithFields(log.Fields{
+		"reason": request.Reason,
+		"count":  len(pods),
+	}).Debug("found triggered candidates")
+
+	if len(pods) == 0 {
+		c.Logger.WithField("reason", request.Reason).Debug(msgVictimNotFound)
+		return nil
+	}
+
+	return c.terminate(ctx, util.RandomPodSubSlice(pods, c.maxKill(len(pods)), c.Rand))
+}
+
+// RunWatchdog checks until the given context is canceled whether no interval completed, or no pod
+// was terminated although there were candidates, within the given threshold. It reports stalls
+// as errors and through the stalled metric, so that wedged instances are noticed.
+func (c *Chaoskube) RunWatchdog(ctx context.Context, threshold time.Duration) {
+	started := c.Now()
+
+	ticker := time.NewTicker(threshold / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.checkWatchdog(started, threshold)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkWatchdog reports whether chaoskube stalled for longer than the given threshold since it
+// started, by interval and by termination.
+func (c *Chaoskube) checkWatchdog(started time.Time, threshold time.Duration) (tickStalled, killStalled bool) {
+	c.statusMutex.Lock()
+	lastTick, lastKill := c.lastTick, c.lastKill
+	candidates, paused := len(c.lastCandidates), c.paused
+	c.statusMutex.Unlock()
+
+	if lastTick.Before(started) {
+		lastTick = started
+	}
+	if lastKill.Before(started) {
+		lastKill = started
+	}
+
+	now := c.Now()
+	tickStalled = now.Sub(lastTick) > threshold
+	killStalled = !paused && candidates > 0 && now.Sub(lastKill) > threshold
+
+	for _, check := range []struct {
+		reason  string
+		stalled bool
+	}{
+		{"tick", tickStalled},
+		{"kill", killStalled},
+	} {
+		value := 0.0
+		if check.stalled {
+			value = 1
+			c.Logger.WithFields(log.Fields{
+				"reason":    check.reason,
+				"lastTick":  lastTick,
+				"lastKill":  lastKill,
+				"threshold": threshold,
+			}).Error(msgStalled)
+		}
+		metrics.Stalled.WithLabelValues(check.reason).Set(value)
+	}
+
+	return tickStalled, killStalled
+}
+
+// RunJanitor cleans up pods in bad terminal states every interval until the given context is
+// canceled: pods still terminating longer than stuckAfter after their deletion, e.g. due to
+// stuck finalizers or lost nodes, and pods lingering in the Failed, Succeeded or Unknown phase
+// for longer than lingerAfter. A non-positive duration disables the respective cleanup.
+func (c *Chaoskube) RunJanitor(ctx context.Context, interval, stuckAfter, lingerAfter time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.cleanUp(ctx, stuckAfter, lingerAfter); err != nil {
+				c.Logger.WithField("err", err).Error("failed to clean up pods")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// cleanUp deletes the pods the janitor is responsible for once. Stuck pods are deleted without
+// grace period as their containers are most likely gone already. Stuck pods of StatefulSets are
+// only deleted once their node is gone, as their replacement would otherwise risk running next to
+// a pod with the same identity that is still alive.
+func (c *Chaoskube) cleanUp(ctx context.Context, stuckAfter, lingerAfter time.Duration) error {
+	if c.isPaused() {
+		return nil
+	}
+
+	pods, err := c.janitorCandidates(ctx)
 	if err != nil {
 		return err
 	}
 
-	c.EventRecorder.Event(ref, v1.EventTypeNormal, "Killing", "Pod was terminated by chaoskube to introduce chaos.")
+	var result *multierror.Error
+	for _, pod := range pods {
+		reason := janitorReason(pod, c.Now(), stuckAfter, lingerAfter)
+		if reason == "" {
+			continue
+		}
 
-	if err := c.Notifier.NotifyPodTermination(victim); err != nil {
-		c.Logger.WithField("err", err).Warn("failed to notify pod termination")
+		c.Logger.WithFields(log.Fields{
+			"namespace": pod.Namespace,
+			"name":      pod.Name,
+			"reason":    reason,
+		}).Info("cleaning up pod")
+
+		if reason == janitorReasonStuck && isStatefulSetPod(pod) {
+			gone, err := c.nodeGone(ctx, pod)
+			if err != nil {
+				result = multierror.Append(result, err)
+				continue
+			}
+			if !gone {
+				c.Logger.WithFields(log.Fields{
+					"namespace": pod.Namespace,
+					"name":      pod.Name,
+					"node":      pod.Spec.NodeName,
+				}).Info("skipping stuck StatefulSet pod whose node still exists")
+				continue
+			}
+		}
+
+		if c.DryRun {
+			continue
+		}
+
+		options := metav1.DeleteOptions{Preconditions: metav1.NewUIDPreconditions(string(pod.UID))}
+		if reason == janitorReasonStuck {
+			options.GracePeriodSeconds = new(int64)
+		}
+		err := c.Client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, options)
+		if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+			continue
+		}
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		metrics.JanitorPodsDeletedTotal.WithLabelValues(pod.Namespace, reason).Inc()
 	}
 
-	return nil
+	return result.ErrorOrNil()
+}
+
+// janitorCandidates lists the pods in any phase the janitor may clean up. They are selected by
+// the same labels, field selector, namespaces, pod names, opt-in annotations, minimum age and
+// DaemonSet exclusion as regular candidates, so that the janitor stays within the scope chaoskube
+// is configured for.
+func (c *Chaoskube) janitorCandidates(ctx context.Context) ([]v1.Pod, error) {
+	fieldSelector := c.FieldSelector
+	if fieldSelector == nil {
+		fieldSelector = fields.Everything()
+	}
+
+	lister := apiLister{client: c.Client, namespaces: c.namespaceScope(), parallelism: c.ListParallelism, labels: c.Labels, fields: fieldSelector}
+	pods, err := lister.ListPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err = c.filterByScope(ctx, pods)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err = filterByOptIn(ctx, pods, c.lister(), c.DefaultOptOut)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.IncludeDaemonSets {
+		pods = filterDaemonSetPods(pods)
+	}
+
+	pods = filterByMinimumAge(pods, c.MinimumAge, c.Now())
+
+	return filterStaticPods(pods), nil
+}
+
+// nodeGone returns true iff the node the given pod was scheduled to doesn't exist anymore.
+func (c *Chaoskube) nodeGone(ctx context.Context, pod v1.Pod) (bool, error) {
+	if pod.Spec.NodeName == "" {
+		return true, nil
+	}
+	_, err := c.Client.CoreV1().Nodes().Get(ctx, pod.Spec.NodeName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// filterByScope filters a list of pods by the configured namespaces, annotations and pod names,
+// i.e. the filters defining which pods chaoskube is responsible for at all.
+func (c *Chaoskube) filterByScope(ctx context.Context, pods []v1.Pod) ([]v1.Pod, error) {
+	pods, err := filterByNamespaces(pods, c.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err = filterPodsByNamespaceLabels(ctx, pods, c.NamespaceLabels, c.lister())
+	if err != nil {
+		return nil, err
+	}
+
+	pods = filterByNamespaceNames(pods, c.IncludedNamespaceNames, c.ExcludedNamespaceNames)
+	pods = filterByAnnotations(pods, c.Annotations)
+
+	return filterByPodName(pods, c.IncludedPodNames, c.ExcludedPodNames), nil
+}
+
+// janitorReason returns why the given pod should be cleaned up at the given time, or an empty
+// string if it should be left alone.
+func janitorReason(pod v1.Pod, now time.Time, stuckAfter, lingerAfter time.Duration) string {
+	if pod.DeletionTimestamp != nil {
+		// the deletion timestamp already includes the pod's grace period
+		if stuckAfter > 0 && now.Sub(pod.DeletionTimestamp.Time) > stuckAfter {
+			return janitorReasonStuck
+		}
+		return ""
+	}
+
+	switch pod.Status.Phase {
+	case v1.PodFailed, v1.PodSucceeded, v1.PodUnknown:
+		if lingerAfter > 0 && now.Sub(terminalSince(pod)) > lingerAfter {
+			return strings.ToLower(string(pod.Status.Phase))
+		}
+	}
+	return ""
+}
+
+// terminalSince returns when the given pod entered its current phase as far as it can be told,
+// i.e. when its last container finished or its readiness last changed, falling back to its
+// creation.
+func terminalSince(pod v1.Pod) time.Time {
+	since := pod.CreationTimestamp.Time
+	for _, status := range append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...) {
+		if terminated := status.State.Terminated; terminated != nil && terminated.FinishedAt.After(since) {
+			since = terminated.FinishedAt.Time
+		}
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady && condition.LastTransitionTime.After(since) {
+			since = condition.LastTransitionTime.Time
+		}
+	}
+	return since
+}
+
+// markKill records the time of the last termination, including those of dry runs.
+func (c *Chaoskube) markKill() {
+	c.statusMutex.Lock()
+	c.lastKill = c.Now()
+	c.statusMutex.Unlock()
+}
+
+// spendBudget spends the kill budget, if any, on a successful termination. Dry runs leave it
+// untouched as they don't terminate anything.
+func (c *Chaoskube) spendBudget() {
+	if c.Budget == nil {
+		return
+	}
+	if err := c.Budget.Spend(c.Now()); err != nil {
+		c.Logger.WithField("err", err).Warn("failed to persist kill budget")
+	}
+}
+
+// Status returns a snapshot of the current state.
+func (c *Chaoskube) Status() Status {
+	c.statusMutex.Lock()
+	defer c.statusMutex.Unlock()
+
+	status := Status{
+		Candidates: c.lastCandidates,
+		NextTick:   c.nextTick,
+		Paused:     c.paused,
+	}
+	if c.History != nil {
+		status.Victims = c.History.Entries()
+	}
+	return status
+}
+
+// SetPaused suspends or resumes all terminations, scheduled and triggered.
+func (c *Chaoskube) SetPaused(paused bool) {
+	c.statusMutex.Lock()
+	defer c.statusMutex.Unlock()
+
+	c.paused = paused
+	c.Logger.WithField("paused", paused).Info("toggled pause")
+}
+
+func (c *Chaoskube) isPaused() bool {
+	c.statusMutex.Lock()
+	defer c.statusMutex.Unlock()
+
+	if c.paused {
+		c.Logger.Debug(msgPaused)
+	}
+	return c.paused
+}
+
+// excluded returns true iff the given point in time falls into one of the configured
+// excluded weekdays, times of day or days of a year.
+func (c *Chaoskube) excluded(now time.Time) bool {
+	for _, wd := range c.ExcludedWeekdays {
+		if wd == now.Weekday() {
+			c.Logger.WithField("weekday", now.Weekday()).Debug(msgWeekdayExcluded)
+			return true
+		}
+	}
+
+	for _, tp := range c.ExcludedTimesOfDay {
+		if tp.Includes(now) {
+			c.Logger.WithField("timeOfDay", now.Format(util.Kitchen24)).Debug(msgTimeOfDayExcluded)
+			return true
+		}
+	}
+
+	for _, d := range c.ExcludedDaysOfYear {
+		if d.Day() == now.Day() && d.Month() == now.Month() {
+			c.Logger.WithField("dayOfYear", now.Format(util.YearDay)).Debug(msgDayOfYearExcluded)
+			return true
+		}
+	}
+
+	if c.ExcludedDaysCalendar != nil && c.ExcludedDaysCalendar.Includes(now) {
+		c.Logger.WithField("day", now.Format(time.DateOnly)).Debug(msgCalendarExcluded)
+		return true
+	}
+
+	if len(c.IncludedWeekdays) > 0 && !slices.Contains(c.IncludedWeekdays, now.Weekday()) {
+		c.Logger.WithField("weekday", now.Weekday()).Debug(msgWeekdayNotIncluded)
+		return true
+	}
+
+	if len(c.IncludedTimesOfDay) > 0 && !slices.ContainsFunc(c.IncludedTimesOfDay, func(tp util.TimePeriod) bool { return tp.Includes(now) }) {
+		c.Logger.WithField("timeOfDay", now.Format(util.Kitchen24)).Debug(msgTimeOfDayNotIncluded)
+		return true
+	}
+
+	return false
+}
+
+// terminate deletes all given victims, once approved if an Approver is set, and collects any errors along the way.
+func (c *Chaoskube) terminate(ctx context.Context, victims []v1.Pod) error {
+	if c.Budget != nil {
+		if remaining := c.Budget.Remaining(c.Now()); remaining >= 0 && remaining < len(victims) {
+			c.Logger.WithFields(log.Fields{
+				"victims":   len(victims),
+				"remaining": remaining,
+			}).Info(msgBudgetExhausted)
+			victims = victims[:remaining]
+		}
+		if len(victims) == 0 {
+			return nil
+		}
+	}
+
+	if c.Approver != nil {
+		approved, err := c.Approver.Approve(ctx, victims)
+		if err != nil {
+			return err
+		}
+		if !approved {
+			c.Logger.WithField("count", len(victims)).Info(msgNotApproved)
+			return nil
+		}
+	}
+
+	workers := c.TerminationWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	// terminate victims with a bounded number of workers, collecting errors in victim order
+	errs := make([]error, len(victims))
+	next := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers && i < len(victims); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range next {
+				errs[j] = c.DeletePod(ctx, victims[j])
+				if errs[j] != nil {
+					c.Logger.WithFields(log.Fields{
+						"namespace": victims[j].Namespace,
+						"name":      victims[j].Name,
+						"err":       errs[j],
+					}).Warn("failed to terminate pod")
+				}
+			}
+		}()
+	}
+
+	for i := range victims {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var result *multierror.Error
+	for _, err := range errs {
+		result = multierror.Append(result, err)
+	}
+
+	return result.ErrorOrNil()
+}
+
+// Victims returns up to N pods as configured by MaxKill or MaxKillPercent flag
+func (c *Chaoskube) Victims(ctx context.Context) ([]v1.Pod, error) {
+	return c.victims(ctx, 0)
+}
+
+// victims returns the victims like Victims, given the number of pods killed already in the same
+// interval, which count towards the maximum.
+func (c *Chaoskube) victims(ctx context.Context, killed int) ([]v1.Pod, error) {
+	var (
+		pods     []v1.Pod
+		recorded *replay.Tick
+		err      error
+	)
+	if c.Player != nil {
+		tick, err := c.Player.Next()
+		if err == io.EOF {
+			return []v1.Pod{}, errPodNotFound
+		}
+		if err != nil {
+			return []v1.Pod{}, err
+		}
+		pods, recorded = tick.Candidates, &tick
+	} else {
+		pods, err = c.Candidates(ctx)
+		if err != nil {
+			return []v1.Pod{}, err
+		}
+	}
+
+	c.Logger.WithField("count", len(pods)).Debug("found candidates")
+
+	c.statusMutex.Lock()
+	c.lastCandidates = replay.Keys(pods)
+	c.statusMutex.Unlock()
+
+	if len(pods) == 0 {
+		return []v1.Pod{}, errPodNotFound
+	}
+
+	// selection reorders the candidates, so keep them as they are for the recording and blast
+	var candidates []v1.Pod
+	if c.Recorder != nil || c.ColocatedBlast > 0 {
+		candidates = append([]v1.Pod{}, pods...)
+	}
+
+	limit := max(0, c.maxKill(len(pods))-killed)
+	if c.CostProvider != nil {
+		pods, err = c.costAwareVictims(ctx, pods, limit)
+		if err != nil {
+			return []v1.Pod{}, err
+		}
+	} else {
+		pods = c.strategy().Select(pods, limit, c.Rand)
+	}
+
+	if c.ColocatedBlast > 0 {
+		pods = colocatedPods(pods, candidates, c.ColocatedBlast)
+	}
+
+	if recorded != nil && !reflect.DeepEqual(replay.Keys(pods), recorded.Victims) {
+		c.Logger.WithFields(log.Fields{
+			"recorded": recorded.Victims,
+			"selected": replay.Keys(pods),
+		}).Warn("replayed selection differs from recording")
+	}
+
+	if c.Recorder != nil {
+		if err := c.Recorder.Record(replay.NewTick(c.Now(), candidates, pods)); err != nil {
+			c.Logger.WithField("err", err).Warn("failed to record selection")
+		}
+	}
+
+	c.Logger.WithField("count", len(pods)).Debug("found victims")
+	return pods, nil
+}
+
+// maxKill returns the maximum number of victims to choose from the given number of candidates.
+// A percentage always allows at least one victim, so that chaos doesn't stop in small clusters,
+// and is bounded by MaxKillMin and MaxKillMax.
+func (c *Chaoskube) maxKill(candidates int) int {
+	if c.MaxKillPercent <= 0 {
+		return c.MaxKill
+	}
+
+	count := max(1, c.MaxKillMin, int(math.Ceil(float64(candidates)*c.MaxKillPercent/100)))
+	if c.MaxKillMax > 0 {
+		count = min(count, max(1, c.MaxKillMax))
+	}
+	return count
+}
+
+// colocatedPods returns the given victims, each followed by up to limit other candidates
+// scheduled on the same node, to simulate correlated failures.
+func colocatedPods(victims, candidates []v1.Pod, limit int) []v1.Pod {
+	chosen := map[string]bool{}
+	for _, victim := range victims {
+		chosen[victim.Namespace+"/"+victim.Name] = true
+	}
+
+	result := make([]v1.Pod, 0, len(victims))
+	for _, victim := range victims {
+		result = append(result, victim)
+
+		if victim.Spec.NodeName == "" {
+			continue
+		}
+
+		added := 0
+		for _, candidate := range candidates {
+			if added >= limit {
+				break
+			}
+			key := candidate.Namespace + "/" + candidate.Name
+			if candidate.Spec.NodeName != victim.Spec.NodeName || chosen[key] {
+				continue
+			}
+			chosen[key] = true
+			result = append(result, candidate)
+			added++
+		}
+	}
+
+	return result
+}
+
+// costAwareVictims picks up to maxKill victims, optionally weighted by their cost, that fit into
+// the remaining daily cost budget.
+func (c *Chaoskube) costAwareVictims(ctx context.Context, pods []v1.Pod, maxKill int) ([]v1.Pod, error) {
+	costs, err := c.CostProvider.PodCosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cost := func(pod v1.Pod) float64 {
+		return costs[pod.Namespace+"/"+pod.Name]
+	}
+
+	selection := c.strategy()
+	if c.CostWeighting {
+		selection = strategy.NewWeighted(cost)
+	}
+	pods = selection.Select(pods, len(pods), c.Rand)
+
+	victims := []v1.Pod{}
+	victimCosts := map[string]float64{}
+	selected := 0.0
+	for _, pod := range pods {
+		if len(victims) >= maxKill {
+			break
+		}
+		if !c.fitsCost(selected + cost(pod)) {
+			c.Logger.WithFields(log.Fields{
+				"namespace": pod.Namespace,
+				"name":      pod.Name,
+				"cost":      cost(pod),
+			}).Debug("daily cost budget exceeded")
+			continue
+		}
+		selected += cost(pod)
+		victims = append(victims, pod)
+		victimCosts[pod.Namespace+"/"+pod.Name] = cost(pod)
+	}
+
+	c.costMutex.Lock()
+	c.victimCosts = victimCosts
+	c.costMutex.Unlock()
+
+	if len(victims) == 0 {
+		return nil, errPodNotFound
+	}
+
+	return victims, nil
+}
+
+// fitsCost returns true iff the given cost fits into what is left of today's budget. It doesn't
+// spend anything, see spendCost.
+func (c *Chaoskube) fitsCost(cost float64) bool {
+	c.costMutex.Lock()
+	defer c.costMutex.Unlock()
+
+	c.resetCostDay()
+	return c.MaxCostPerDay <= 0 || c.costSpent+cost <= c.MaxCostPerDay
+}
+
+// spendCost adds the cost of the given victim, as of its selection, to today's spending once it
+// was terminated. Victims that weren't selected by cost, e.g. triggered, expired or colocated
+// ones, are charged their current cost.
+func (c *Chaoskube) spendCost(ctx context.Context, victim v1.Pod) {
+	if c.CostProvider == nil {
+		return
+	}
+
+	key := victim.Namespace + "/" + victim.Name
+
+	c.costMutex.Lock()
+	cost, ok := c.victimCosts[key]
+	delete(c.victimCosts, key)
+	c.costMutex.Unlock()
+
+	if !ok {
+		costs, err := c.CostProvider.PodCosts(ctx)
+		if err != nil {
+			c.Logger.WithFields(log.Fields{
+				"namespace": victim.Namespace,
+				"name":      victim.Name,
+				"err":       err,
+			}).Warn("failed to look up cost of victim")
+			return
+		}
+		cost = costs[key]
+	}
+
+	c.costMutex.Lock()
+	defer c.costMutex.Unlock()
+
+	c.resetCostDay()
+	c.costSpent += cost
+}
+
+// resetCostDay starts over with today's spending on a new day. It must be called with costMutex held.
+func (c *Chaoskube) resetCostDay() {
+	today := c.Now().In(c.Timezone).Format("2006-01-02")
+	if today != c.costDay {
+		c.costDay = today
+		c.costSpent = 0
+	}
+}
+
+// Candidates returns the list of pods that are available for termination.
+// It returns all pods that match the configured label, annotation and namespace selectors.
+func (c *Chaoskube) Candidates(ctx context.Context) ([]v1.Pod, error) {
+	podList, err := c.listPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filterCounts := fmt.Sprintf("initial:%d", len(podList))
+
+	resolver := workload.NewResolver(c.Client)
+
+	// filters work in place, so the zones covered by all pods are counted upfront
+	var (
+		zones    map[string]string
+		coverage map[string]int
+	)
+	if c.PreserveZoneCoverage {
+		zones, err = nodeZones(ctx, c.Client)
+		if err != nil {
+			return nil, err
+		}
+		coverage = zoneCoverage(podList, zones)
+	}
+
+	pods := podList
+	if len(c.AnyLabels) > 0 {
+		pods = filterByAnyLabels(pods, c.AnyLabels)
+		filterCounts += fmt.Sprintf(" → any-labels:%d", len(pods))
+	}
+
+	if c.HelmRelease != "" || c.HelmChart != "" {
+		pods = filterByHelmRelease(pods, c.HelmRelease, c.HelmChart)
+		filterCounts += fmt.Sprintf(" → helm:%d", len(pods))
+	}
+
+	pods, err = filterByNamespaces(pods, c.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+	filterCounts += fmt.Sprintf(" → namespaces:%d", len(pods))
+
+	pods, err = filterPodsByNamespaceLabels(ctx, pods, c.NamespaceLabels, c.lister())
+	if err != nil {
+		return nil, err
+	}
+	filterCounts += fmt.Sprintf(" → ns-labels:%d", len(pods))
+
+	pods = filterByNamespaceNames(pods, c.IncludedNamespaceNames, c.ExcludedNamespaceNames)
+	filterCounts += fmt.Sprintf(" → ns-names:%d", len(pods))
+
+	pods, err = filterByKinds(ctx, pods, c.Kinds, c.kindResolver())
+	if err != nil {
+		c.Logger.WithField("err", err).Warn(msgKindsUnresolved)
+	}
+	filterCounts += fmt.Sprintf(" → kinds:%d", len(pods))
+
+	if c.QOSClasses != nil && !c.QOSClasses.Empty() {
+		pods, err = filterByQOSClasses(pods, c.QOSClasses)
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → qos-classes:%d", len(pods))
+	}
+
+	pods = filterByAnnotations(pods, c.Annotations)
+	filterCounts += fmt.Sprintf(" → annotations:%d", len(pods))
+
+	if len(c.AnnotationPatterns) > 0 {
+		pods = filterByAnnotationPatterns(pods, c.AnnotationPatterns)
+		filterCounts += fmt.Sprintf(" → annotation-patterns:%d", len(pods))
+	}
+
+	if c.CandidateQuery != nil {
+		queried, err := c.CandidateQuery.Pods(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pods = filterByPodSet(pods, queried)
+		filterCounts += fmt.Sprintf(" → query:%d", len(pods))
+	}
+
+	if c.ArgoRollouts != "" && c.ArgoRollouts != ArgoRolloutsIgnore {
+		pods, err = filterByArgoRollouts(ctx, pods, c.ArgoRollouts, c.DynamicClient, c.namespaceScope())
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → argo-rollouts:%d", len(pods))
+	}
+
+	if c.PauseDuringCanary {
+		pods, err = filterByCanaries(ctx, pods, c.DynamicClient, resolver, c.namespaceScope())
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → canaries:%d", len(pods))
+	}
+
+	if c.VirtualNodes != "" && c.VirtualNodes != VirtualNodesInclude {
+		pods, err = filterByVirtualNodes(ctx, pods, c.VirtualNodes, c.Client)
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → virtual-nodes:%d", len(pods))
+	}
+
+	if (c.NodeLabels != nil && !c.NodeLabels.Empty()) || c.NodeNames != nil {
+		pods, err = filterByNodes(ctx, pods, c.Client, c.NodeLabels, c.NodeNames)
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → nodes:%d", len(pods))
+	}
+
+	pods = filterByPhase(pods, v1.PodRunning)
+	filterCounts += fmt.Sprintf(" → running:%d", len(pods))
+
+	pods = filterTerminatingPods(pods)
+	filterCounts += fmt.Sprintf(" → non-terminating:%d", len(pods))
+
+	if c.OnlyReady {
+		pods = filterByReadiness(pods)
+		filterCounts += fmt.Sprintf(" → ready:%d", len(pods))
+	}
+
+	if len(c.MinRequests) > 0 || len(c.MaxRequests) > 0 {
+		pods = filterByRequests(pods, c.MinRequests, c.MaxRequests)
+		filterCounts += fmt.Sprintf(" → requests:%d", len(pods))
+	}
+
+	if c.RestartWindow > 0 {
+		pods = filterByRestarts(pods, c.MaxContainerRestarts, c.RestartWindow, c.Now())
+		filterCounts += fmt.Sprintf(" → restarts:%d", len(pods))
+	}
+
+	pods = filterByMinimumAge(pods, c.MinimumAge, c.Now())
+	filterCounts += fmt.Sprintf(" → min-age:%d", len(pods))
+
+	pods = filterByPodName(pods, c.IncludedPodNames, c.ExcludedPodNames)
+	filterCounts += fmt.Sprintf(" → pod-names:%d", len(pods))
+
+	if c.IncludedImages != nil || c.ExcludedImages != nil {
+		pods = filterByImages(pods, c.IncludedImages, c.ExcludedImages)
+		filterCounts += fmt.Sprintf(" → images:%d", len(pods))
+	}
+
+	if len(c.IncludedPriorityClasses) > 0 || len(c.ExcludedPriorityClasses) > 0 {
+		pods = filterByPriorityClasses(pods, c.IncludedPriorityClasses, c.ExcludedPriorityClasses)
+		filterCounts += fmt.Sprintf(" → priority-classes:%d", len(pods))
+	}
+
+	if len(c.IncludedServiceAccounts) > 0 || len(c.ExcludedServiceAccounts) > 0 {
+		pods = filterByServiceAccounts(pods, c.IncludedServiceAccounts, c.ExcludedServiceAccounts)
+		filterCounts += fmt.Sprintf(" → service-accounts:%d", len(pods))
+	}
+
+	if c.Snooze {
+		pods, err = filterBySnooze(ctx, pods, c.lister(), resolver, c.Now())
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → snooze:%d", len(pods))
+	}
+
+	pods, err = filterByOptIn(ctx, pods, c.lister(), c.DefaultOptOut)
+	if err != nil {
+		return nil, err
+	}
+	filterCounts += fmt.Sprintf(" → opt-in:%d", len(pods))
+
+	if c.History != nil && c.Cooldown > 0 {
+		pods = filterByCooldown(pods, c.History.LastTerminations(), c.Cooldown, c.Now())
+		filterCounts += fmt.Sprintf(" → cooldown:%d", len(pods))
+	}
+
+	if c.History != nil && c.WorkloadIntervals {
+		pods, err = filterByWorkloadIntervals(ctx, pods, resolver, c.History.LastWorkloadTerminations(), c.Now())
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → workload-intervals:%d", len(pods))
+	}
+
+	if c.MinOwnerReady > 0 {
+		pods, err = filterByOwnerReadiness(ctx, pods, resolver, c.MinOwnerReady)
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → owner-ready:%d", len(pods))
+	}
+
+	if c.SkipRollouts {
+		pods, err = filterByRollouts(ctx, pods, resolver)
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → rollouts:%d", len(pods))
+	}
+
+	if c.ExcludePVCs {
+		pods, err = filterByPVCs(ctx, pods, c.Client, c.PVCAccessModes, c.namespaceScope())
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → pvcs:%d", len(pods))
+	}
+
+	if c.ProtectSoleEndpoints {
+		pods, err = filterBySoleEndpoints(ctx, pods, c.Client, c.namespaceScope())
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → sole-endpoints:%d", len(pods))
+	}
+
+	if c.NamespaceLevels {
+		pods, err = filterByNamespaceLevels(ctx, pods, c.lister(), c.Rand)
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → levels:%d", len(pods))
+	}
+
+	pods = filterByProbability(pods, c.Rand)
+	filterCounts += fmt.Sprintf(" → probability:%d", len(pods))
+
+	if c.StatefulSets != "" && c.StatefulSets != StatefulSetsAny {
+		pods, err = filterByStatefulSetOrdinals(ctx, pods, c.Client, c.targetOrdinal)
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → statefulset-ordinals:%d", len(pods))
+	}
+
+	if c.JobProgressThreshold > 0 {
+		pods, err = filterByJobProgress(ctx, pods, c.Client, c.JobProgressThreshold, c.Now())
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → job-progress:%d", len(pods))
+	}
+
+	if !c.IncludeDaemonSets {
+		pods = filterDaemonSetPods(pods)
+		filterCounts += fmt.Sprintf(" → daemonsets:%d", len(pods))
+	}
+
+	if !c.IgnoreDisruptionAnnotations {
+		pods = filterByDisruptionAnnotations(pods)
+		filterCounts += fmt.Sprintf(" → do-not-disrupt:%d", len(pods))
+	}
+
+	if !c.IncludeStaticPods {
+		pods = filterStaticPods(pods)
+		filterCounts += fmt.Sprintf(" → static-pods:%d", len(pods))
+	}
+
+	// checks per pod are expensive, so they run after the other filters, but before
+	// the candidates are reduced per owner, which would leave owners without candidates
+	if c.CandidateMatcher != nil {
+		pods, err = filterByMatcher(ctx, pods, c.CandidateMatcher)
+		if err != nil {
+			return nil, err
+		}
+		filterCounts += fmt.Sprintf(" → candidate-query:%d", len(pods))
+	}
+
+	if c.PreserveZoneCoverage {
+		pods = filterByZoneCoverage(pods, zones, coverage, c.Rand)
+		filterCounts += fmt.Sprintf(" → zone-coverage:%d", len(pods))
+	}
+
+	if !c.samplesOwners() {
+		pods = filterByOwnerReference(pods, max(1, c.MaxKillPerOwner), c.PreferLowDeletionCost, c.Rand)
+		filterCounts += fmt.Sprintf(" → owner-ref:%d", len(pods))
+	}
+
+	c.Logger.Debug("Pod filtering: " + filterCounts)
+
+	return pods, nil
+}
+
+// Validate checks the configuration against the cluster before any chaos runs: that the
+// namespaces included by the namespace selector exist, that all filters can be evaluated, and
+// that they don't rule out every pod. It returns the candidates found along with all problems
+// detected. Excluded namespaces may well be missing, and namespaces aren't checked at all with a
+// client namespace scope, which most likely isn't allowed to list them.
+func (c *Chaoskube) Validate(ctx context.Context) ([]v1.Pod, error) {
+	var result *multierror.Error
+
+	referenced := []string{}
+	reqs, _ := c.Namespaces.Requirements()
+	for _, req := range reqs {
+		if req.Operator() == selection.Exists {
+			referenced = append(referenced, req.Key())
+		}
+	}
+
+	// ask the API server, since a Lister might not know about namespaces
+	if len(referenced) > 0 && c.ClientNamespaceScope == "" {
+		namespaces, err := apiLister{client: c.Client}.ListNamespaces(ctx, labels.Everything())
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to list namespaces: %v", err))
+		} else {
+			existing := make(map[string]bool, len(namespaces))
+			for _, namespace := range namespaces {
+				existing[namespace.Name] = true
+			}
+			for _, namespace := range referenced {
+				if !existing[namespace] {
+					result = multierror.Append(result, fmt.Errorf("namespace %s doesn't exist", namespace))
+				}
+			}
+		}
+	}
+
+	candidates, err := c.Candidates(ctx)
+	if err != nil {
+		result = multierror.Append(result, err)
+	} else if len(candidates) == 0 {
+		result = multierror.Append(result, errors.New("no pods match the configured filters"))
+	}
+
+	return candidates, result.ErrorOrNil()
+}
+
+// kindResolver returns the Resolver the kind filter shares across ticks, or a fresh one if the
+// Chaoskube object wasn't created by New.
+func (c *Chaoskube) kindResolver() *workload.Resolver {
+	if c.kindCache != nil {
+		return c.kindCache
+	}
+	return workload.NewResolver(c.Client)
+}
+
+// lister returns the configured Lister or one that queries the API server.
+func (c *Chaoskube) lister() Lister {
+	if c.Lister != nil {
+		return c.Lister
+	}
+	if c.MetadataClient != nil {
+		return metadataLister{client: c.MetadataClient, namespaces: c.namespaceScope(), parallelism: c.ListParallelism, labels: c.Labels, fields: c.PodFieldSelector()}
+	}
+	return apiLister{client: c.Client, namespaces: c.namespaceScope(), parallelism: c.ListParallelism, labels: c.Labels, fields: c.PodFieldSelector()}
+}
+
+// strategy returns the configured Strategy, or selects victims uniformly at random if there is none.
+func (c *Chaoskube) strategy() strategy.Strategy {
+	if c.Strategy != nil {
+		return c.Strategy
+	}
+	return strategy.Uniform{}
+}
+
+// samplesOwners returns true iff victims are selected by a strategy that picks at most one pod
+// per owner itself, so candidates don't need to be reduced to one per owner upfront.
+func (c *Chaoskube) samplesOwners() bool {
+	if c.CostProvider != nil && c.CostWeighting {
+		return false
+	}
+	_, ok := c.strategy().(strategy.OwnerSampler)
+	return ok
+}
+
+// namespaceScope returns the namespaces the Kubernetes client is scoped to.
+func (c *Chaoskube) namespaceScope() []string {
+	return util.ParseNamespaceScope(c.ClientNamespaceScope)
+}
+
+// PodFieldSelector returns the field selector to list pods with. Only running pods are ever
+// considered, so they are filtered on the API server already, along with the configured field selector.
+func (c *Chaoskube) PodFieldSelector() fields.Selector {
+	running := fields.OneTermEqualSelector("status.phase", string(v1.PodRunning))
+	if c.FieldSelector == nil || c.FieldSelector.Empty() {
+		return running
+	}
+	return fields.AndSelectors(running, c.FieldSelector)
+}
+
+// serverDryRunTerminator returns the Terminator to pass victims to in dry-run mode, if server-side
+// dry runs are enabled. Only a plain delete-pod terminator sending its deletions as dry runs is
+// safe to call, all others would cause real chaos.
+func (c *Chaoskube) serverDryRunTerminator() terminator.Terminator {
+	if !c.ServerDryRun {
+		return nil
+	}
+	deletePod, ok := c.Terminator.(*terminator.DeletePodTerminator)
+	if !ok || !deletePod.ServerDryRun {
+		return nil
+	}
+	return deletePod
+}
+
+// DeletePod deletes the given pod with the selected terminator.
+// It will not delete the pod if dry-run mode is enabled.
+func (c *Chaoskube) DeletePod(ctx context.Context, victim v1.Pod) error {
+	c.Logger.WithFields(log.Fields{
+		"namespace": victim.Namespace,
+		"name":      victim.Name,
+	}).Info("terminating pod")
+
+	var logTail string
+	if c.LogTailLines > 0 {
+		logTail = c.logTail(ctx, victim)
+	}
+
+	if c.Snapshots != nil {
+		if err := c.snapshot(ctx, victim); err != nil {
+			c.Logger.WithFields(log.Fields{
+				"namespace": victim.Namespace,
+				"name":      victim.Name,
+				"err":       err,
+			}).Warn("failed to snapshot victim")
+		}
+	}
+
+	// return early if we're running in dryRun mode, after passing the deletion by the API
+	// server if server-side dry runs are enabled.
+	if c.DryRun {
+		if deletePod := c.serverDryRunTerminator(); deletePod != nil {
+			if err := deletePod.Terminate(ctx, victim); err != nil {
+				return err
+			}
+		}
+		c.record(ctx, victim)
+		c.advanceOrdinal(victim)
+		c.markKill()
+		return nil
+	}
+
+	start := time.Now()
+	err := c.Terminator.Terminate(ctx, victim)
+	metrics.TerminationDurationSeconds.Observe(time.Since(start).Seconds())
+
+	// the UID precondition failed, so the pod was replaced and the replacement is left alone
+	if apierrors.IsConflict(err) {
+		c.Logger.WithFields(log.Fields{
+			"namespace": victim.Namespace,
+			"name":      victim.Name,
+			"uid":       victim.UID,
+		}).Warn(msgPodReplaced)
+		metrics.PodsReplacedTotal.WithLabelValues(victim.Namespace).Inc()
+		return nil
+	}
+
+	c.stampOwner(ctx, victim, err)
+	if err != nil {
+		return err
+	}
+
+	metrics.PodsDeletedTotal.WithLabelValues(victim.Namespace).Inc()
+
+	c.record(ctx, victim)
+	c.advanceOrdinal(victim)
+	c.markKill()
+	c.spendBudget()
+	c.spendCost(ctx, victim)
+
+	ref, err := reference.GetReference(scheme.Scheme, &victim)
+	if err != nil {
+		return err
+	}
+
+	c.EventRecorder.Event(ref, v1.EventTypeNormal, "Killing", "Pod was terminated by chaoskube to introduce chaos.")
+
+	if logTail != "" {
+		// don't modify the annotations shared with the given pod
+		annotations := make(map[string]string, len(victim.Annotations)+1)
+		for key, value := range victim.Annotations {
+			annotations[key] = value
+		}
+		annotations[notifier.LogTailAnnotation] = logTail
+		victim.Annotations = annotations
+	}
+
+	if err := c.Notifier.NotifyPodTermination(victim); err != nil {
+		c.Logger.WithField("err", err).Warn("failed to notify pod termination")
+	}
+
+	return nil
+}
+
+// record adds the termination of the given pod to the history, if there is one. The pod's
+// top-level workload is only resolved if workload intervals are honored. Log tails are left out,
+// as they may contain secrets and would void the history's memory bound.
+func (c *Chaoskube) record(ctx context.Context, victim v1.Pod) {
+	if c.History == nil {
+		return
+	}
+
+	entry := history.Entry{
+		Time:      c.Now(),
+		Namespace: victim.Namespace,
+		Name:      victim.Name,
+		Owner:     history.OwnerKey(victim),
+		DryRun:    c.DryRun,
+	}
+
+	if c.WorkloadIntervals {
+		w, err := workload.NewResolver(c.Client).Resolve(ctx, victim)
+		if err != nil {
+			c.Logger.WithField("err", err).Warn("failed to resolve workload of terminated pod")
+		}
+		if w != nil {
+			entry.Workload = string(w.UID)
+		}
+	}
+
+	c.History.Add(entry)
+}
+
+// logTail returns the last lines of the logs of each container of the given pod, headed by the
+// container name if there are several. Failures are only logged, since the logs are merely informational.
+func (c *Chaoskube) logTail(ctx context.Context, pod v1.Pod) string {
+	tails := []string{}
+	for _, container := range pod.Spec.Containers {
+		options := &v1.PodLogOptions{
+			Container:  container.Name,
+			TailLines:  &c.LogTailLines,
+			LimitBytes: &maxLogTailBytes,
+		}
+
+		raw, err := c.Client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, options).DoRaw(ctx)
+		if err != nil {
+			c.Logger.WithFields(log.Fields{
+				"namespace": pod.Namespace,
+				"name":      pod.Name,
+				"container": container.Name,
+				"err":       err,
+			}).Warn("failed to fetch logs of victim")
+			continue
+		}
+
+		tail := strings.TrimRight(string(raw), "\n")
+		if len(pod.Spec.Containers) > 1 {
+			tail = "==> " + container.Name + " <==\n" + tail
+		}
+		tails = append(tails, tail)
+	}
+
+	return strings.Join(tails, "\n")
+}
+
+// snapshot saves the full manifest of the given pod and the events involving it to the snapshot
+// store. The pod is fetched again, since candidates may only consist of metadata.
+func (c *Chaoskube) snapshot(ctx context.Context, victim v1.Pod) error {
+	pod, err := c.Client.CoreV1().Pods(victim.Namespace).Get(ctx, victim.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	events, err := c.Client.CoreV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(pod.UID)).String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// field selectors on events aren't honored by every implementation, e.g. fake clients
+	involved := []v1.Event{}
+	for _, event := range events.Items {
+		if event.InvolvedObject.UID == pod.UID {
+			involved = append(involved, event)
+		}
+	}
+
+	pod.ManagedFields = nil
+
+	return c.Snapshots.Save(snapshot.Snapshot{
+		Time:   c.Now(),
+		Pod:    *pod,
+		Events: involved,
+	})
+}
+
+// stampOwner annotates the top-level workload of the given pod with the time, run ID and result
+// of its termination, if enabled. Failures are only logged, since the termination already happened.
+func (c *Chaoskube) stampOwner(ctx context.Context, victim v1.Pod, terminateErr error) {
+	if !c.StampOwners {
+		return
+	}
+
+	w, err := workload.NewResolver(c.Client).Resolve(ctx, victim)
+	if err != nil {
+		c.Logger.WithField("err", err).Warn("failed to resolve workload of terminated pod")
+		return
+	}
+	if w == nil {
+		return
+	}
+
+	result := "terminated"
+	if terminateErr != nil {
+		result = "failed"
+	}
+
+	err = workload.Annotate(ctx, c.Client, w, map[string]string{
+		lastChaosAtAnnotation:     c.Now().UTC().Format(time.RFC3339),
+		lastChaosRunIDAnnotation:  c.RunID,
+		lastChaosResultAnnotation: result,
+	})
+	if err != nil {
+		c.Logger.WithFields(log.Fields{
+			"kind": w.Kind,
+			"name": w.Name,
+			"err":  err,
+		}).Warn("failed to stamp workload")
+	}
+}
+
+// targetOrdinal returns the ordinal to target for the given StatefulSet with the given number of replicas.
+func (c *Chaoskube) targetOrdinal(uid types.UID, replicas int) int {
+	if c.StatefulSets != StatefulSetsDescending {
+		return replicas - 1
+	}
+
+	c.ordinalMutex.Lock()
+	defer c.ordinalMutex.Unlock()
+
+	// start over from the top when all ordinals were targeted or the StatefulSet was scaled down
+	next, ok := c.nextOrdinals[uid]
+	if !ok || next >= replicas {
+		return replicas - 1
+	}
+	return next
+}
+
+// advanceOrdinal moves on to the next lower ordinal after the given pod of a StatefulSet was
+// terminated, if StatefulSets are targeted in descending order.
+func (c *Chaoskube) advanceOrdinal(victim v1.Pod) {
+	if c.StatefulSets != StatefulSetsDescending {
+		return
+	}
+
+	ref := metav1.GetControllerOf(&victim)
+	if ref == nil || ref.Kind != "StatefulSet" {
+		return
+	}
+
+	ordinal, ok := statefulSetOrdinal(victim, ref.Name)
+	if !ok {
+		return
+	}
+
+	c.ordinalMutex.Lock()
+	defer c.ordinalMutex.Unlock()
+
+	if c.nextOrdinals == nil {
+		c.nextOrdinals = map[types.UID]int{}
+	}
+	if ordinal > 0 {
+		c.nextOrdinals[ref.UID] = ordinal - 1
+	} else {
+		delete(c.nextOrdinals, ref.UID)
+	}
+}
+
+// ParseKinds parses a kind selector, e.g. Deployment,!DaemonSet. Besides plain kinds, which match
+// any group and version, it accepts kinds qualified by their API version, e.g. apps/v1:Deployment,
+// to tell apart custom controllers of the same kind. Those are turned into valid label keys, see
+// kindKeys.
+func ParseKinds(kinds string) (labels.Selector, error) {
+	terms := []string{}
+	for _, term := range strings.Split(kinds, ",") {
+		negated := strings.HasPrefix(strings.TrimSpace(term), "!")
+		apiVersion, kind, qualified := strings.Cut(strings.TrimPrefix(strings.TrimSpace(term), "!"), ":")
+		if qualified {
+			term = qualifiedKindKey(apiVersion, kind)
+			if negated {
+				term = "!" + term
+			}
+		}
+		terms = append(terms, term)
+	}
+
+	return labels.Parse(strings.Join(terms, ","))
+}
+
+// qualifiedKindKey turns a kind and its API version into a valid label key, e.g. apps/v1 and
+// Deployment into v1.apps/Deployment, and v1 and Node into v1/Node.
+func qualifiedKindKey(apiVersion, kind string) string {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil || gv.Group == "" {
+		return apiVersion + "/" + kind
+	}
+	return gv.Version + "." + gv.Group + "/" + kind
+}
+
+// kindKeys returns the label set an owner of the given API version and kind matches against.
+func kindKeys(apiVersion, kind string) labels.Set {
+	return labels.Set{kind: "", qualifiedKindKey(apiVersion, kind): ""}
+}
+
+// filterByKinds filters a list of pods by a given kind selector, see ParseKinds. It matches the
+// pods' direct owners as well as their top-level workloads, e.g. the Deployment owning the
+// ReplicaSet owning a pod, if a resolver is given. Pods whose workload can't be resolved are
+// left out and the errors returned alongside the remaining pods.
+func filterByKinds(ctx context.Context, pods []v1.Pod, kinds labels.Selector, resolver *workload.Resolver) ([]v1.Pod, error) {
+	// empty filter returns original list
+	if kinds.Empty() {
+		return pods, nil
+	}
+
+	// split requirements into including and excluding groups
+	reqs, _ := kinds.Requirements()
+	reqIncl := []labels.Requirement{}
+	reqExcl := []labels.Requirement{}
+
+	for _, req := range reqs {
+		switch req.Operator() {
+		case selection.Exists:
+			reqIncl = append(reqIncl, req)
+		case selection.DoesNotExist:
+			reqExcl = append(reqExcl, req)
+		default:
+			return nil, fmt.Errorf("unsupported operator: %s", req.Operator())
+		}
+	}
+
+	// there are only a few distinct kinds, so evaluate the requirements once per kind
+	type decision struct{ include, exclude bool }
+	decisions := map[string]decision{}
+
+	decide := func(apiVersion, kind string) decision {
+		if d, ok := decisions[apiVersion+":"+kind]; ok {
+			return d
+		}
+
+		// convert the owner's kind to an equivalent label selector
+		selector := kindKeys(apiVersion, kind)
+
+		d := decision{}
+
+		// include pod if one including requirement matches
+		for _, req := range reqIncl {
+			if req.Matches(selector) {
+				d.include = true
+				break
+			}
+		}
+
+		// exclude pod if it is filtered out by at least one excluding requirement
+		for _, req := range reqExcl {
+			if !req.Matches(selector) {
+				d.exclude = true
+				break
+			}
+		}
+
+		decisions[apiVersion+":"+kind] = d
+		return d
+	}
+
+	var result *multierror.Error
+
+	filteredList := filterPods(pods, func(pod *v1.Pod) bool {
+		// if there aren't any including requirements, we're in by default
+		included := len(reqIncl) == 0
+		excluded := false
+
+		directKinds := map[string]bool{}
+		for _, ref := range pod.GetOwnerReferences() {
+			directKinds[ref.Kind] = true
+
+			d := decide(ref.APIVersion, ref.Kind)
+			if d.include {
+				included = true
+			}
+			if d.exclude {
+				excluded = true
+			}
+		}
+
+		if excluded || resolver == nil || metav1.GetControllerOf(pod) == nil {
+			return included && !excluded
+		}
+
+		// the top-level workload only matters for requirements the direct owners can't answer,
+		// i.e. qualified kinds and kinds none of them has, so the API is only asked if needed
+		unanswered := func(reqs []labels.Requirement) bool {
+			for _, req := range reqs {
+				if strings.Contains(req.Key(), "/") || !directKinds[req.Key()] {
+					return true
+				}
+			}
+			return false
+		}
+		if (included || !unanswered(reqIncl)) && !unanswered(reqExcl) {
+			return included
+		}
+
+		w, err := resolver.Resolve(ctx, *pod)
+		if err != nil {
+			// leave out the pod rather than risking a kind that is excluded
+			result = multierror.Append(result, err)
+			return false
+		}
+		if w != nil {
+			d := decide(w.APIVersion, w.Kind)
+			included = included || d.include
+			excluded = d.exclude
+		}
+
+		return included && !excluded
+	})
+
+	return filteredList, result.ErrorOrNil()
+}
+
+// filterByQOSClasses filters a list of pods by a selector of their lower-case QoS classes, e.g.
+// burstable,besteffort or !guaranteed. Pods whose QoS class isn't known, e.g. when listing only
+// metadata, don't match any class.
+func filterByQOSClasses(pods []v1.Pod, classes labels.Selector) ([]v1.Pod, error) {
+	reqs, _ := classes.Requirements()
+	for _, req := range reqs {
+		if req.Operator() != selection.Exists && req.Operator() != selection.DoesNotExist {
+			return nil, fmt.Errorf("unsupported operator: %s", req.Operator())
+		}
+	}
+
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		class := labels.Set{strings.ToLower(string(pod.Status.QOSClass)): ""}
+
+		// if there aren't any including requirements, we're in by default
+		included := true
+		for _, req := range reqs {
+			if req.Operator() == selection.Exists {
+				included = false
+				break
+			}
+		}
+
+		for _, req := range reqs {
+			switch {
+			case req.Operator() == selection.Exists && req.Matches(class):
+				included = true
+			case req.Operator() == selection.DoesNotExist && !req.Matches(class):
+				return false
+			}
+		}
+
+		return included
+	}), nil
+}
+
+// filterByNamespaces filters a list of pods by a given namespace selector.
+func filterByNamespaces(pods []v1.Pod, namespaces labels.Selector) ([]v1.Pod, error) {
+	// empty filter returns original list
+	if namespaces.Empty() {
+		return pods, nil
+	}
+
+	// split requirements into including and excluding groups
+	reqs, _ := namespaces.Requirements()
+	reqIncl := []labels.Requirement{}
+	reqExcl := []labels.Requirement{}
+
+	for _, req := range reqs {
+		switch req.Operator() {
+		case selection.Exists:
+			reqIncl = append(reqIncl, req)
+		case selection.DoesNotExist:
+			reqExcl = append(reqExcl, req)
+		default:
+			return nil, fmt.Errorf("unsupported operator: %s", req.Operator())
+		}
+	}
+
+	// there are far fewer namespaces than pods, so evaluate the requirements once per namespace
+	decisions := map[string]bool{}
+
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		if included, ok := decisions[pod.Namespace]; ok {
+			return included
+		}
+
+		// if there aren't any including requirements, we're in by default
+		included := len(reqIncl) == 0
+
+		// convert the pod's namespace to an equivalent label selector
+		selector := labels.Set{pod.Namespace: ""}
+
+		// include pod if one including requirement matches
+		for _, req := range reqIncl {
+			if req.Matches(selector) {
+				included = true
+				break
+			}
+		}
+
+		// exclude pod if it is filtered out by at least one excluding requirement
+		for _, req := range reqExcl {
+			if !req.Matches(selector) {
+				included = false
+				break
+			}
+		}
+
+		decisions[pod.Namespace] = included
+		return included
+	}), nil
+}
+
+// filterPodsByNamespaceLabels filters a list of pods by a given label selector on their namespace.
+func filterPodsByNamespaceLabels(ctx context.Context, pods []v1.Pod, labels labels.Selector, lister Lister) ([]v1.Pod, error) {
+	// empty filter returns original list
+	if labels.Empty() {
+		return pods, nil
+	}
+
+	// find all namespaces matching the label selector
+	namespaces, err := lister.ListNamespaces(ctx, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[string]struct{}, len(namespaces))
+	for _, namespace := range namespaces {
+		matched[namespace.Name] = struct{}{}
+	}
+
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		// include pod if its in one of the matched namespaces
+		_, ok := matched[pod.Namespace]
+		return ok
+	}), nil
+}
+
+// filterByNamespaceLevels randomly thins out a list of pods by the chaos level annotation of their
+// namespace, e.g. 0.5 or 2.0, defaulting to 1. Pods in the namespaces with the highest level are
+// kept, the others with a probability relative to it, so that relative selection probabilities
+// scale with the level. A level of 0 excludes a namespace.
+func filterByNamespaceLevels(ctx context.Context, pods []v1.Pod, lister Lister, rnd *rand.Rand) ([]v1.Pod, error) {
+	namespaces, err := lister.ListNamespaces(ctx, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make(map[string]float64, len(namespaces))
+	for _, namespace := range namespaces {
+		value, ok := namespace.Annotations[levelAnnotation]
+		if !ok {
+			continue
+		}
+		level, err := strconv.ParseFloat(value, 64)
+		if err != nil || level < 0 {
+			continue
+		}
+		levels[namespace.Name] = level
+	}
+
+	level := func(pod *v1.Pod) float64 {
+		if level, ok := levels[pod.Namespace]; ok {
+			return level
+		}
+		return 1
+	}
+
+	// only the namespaces of candidates matter for their relative probabilities
+	maxLevel := 0.0
+	for i := range pods {
+		maxLevel = math.Max(maxLevel, level(&pods[i]))
+	}
+
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		return level(pod) > 0 && rnd.Float64() < level(pod)/maxLevel
+	}), nil
+}
+
+// filterByProbability randomly thins out a list of pods by their probability annotation, e.g. 0.2
+// to keep a pod as a candidate in only one out of five intervals. Pods without a valid probability
+// are always kept.
+func filterByProbability(pods []v1.Pod, rnd *rand.Rand) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		value, ok := pod.Annotations[probabilityAnnotation]
+		if !ok {
+			return true
+		}
+		probability, err := strconv.ParseFloat(value, 64)
+		if err != nil || probability < 0 {
+			return true
+		}
+		return rnd.Float64() < probability
+	})
+}
+
+// filterByMaxLifetime filters a list of pods by the max-lifetime annotation. Only pods older than
+// their declared maximum lifetime at the given time are kept, pods without a valid one are removed.
+func filterByMaxLifetime(pods []v1.Pod, now time.Time) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		maxLifetime, err := time.ParseDuration(pod.Annotations[maxLifetimeAnnotation])
+		if err != nil || maxLifetime <= 0 {
+			return false
+		}
+		return now.Sub(pod.CreationTimestamp.Time) > maxLifetime
+	})
+}
+
+// oldestPerOwner reduces a list of pods to the perOwner oldest pods of each owner. Pods without an
+// owner are all kept. The result is ordered from the oldest to the youngest pod.
+func oldestPerOwner(pods []v1.Pod, perOwner int) []v1.Pod {
+	sorted := slices.Clone(pods)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+	})
+
+	counts := make(map[types.UID]int)
+	return filterPods(sorted, func(pod *v1.Pod) bool {
+		refs := pod.GetOwnerReferences()
+		if len(refs) == 0 {
+			return true
+		}
+		counts[refs[0].UID]++
+		return counts[refs[0].UID] <= perOwner
+	})
+}
+
+// filterByOptIn filters a list of pods by the enabled annotation of the pods themselves or, if
+// they don't carry one, of their namespaces. Pods are kept if the annotation is true and removed
+// if it's false. Pods without either annotation are kept unless defaultOptOut is set.
+func filterByOptIn(ctx context.Context, pods []v1.Pod, lister Lister, defaultOptOut bool) ([]v1.Pod, error) {
+	namespaces, err := lister.ListNamespaces(ctx, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	enabledNamespaces := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		if enabled, err := strconv.ParseBool(namespace.Annotations[enabledAnnotation]); err == nil {
+			enabledNamespaces[namespace.Name] = enabled
+		}
+	}
+
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		if enabled, err := strconv.ParseBool(pod.Annotations[enabledAnnotation]); err == nil {
+			return enabled
+		}
+		if enabled, ok := enabledNamespaces[pod.Namespace]; ok {
+			return enabled
+		}
+		return !defaultOptOut
+	}), nil
+}
+
+// filterBySnooze filters a list of pods by the snooze annotation of the pods themselves, their
+// top-level workloads and their namespaces. Pods are removed while any of them holds an RFC 3339
+// timestamp in the future. Invalid timestamps are ignored.
+func filterBySnooze(ctx context.Context, pods []v1.Pod, lister Lister, resolver *workload.Resolver, now time.Time) ([]v1.Pod, error) {
+	snoozed := func(object metav1.Object) bool {
+		until, err := time.Parse(time.RFC3339, object.GetAnnotations()[snoozeAnnotation])
+		return err == nil && now.Before(until)
+	}
+
+	namespaces, err := lister.ListNamespaces(ctx, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	snoozedNamespaces := make(map[string]bool, len(namespaces))
+	for i := range namespaces {
+		snoozedNamespaces[namespaces[i].Name] = snoozed(&namespaces[i])
+	}
+
+	var resolveErr error
+
+	filteredList := filterPods(pods, func(pod *v1.Pod) bool {
+		if resolveErr != nil || snoozed(pod) || snoozedNamespaces[pod.Namespace] {
+			return false
+		}
+
+		w, err := resolver.Resolve(ctx, *pod)
+		if err != nil {
+			resolveErr = err
+			return false
+		}
+
+		return w == nil || w.Object == nil || !snoozed(w.Object)
+	})
+	if resolveErr != nil {
+		return nil, resolveErr
+	}
+
+	return filteredList, nil
+}
+
+// filterByWorkloadIntervals filters a list of pods by the minimum interval annotation of their
+// top-level workloads, e.g. 24h, given the time of the last termination per workload UID.
+// Invalid intervals are ignored.
+func filterByWorkloadIntervals(ctx context.Context, pods []v1.Pod, resolver *workload.Resolver, lastTerminations map[string]time.Time, now time.Time) ([]v1.Pod, error) {
+	var resolveErr error
+
+	filteredList := filterPods(pods, func(pod *v1.Pod) bool {
+		if resolveErr != nil {
+			return false
+		}
+
+		w, err := resolver.Resolve(ctx, *pod)
+		if err != nil {
+			resolveErr = err
+			return false
+		}
+		if w == nil || w.Object == nil {
+			return true
+		}
+
+		interval, err := time.ParseDuration(w.Object.GetAnnotations()[minIntervalAnnotation])
+		if err != nil {
+			return true
+		}
+
+		last, ok := lastTerminations[string(w.UID)]
+		return !ok || now.Sub(last) >= interval
+	})
+	if resolveErr != nil {
+		return nil, resolveErr
+	}
+
+	return filteredList, nil
+}
+
+// filterByOwnerReadiness filters out pods whose top-level workload has fewer than the given number
+// of ready replicas, so that the last healthy replicas of a service are left alone. Pods without
+// a workload or whose workload has no notion of readiness, e.g. Jobs, are kept.
+func filterByOwnerReadiness(ctx context.Context, pods []v1.Pod, resolver *workload.Resolver, minReady int) ([]v1.Pod, error) {
+	var resolveErr error
+
+	filteredList := filterPods(pods, func(pod *v1.Pod) bool {
+		if resolveErr != nil {
+			return false
+		}
+
+		w, err := resolver.Resolve(ctx, *pod)
+		if err != nil {
+			resolveErr = err
+			return false
+		}
+		if w == nil {
+			return true
+		}
+
+		ready, ok := workload.ReadyReplicas(w)
+		return !ok || int(ready) >= minReady
+	})
+	if resolveErr != nil {
+		return nil, resolveErr
+	}
+
+	return filteredList, nil
+}
+
+// nodeZones returns the topology zone of each node by its name. Nodes without a zone are left out.
+func nodeZones(ctx context.Context, client kubernetes.Interface) (map[string]string, error) {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make(map[string]string, len(nodes.Items))
+	for _, node := range nodes.Items {
+		if zone, ok := node.Labels[v1.LabelTopologyZone]; ok {
+			zones[node.Name] = zone
+		}
+	}
+	return zones, nil
+}
+
+// ownerZone returns a key for the controller and the topology zone of the given pod. It returns
+// false for pods without a controller or zone.
+func ownerZone(pod *v1.Pod, zones map[string]string) (string, bool) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return "", false
+	}
+	zone, ok := zones[pod.Spec.NodeName]
+	if !ok {
+		return "", false
+	}
+	return string(ref.UID) + "/" + zone, true
+}
+
+// zoneCoverage counts the pods of each controller per topology zone.
+func zoneCoverage(pods []v1.Pod, zones map[string]string) map[string]int {
+	coverage := map[string]int{}
+	for i := range pods {
+		if key, ok := ownerZone(&pods[i], zones); ok {
+			coverage[key]++
+		}
+	}
+	return coverage
+}
+
+// filterByZoneCoverage thins out a list of pods so that terminating all of them leaves every
+// controller with at least one pod in each topology zone it covers, given the number of pods
+// per controller and zone. It keeps a random selection of the pods where it has to choose.
+func filterByZoneCoverage(pods []v1.Pod, zones map[string]string, coverage map[string]int, rnd *rand.Rand) []v1.Pod {
+	keep := make([]bool, len(pods))
+	taken := map[string]int{}
+	for _, i := range rnd.Perm(len(pods)) {
+		key, ok := ownerZone(&pods[i], zones)
+		if !ok {
+			keep[i] = true
+			continue
+		}
+		if taken[key] < coverage[key]-1 {
+			taken[key]++
+			keep[i] = true
+		}
+	}
+
+	// filterPods visits the pods in order
+	i := -1
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		i++
+		return keep[i]
+	})
+}
+
+// filterByRollouts filters out pods whose top-level workload is rolling out a change, since
+// terminations in the middle of a deployment create noise and can mask genuine rollout failures.
+func filterByRollouts(ctx context.Context, pods []v1.Pod, resolver *workload.Resolver) ([]v1.Pod, error) {
+	var resolveErr error
+
+	filteredList := filterPods(pods, func(pod *v1.Pod) bool {
+		if resolveErr != nil {
+			return false
+		}
+
+		w, err := resolver.Resolve(ctx, *pod)
+		if err != nil {
+			resolveErr = err
+			return false
+		}
+
+		return w == nil || !workload.RolloutInProgress(w)
+	})
+	if resolveErr != nil {
+		return nil, resolveErr
+	}
+
+	return filteredList, nil
+}
+
+// filterByVirtualNodes filters a list of pods by whether they are scheduled on a virtual node,
+// whose termination semantics and costs differ from regular nodes.
+func filterByVirtualNodes(ctx context.Context, pods []v1.Pod, mode string, client kubernetes.Interface) ([]v1.Pod, error) {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	virtual := map[string]bool{}
+	for _, node := range nodes.Items {
+		if isVirtualNode(node) {
+			virtual[node.Name] = true
+		}
+	}
+
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		return virtual[pod.Spec.NodeName] == (mode == VirtualNodesOnly)
+	}), nil
 }
 
-// filterByKinds filters a list of pods by a given kind selector.
-func filterByKinds(pods []v1.Pod, kinds labels.Selector) ([]v1.Pod, error) {
-	// empty filter returns original list
-	if kinds.Empty() {
-		return pods, nil
+// filterByNodes filters a list of pods by the node they are scheduled on, keeping only pods on
+// nodes matching the given label selector and name regular expression, either of which may be
+// nil. Nodes are only listed if there is a label selector.
+func filterByNodes(ctx context.Context, pods []v1.Pod, client kubernetes.Interface, selector labels.Selector, names *regexp.Regexp) ([]v1.Pod, error) {
+	var matching map[string]bool
+	if selector != nil && !selector.Empty() {
+		nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+
+		matching = make(map[string]bool, len(nodes.Items))
+		for _, node := range nodes.Items {
+			matching[node.Name] = true
+		}
 	}
 
-	// split requirements into including and excluding groups
-	reqs, _ := kinds.Requirements()
-	reqIncl := []labels.Requirement{}
-	reqExcl := []labels.Requirement{}
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		if matching != nil && !matching[pod.Spec.NodeName] {
+			return false
+		}
+		return names == nil || names.MatchString(pod.Spec.NodeName)
+	}), nil
+}
 
-	for _, req := range reqs {
-		switch req.Operator() {
-		case selection.Exists:
-			reqIncl = append(reqIncl, req)
-		case selection.DoesNotExist:
-			reqExcl = append(reqExcl, req)
-		default:
-			return nil, fmt.Errorf("unsupported operator: %s", req.Operator())
+// filterByPVCs filters out pods mounting PersistentVolumeClaims, including generic ephemeral
+// volumes, since stateful pods often need different chaos treatment. If access modes are given,
+// only claims with one of them count, e.g. ReadWriteOnce.
+func filterByPVCs(ctx context.Context, pods []v1.Pod, client kubernetes.Interface, accessModes []v1.PersistentVolumeAccessMode, namespaces []string) ([]v1.Pod, error) {
+	// the claims with one of the access modes by namespace/name, nil if any claim counts
+	var matching map[string]bool
+	if len(accessModes) > 0 {
+		matching = map[string]bool{}
+		for _, namespace := range namespaces {
+			claims, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			for _, claim := range claims.Items {
+				for _, mode := range claim.Spec.AccessModes {
+					if slices.Contains(accessModes, mode) {
+						matching[claim.Namespace+"/"+claim.Name] = true
+					}
+				}
+			}
 		}
 	}
 
-	filteredList := []v1.Pod{}
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		for _, volume := range pod.Spec.Volumes {
+			var claim string
+			switch {
+			case volume.PersistentVolumeClaim != nil:
+				claim = volume.PersistentVolumeClaim.ClaimName
+			case volume.Ephemeral != nil:
+				// generic ephemeral volumes are backed by a claim named after the pod and volume
+				claim = pod.Name + "-" + volume.Name
+			default:
+				continue
+			}
+			if matching == nil || matching[pod.Namespace+"/"+claim] {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
 
-	for _, pod := range pods {
-		// if there aren't any including requirements, we're in by default
-		included := len(reqIncl) == 0
+// filterBySoleEndpoints filters out pods that are the only ready endpoint of a Service according
+// to its EndpointSlices, since terminating them takes the Service down entirely, which matters
+// most for singleton services without a disruption budget.
+func filterBySoleEndpoints(ctx context.Context, pods []v1.Pod, client kubernetes.Interface, namespaces []string) ([]v1.Pod, error) {
+	// a Service's ready pods, which may be spread over several slices
+	services := map[string]map[string]bool{}
+
+	for _, namespace := range namespaces {
+		list, err := client.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
 
-		// Check owner reference
-		for _, ref := range pod.GetOwnerReferences() {
-			// convert the pod's owner kind to an equivalent label selector
-			selector := labels.Set{ref.Kind: ""}
-
-			// include pod if one including requirement matches
-			for _, req := range reqIncl {
-				if req.Matches(selector) {
-					included = true
-					break
-				}
+		for _, slice := range list.Items {
+			service, ok := slice.Labels[discoveryv1.LabelServiceName]
+			if !ok {
+				continue
+			}
+			key := slice.Namespace + "/" + service
+			if services[key] == nil {
+				services[key] = map[string]bool{}
 			}
 
-			// exclude pod if it is filtered out by at least one excluding requirement
-			for _, req := range reqExcl {
-				if !req.Matches(selector) {
-					included = false
-					break
+			for _, endpoint := range slice.Endpoints {
+				// a missing ready condition is to be interpreted as ready
+				if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+					continue
+				}
+				if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+					continue
 				}
+				services[key][slice.Namespace+"/"+endpoint.TargetRef.Name] = true
 			}
 		}
+	}
 
-		if included {
-			filteredList = append(filteredList, pod)
+	sole := map[string]bool{}
+	for _, ready := range services {
+		if len(ready) == 1 {
+			for pod := range ready {
+				sole[pod] = true
+			}
 		}
 	}
 
-	return filteredList, nil
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		return !sole[pod.Namespace+"/"+pod.Name]
+	}), nil
 }
 
-// filterByNamespaces filters a list of pods by a given namespace selector.
-func filterByNamespaces(pods []v1.Pod, namespaces labels.Selector) ([]v1.Pod, error) {
-	// empty filter returns original list
-	if namespaces.Empty() {
-		return pods, nil
+// isVirtualNode returns true iff the given node is backed by virtual-kubelet, e.g. Azure virtual
+// nodes, or by AWS Fargate.
+func isVirtualNode(node v1.Node) bool {
+	if node.Labels["type"] == "virtual-kubelet" || node.Labels["eks.amazonaws.com/compute-type"] == "fargate" {
+		return true
 	}
 
-	// split requirements into including and excluding groups
-	reqs, _ := namespaces.Requirements()
-	reqIncl := []labels.Requirement{}
-	reqExcl := []labels.Requirement{}
-
-	for _, req := range reqs {
-		switch req.Operator() {
-		case selection.Exists:
-			reqIncl = append(reqIncl, req)
-		case selection.DoesNotExist:
-			reqExcl = append(reqExcl, req)
-		default:
-			return nil, fmt.Errorf("unsupported operator: %s", req.Operator())
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == "virtual-kubelet.io/provider" {
+			return true
 		}
 	}
 
-	filteredList := []v1.Pod{}
-
-	for _, pod := range pods {
-		// if there aren't any including requirements, we're in by default
-		included := len(reqIncl) == 0
+	return false
+}
 
-		// convert the pod's namespace to an equivalent label selector
-		selector := labels.Set{pod.Namespace: ""}
+// filterByStatefulSetOrdinals filters the pods of StatefulSets by their ordinal, keeping only the
+// pod with the ordinal returned by target, given the StatefulSet's UID and number of replicas.
+// Pods not controlled by a StatefulSet are kept.
+func filterByStatefulSetOrdinals(ctx context.Context, pods []v1.Pod, client kubernetes.Interface, target func(uid types.UID, replicas int) int) ([]v1.Pod, error) {
+	// there are far fewer StatefulSets than pods, so fetch each of them once
+	replicas := map[types.UID]int{}
+	var getErr error
+
+	filteredList := filterPods(pods, func(pod *v1.Pod) bool {
+		ref := metav1.GetControllerOf(pod)
+		if ref == nil || ref.Kind != "StatefulSet" {
+			return true
+		}
+		if getErr != nil {
+			return false
+		}
 
-		// include pod if one including requirement matches
-		for _, req := range reqIncl {
-			if req.Matches(selector) {
-				included = true
-				break
+		if _, ok := replicas[ref.UID]; !ok {
+			statefulSet, err := client.AppsV1().StatefulSets(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			if err != nil {
+				getErr = err
+				return false
+			}
+			replicas[ref.UID] = 1
+			if statefulSet.Spec.Replicas != nil {
+				replicas[ref.UID] = int(*statefulSet.Spec.Replicas)
 			}
 		}
 
-		// exclude pod if it is filtered out by at least one excluding requirement
-		for _, req := range reqExcl {
-			if !req.Matches(selector) {
-				included = false
-				break
-			}
+		ordinal, ok := statefulSetOrdinal(*pod, ref.Name)
+		return ok && ordinal == target(ref.UID, replicas[ref.UID])
+	})
+	if getErr != nil {
+		return nil, getErr
+	}
+
+	return filteredList, nil
+}
+
+// filterByJobProgress filters the pods of Jobs by how far their Job progressed, removing pods of
+// Jobs that reached the given threshold, e.g. 0.9, of their completions or active deadline.
+// Pods not controlled by a Job are kept.
+func filterByJobProgress(ctx context.Context, pods []v1.Pod, client kubernetes.Interface, threshold float64, now time.Time) ([]v1.Pod, error) {
+	// there are far fewer Jobs than pods, so decide once per Job
+	decisions := map[types.UID]bool{}
+	var getErr error
+
+	filteredList := filterPods(pods, func(pod *v1.Pod) bool {
+		ref := metav1.GetControllerOf(pod)
+		if ref == nil || ref.Kind != "Job" {
+			return true
+		}
+		if getErr != nil {
+			return false
 		}
 
-		if included {
-			filteredList = append(filteredList, pod)
+		if keep, ok := decisions[ref.UID]; ok {
+			return keep
+		}
+
+		job, err := client.BatchV1().Jobs(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			getErr = err
+			return false
 		}
+
+		decisions[ref.UID] = jobProgress(job, now) < threshold
+		return decisions[ref.UID]
+	})
+	if getErr != nil {
+		return nil, getErr
 	}
 
 	return filteredList, nil
 }
 
-// filterPodsByNamespaceLabels filters a list of pods by a given label selector on their namespace.
-func filterPodsByNamespaceLabels(ctx context.Context, pods []v1.Pod, labels labels.Selector, client kubernetes.Interface) ([]v1.Pod, error) {
-	// empty filter returns original list
-	if labels.Empty() {
-		return pods, nil
+// jobProgress returns how far the given Job progressed towards its completions or its active
+// deadline, whichever is further, from 0 to 1.
+func jobProgress(job *batchv1.Job, now time.Time) float64 {
+	progress := 0.0
+
+	if job.Spec.Completions != nil && *job.Spec.Completions > 0 {
+		progress = float64(job.Status.Succeeded) / float64(*job.Spec.Completions)
 	}
 
-	// find all namespaces matching the label selector
-	listOptions := metav1.ListOptions{LabelSelector: labels.String()}
+	if job.Spec.ActiveDeadlineSeconds != nil && *job.Spec.ActiveDeadlineSeconds > 0 && job.Status.StartTime != nil {
+		deadline := time.Duration(*job.Spec.ActiveDeadlineSeconds) * time.Second
+		progress = math.Max(progress, float64(now.Sub(job.Status.StartTime.Time))/float64(deadline))
+	}
+
+	return math.Min(progress, 1)
+}
+
+// statefulSetOrdinal returns the ordinal of a pod of the StatefulSet with the given name,
+// i.e. the number following the StatefulSet's name in the pod's name.
+func statefulSetOrdinal(pod v1.Pod, statefulSet string) (int, bool) {
+	suffix, ok := strings.CutPrefix(pod.Name, statefulSet+"-")
+	if !ok {
+		return 0, false
+	}
+
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil || ordinal < 0 {
+		return 0, false
+	}
+	return ordinal, true
+}
 
-	namespaces, err := client.CoreV1().Namespaces().List(ctx, listOptions)
+// filterByArgoRollouts filters a list of pods managed by Argo Rollouts by whether they belong to
+// the stable ReplicaSet of their rollout. Pods not managed by Argo Rollouts are kept.
+func filterByArgoRollouts(ctx context.Context, pods []v1.Pod, mode string, client dynamic.Interface, namespaces []string) ([]v1.Pod, error) {
+	rollouts, err := listResources(ctx, client, rolloutsResource, namespaces)
 	if err != nil {
 		return nil, err
 	}
 
-	filteredList := []v1.Pod{}
+	// parse the rollouts' selectors once rather than for every pod
+	type rolloutStatus struct {
+		namespace   string
+		selector    labels.Selector
+		stableHash  string
+		currentHash string
+	}
+	statuses := make([]rolloutStatus, 0, len(rollouts))
 
-	for _, pod := range pods {
-		for _, namespace := range namespaces.Items {
-			// include pod if its in one of the matched namespaces
-			if pod.Namespace == namespace.Name {
-				filteredList = append(filteredList, pod)
+	for _, rollout := range rollouts {
+		selectorMap, _, _ := unstructured.NestedMap(rollout.Object, "spec", "selector")
+		var labelSelector metav1.LabelSelector
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(selectorMap, &labelSelector); err != nil {
+			return nil, err
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		stableHash, _, _ := unstructured.NestedString(rollout.Object, "status", "stableRS")
+		currentHash, _, _ := unstructured.NestedString(rollout.Object, "status", "currentPodHash")
+
+		statuses = append(statuses, rolloutStatus{
+			namespace:   rollout.GetNamespace(),
+			selector:    selector,
+			stableHash:  stableHash,
+			currentHash: currentHash,
+		})
+	}
+
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		hash, managed := pod.Labels[rolloutsPodTemplateHashLabel]
+		if !managed {
+			return true
+		}
+
+		for _, status := range statuses {
+			if status.namespace != pod.Namespace {
+				continue
+			}
+			if status.selector.Empty() || !status.selector.Matches(labels.Set(pod.Labels)) {
+				continue
 			}
+
+			switch mode {
+			case ArgoRolloutsSkipCanary:
+				// a rollout is in progress while the current pod template isn't the stable one
+				return status.stableHash == status.currentHash || hash == status.stableHash
+			case ArgoRolloutsStableOnly:
+				return hash == status.stableHash
+			}
+			break
+		}
+
+		return true
+	}), nil
+}
+
+// filterByCanaries filters out pods whose workload is the target, or its primary, of a Flagger
+// canary that is currently being analyzed.
+func filterByCanaries(ctx context.Context, pods []v1.Pod, client dynamic.Interface, resolver *workload.Resolver, namespaces []string) ([]v1.Pod, error) {
+	canaries, err := listResources(ctx, client, canariesResource, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	// collect the workloads under analysis, keyed by namespace/kind/name
+	paused := map[string]bool{}
+	for _, canary := range canaries {
+		phase, _, _ := unstructured.NestedString(canary.Object, "status", "phase")
+		if !canaryPhasesInProgress[phase] {
+			continue
+		}
+
+		kind, _, _ := unstructured.NestedString(canary.Object, "spec", "targetRef", "kind")
+		name, _, _ := unstructured.NestedString(canary.Object, "spec", "targetRef", "name")
+
+		paused[canary.GetNamespace()+"/"+kind+"/"+name] = true
+		paused[canary.GetNamespace()+"/"+kind+"/"+name+"-primary"] = true
+	}
+
+	// return early if no analysis is running
+	if len(paused) == 0 {
+		return pods, nil
+	}
+
+	var resolveErr error
+
+	filteredList := filterPods(pods, func(pod *v1.Pod) bool {
+		if resolveErr != nil {
+			return false
 		}
+
+		w, err := resolver.Resolve(ctx, *pod)
+		if err != nil {
+			resolveErr = err
+			return false
+		}
+
+		return w == nil || !paused[w.Namespace+"/"+w.Kind+"/"+w.Name]
+	})
+	if resolveErr != nil {
+		return nil, resolveErr
 	}
 
 	return filteredList, nil
 }
 
+// listResources lists the custom resources of the given kind in all of the given namespaces.
+func listResources(ctx context.Context, client dynamic.Interface, resource schema.GroupVersionResource, namespaces []string) ([]unstructured.Unstructured, error) {
+	items := []unstructured.Unstructured{}
+
+	for _, namespace := range namespaces {
+		list, err := client.Resource(resource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, list.Items...)
+	}
+
+	return items, nil
+}
+
+// filterByAnyLabels filters a list of pods by the given label selectors, keeping pods matching any of them.
+func filterByAnyLabels(pods []v1.Pod, selectors []labels.Selector) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		for _, selector := range selectors {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// filterByHelmRelease filters a list of pods by the standard labels of Helm charts, keeping the
+// pods of the given release and chart, if set. The chart label carries the chart's version, e.g.
+// nginx-1.2.3, which is ignored.
+func filterByHelmRelease(pods []v1.Pod, release, chart string) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		if release != "" && pod.Labels[helmReleaseLabel] != release {
+			return false
+		}
+		return chart == "" || helmChartName(pod.Labels[helmChartLabel]) == chart
+	})
+}
+
+// helmChartName strips the version off the value of a chart label, e.g. nginx-1.2.3 or
+// nginx-1.2.3-rc.1 becomes nginx.
+func helmChartName(value string) string {
+	if loc := helmChartVersion.FindStringIndex(value); loc != nil {
+		return value[:loc[0]]
+	}
+	return value
+}
+
 // filterByAnnotations filters a list of pods by a given annotation selector.
 func filterByAnnotations(pods []v1.Pod, annotations labels.Selector) []v1.Pod {
 	// empty filter returns original list
@@ -585,44 +3427,58 @@ func filterByAnnotations(pods []v1.Pod, annotations labels.Selector) []v1.Pod {
 		return pods
 	}
 
-	filteredList := []v1.Pod{}
-
-	for _, pod := range pods {
+	return filterPods(pods, func(pod *v1.Pod) bool {
 		// convert the pod's annotations to an equivalent label selector
 		selector := labels.Set(pod.Annotations)
 
 		// include pod if its annotations match the selector
-		if annotations.Matches(selector) {
-			filteredList = append(filteredList, pod)
-		}
-	}
+		return annotations.Matches(selector)
+	})
+}
 
-	return filteredList
+// filterByPodSet filters a list of pods by a set of pods keyed by namespace/name.
+func filterByPodSet(pods []v1.Pod, set map[string]struct{}) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		_, ok := set[pod.Namespace+"/"+pod.Name]
+		return ok
+	})
 }
 
-// filterByPhase filters a list of pods by a given PodPhase, e.g. Running.
-func filterByPhase(pods []v1.Pod, phase v1.PodPhase) []v1.Pod {
-	filteredList := []v1.Pod{}
+// filterByMatcher filters a list of pods by the given matcher, keeping the pods it matches.
+func filterByMatcher(ctx context.Context, pods []v1.Pod, matcher PodMatcher) ([]v1.Pod, error) {
+	var matchErr error
 
-	for _, pod := range pods {
-		if pod.Status.Phase == phase {
-			filteredList = append(filteredList, pod)
+	filteredList := filterPods(pods, func(pod *v1.Pod) bool {
+		if matchErr != nil {
+			return false
 		}
+
+		matches, err := matcher.Matches(ctx, *pod)
+		if err != nil {
+			matchErr = err
+			return false
+		}
+		return matches
+	})
+	if matchErr != nil {
+		return nil, matchErr
 	}
 
-	return filteredList
+	return filteredList, nil
+}
+
+// filterByPhase filters a list of pods by a given PodPhase, e.g. Running.
+func filterByPhase(pods []v1.Pod, phase v1.PodPhase) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		return pod.Status.Phase == phase
+	})
 }
 
 // filterTerminatingPods removes pod which have a non nil DeletionTimestamp
 func filterTerminatingPods(pods []v1.Pod) []v1.Pod {
-	filteredList := []v1.Pod{}
-	for _, pod := range pods {
-		if pod.DeletionTimestamp != nil {
-			continue
-		}
-		filteredList = append(filteredList, pod)
-	}
-	return filteredList
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		return pod.DeletionTimestamp == nil
+	})
 }
 
 // filterByMinimumAge filters pods by creation time. Only pods
@@ -634,15 +3490,47 @@ func filterByMinimumAge(pods []v1.Pod, minimumAge time.Duration, now time.Time)
 
 	creationTime := now.Add(-minimumAge)
 
-	filteredList := []v1.Pod{}
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		return pod.ObjectMeta.CreationTimestamp.Time.Before(creationTime)
+	})
+}
 
-	for _, pod := range pods {
-		if pod.ObjectMeta.CreationTimestamp.Time.Before(creationTime) {
-			filteredList = append(filteredList, pod)
+// filterByCooldown filters out pods whose owner had a pod terminated within the cooldown period.
+func filterByCooldown(pods []v1.Pod, lastTerminations map[string]time.Time, cooldown time.Duration, now time.Time) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		last, ok := lastTerminations[history.OwnerKey(*pod)]
+		return !ok || now.Sub(last) >= cooldown
+	})
+}
+
+// filterByAnnotationPatterns filters a list of pods by regular expressions on annotation values.
+// Only pods having all of the given annotations with matching values are kept.
+func filterByAnnotationPatterns(pods []v1.Pod, patterns map[string]*regexp.Regexp) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		for key, pattern := range patterns {
+			value, ok := pod.Annotations[key]
+			if !ok || !pattern.MatchString(value) {
+				return false
+			}
 		}
+		return true
+	})
+}
+
+// filterByNamespaceNames filters pods by the name of their namespace. Only pods in namespaces
+// matching includedNamespaceNames and not matching excludedNamespaceNames are returned.
+func filterByNamespaceNames(pods []v1.Pod, includedNamespaceNames, excludedNamespaceNames *regexp.Regexp) []v1.Pod {
+	// return early if neither included nor excluded regular expressions are given
+	if includedNamespaceNames == nil && excludedNamespaceNames == nil {
+		return pods
 	}
 
-	return filteredList
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		include := includedNamespaceNames == nil || includedNamespaceNames.String() == "" || includedNamespaceNames.MatchString(pod.Namespace)
+		exclude := excludedNamespaceNames != nil && excludedNamespaceNames.String() != "" && excludedNamespaceNames.MatchString(pod.Namespace)
+
+		return include && !exclude
+	})
 }
 
 // filterByPodName filters pods by name.  Only pods matching the includedPodNames and not
@@ -653,21 +3541,133 @@ func filterByPodName(pods []v1.Pod, includedPodNames, excludedPodNames *regexp.R
 		return pods
 	}
 
-	filteredList := []v1.Pod{}
-
-	for _, pod := range pods {
+	return filterPods(pods, func(pod *v1.Pod) bool {
 		include := includedPodNames == nil || includedPodNames.String() == "" || includedPodNames.MatchString(pod.Name)
 		exclude := excludedPodNames != nil && excludedPodNames.String() != "" && excludedPodNames.MatchString(pod.Name)
 
-		if include && !exclude {
-			filteredList = append(filteredList, pod)
+		return include && !exclude
+	})
+}
+
+// filterByImages filters pods by the images of their containers, including init containers. Only
+// pods with at least one image matching includedImages and none matching excludedImages are
+// returned.
+func filterByImages(pods []v1.Pod, includedImages, excludedImages *regexp.Regexp) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		include := includedImages == nil || includedImages.String() == ""
+		exclude := false
+
+		for _, containers := range [][]v1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+			for _, container := range containers {
+				if !include && includedImages.MatchString(container.Image) {
+					include = true
+				}
+				if excludedImages != nil && excludedImages.String() != "" && excludedImages.MatchString(container.Image) {
+					exclude = true
+				}
+			}
 		}
-	}
 
-	return filteredList
+		return include && !exclude
+	})
+}
+
+// filterByRequests filters pods by the resources their containers request in total. Only pods
+// requesting at least min and at most max of each given resource are returned.
+func filterByRequests(pods []v1.Pod, min, max v1.ResourceList) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		requests := v1.ResourceList{}
+		for _, container := range pod.Spec.Containers {
+			for name, quantity := range container.Resources.Requests {
+				total := requests[name]
+				total.Add(quantity)
+				requests[name] = total
+			}
+		}
+
+		for name, quantity := range min {
+			if total := requests[name]; total.Cmp(quantity) < 0 {
+				return false
+			}
+		}
+		for name, quantity := range max {
+			if total := requests[name]; total.Cmp(quantity) > 0 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// filterByReadiness filters out pods whose Ready condition isn't true, since terminating pods
+// that are unhealthy already tells little about resilience.
+func filterByReadiness(pods []v1.Pod) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodReady {
+				return condition.Status == v1.ConditionTrue
+			}
+		}
+		return false
+	})
+}
+
+// filterByRestarts filters out pods with a container in CrashLoopBackOff or that restarted more
+// than maxRestarts times and last terminated within the window, since terminating them tells
+// nothing and masks real incidents. Restart counts never reset, so containers that stopped
+// restarting for the window are fine again.
+func filterByRestarts(pods []v1.Pod, maxRestarts int, window time.Duration, now time.Time) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+				return false
+			}
+
+			terminated := status.LastTerminationState.Terminated
+			if int(status.RestartCount) > maxRestarts && terminated != nil && now.Sub(terminated.FinishedAt.Time) < window {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// filterByPriorityClasses filters pods by the name of their priority class. Only pods of one of
+// the included classes, if any, and none of the excluded ones are returned. Pods without a
+// priority class don't belong to any class.
+func filterByPriorityClasses(pods []v1.Pod, included, excluded []string) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		include := len(included) == 0 || slices.Contains(included, pod.Spec.PriorityClassName)
+		exclude := slices.Contains(excluded, pod.Spec.PriorityClassName)
+
+		return include && !exclude
+	})
+}
+
+// filterByServiceAccounts filters pods by the name of their service account, e.g. to keep
+// operators out of the blast radius that share a namespace with their workloads. Only pods of
+// one of the included service accounts, if any, and none of the excluded ones are returned. Pods
+// without a service account run as the default one.
+func filterByServiceAccounts(pods []v1.Pod, included, excluded []string) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		name := pod.Spec.ServiceAccountName
+		if name == "" {
+			name = "default"
+		}
+
+		include := len(included) == 0 || slices.Contains(included, name)
+		exclude := slices.Contains(excluded, name)
+
+		return include && !exclude
+	})
 }
 
-func filterByOwnerReference(pods []v1.Pod) []v1.Pod {
+// filterByOwnerReference reduces a list of pods to up to perOwner random pods per owner, so that
+// a single interval doesn't take down too many replicas of the same workload. With byDeletionCost,
+// it keeps the pods with the lowest pod deletion cost of each owner instead, choosing at random
+// among pods of the same cost.
+func filterByOwnerReference(pods []v1.Pod, perOwner int, byDeletionCost bool, rnd *rand.Rand) []v1.Pod {
 	owners := make(map[types.UID][]v1.Pod)
 	filteredList := []v1.Pod{}
 	for _, pod := range pods {
@@ -683,25 +3683,89 @@ func filterByOwnerReference(pods []v1.Pod) []v1.Pod {
 		}
 	}
 
-	// For each owner reference select a random pod from its group
+	// For each owner reference select random pods from its group
 	for _, pods := range owners {
-		filteredList = append(filteredList, util.RandomPodSubSlice(pods, 1)...)
+		if byDeletionCost {
+			// shuffle all pods first, so that pods of the same cost are chosen at random
+			pods = util.RandomPodSubSlice(pods, len(pods), rnd)
+			sort.SliceStable(pods, func(i, j int) bool {
+				return deletionCost(pods[i]) < deletionCost(pods[j])
+			})
+			filteredList = append(filteredList, pods[:min(perOwner, len(pods))]...)
+			continue
+		}
+		filteredList = append(filteredList, util.RandomPodSubSlice(pods, perOwner, rnd)...)
 	}
 
 	return filteredList
 }
 
+// deletionCost returns the pod deletion cost of the given pod, defaulting to zero like the
+// ReplicaSet controller.
+func deletionCost(pod v1.Pod) int32 {
+	cost, err := strconv.ParseInt(pod.Annotations[deletionCostAnnotation], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(cost)
+}
+
+// filterByTriggerRequest filters a list of pods by the namespace and selector of a trigger request.
+func filterByTriggerRequest(pods []v1.Pod, request trigger.Request) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		if request.Namespace != v1.NamespaceAll && pod.Namespace != request.Namespace {
+			return false
+		}
+		return request.Selector == nil || request.Selector.Matches(labels.Set(pod.Labels))
+	})
+}
+
 // filterStaticPods filters out static pods (mirror pods) that should not be killed
 func filterStaticPods(pods []v1.Pod) []v1.Pod {
-	filteredList := []v1.Pod{}
-
-	for _, pod := range pods {
+	return filterPods(pods, func(pod *v1.Pod) bool {
 		// Skip static pods (mirror pods) which have the mirror pod annotation
-		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
-			continue
+		_, ok := pod.Annotations[mirrorPodAnnotation]
+		return !ok
+	})
+}
+
+// filterByDisruptionAnnotations filters out pods that mark themselves as disruption-sensitive for
+// the cluster autoscaler or Karpenter, following the conventions of the ecosystem.
+func filterByDisruptionAnnotations(pods []v1.Pod) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		return pod.Annotations[safeToEvictAnnotation] != "false" && pod.Annotations[doNotDisruptAnnotation] != "true"
+	})
+}
+
+// filterDaemonSetPods filters out pods controlled by a DaemonSet, which run once per node and
+// are mostly infrastructure, e.g. networking or log shipping, rather than workloads.
+func filterDaemonSetPods(pods []v1.Pod) []v1.Pod {
+	return filterPods(pods, func(pod *v1.Pod) bool {
+		ref := metav1.GetControllerOf(pod)
+		return ref == nil || ref.Kind != "DaemonSet"
+	})
+}
+
+// isStatefulSetPod returns true iff the given pod is controlled by a StatefulSet.
+func isStatefulSetPod(pod v1.Pod) bool {
+	ref := metav1.GetControllerOf(&pod)
+	return ref != nil && ref.Kind == "StatefulSet"
+}
+
+// filterPods returns the pods for which keep returns true. It filters in place, reusing the
+// backing array of the given slice, so that filtering large lists of pods doesn't allocate.
+// The given slice must not be used afterwards.
+func filterPods(pods []v1.Pod, keep func(pod *v1.Pod) bool) []v1.Pod {
+	filteredList := pods[:0]
+
+	for i := range pods {
+		if keep(&pods[i]) {
+			filteredList = append(filteredList, pods[i])
 		}
-		filteredList = append(filteredList, pod)
 	}
 
+	// release the dropped pods so they can be garbage collected
+	clear(pods[len(filteredList):])
+
 	return filteredList
 }
@@ -1,27 +1,51 @@
 package chaoskube
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"regexp"
+	"slices"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	ktesting "k8s.io/client-go/testing"
 
+	"github.com/linki/chaoskube/budget"
+	"github.com/linki/chaoskube/history"
 	"github.com/linki/chaoskube/internal/testutil"
 	"github.com/linki/chaoskube/notifier"
+	"github.com/linki/chaoskube/replay"
+	"github.com/linki/chaoskube/snapshot"
+	"github.com/linki/chaoskube/strategy"
 	"github.com/linki/chaoskube/terminator"
+	"github.com/linki/chaoskube/trigger"
 	"github.com/linki/chaoskube/util"
+	"github.com/linki/chaoskube/workload"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -144,6 +168,40 @@ func (suite *Suite) TestRunContextCanceled() {
 	chaoskube.Run(ctx, nil)
 }
 
+// TestRunScheduleWaits tests that Run waits for the first scheduled run before terminating.
+func (suite *Suite) TestRunScheduleWaits() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+
+	schedule, err := util.ParseSchedule("0 10 * * *")
+	suite.Require().NoError(err)
+	chaoskube.Schedule = schedule
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	chaoskube.Run(ctx, make(chan time.Time))
+
+	pods, err := chaoskube.Candidates(context.Background())
+	suite.Require().NoError(err)
+	suite.Len(pods, 2)
+}
+
 // TestCandidates tests that the various pod filters are applied correctly.
 func (suite *Suite) TestCandidates() {
 	foo := map[string]string{"namespace": "default", "name": "foo"}
@@ -255,6 +313,8 @@ func (suite *Suite) TestCandidatesClientNamespaceScope() {
 		{v1.NamespaceAll, []map[string]string{foo, bar}},
 		{"default", []map[string]string{foo}},
 		{"testing", []map[string]string{bar}},
+		{"default,testing", []map[string]string{foo, bar}},
+		{"testing,other", []map[string]string{bar}},
 	} {
 		chaoskube := suite.setupWithPods(
 			labels.Everything(),
@@ -322,6 +382,186 @@ func (suite *Suite) TestCandidatesPodNameRegexp() {
 	}
 }
 
+// TestCandidatesQuery tests that the candidates are intersected with the result of an external query.
+func (suite *Suite) TestCandidatesQuery() {
+	foo := map[string]string{"namespace": "default", "name": "foo"}
+	bar := map[string]string{"namespace": "testing", "name": "bar"}
+
+	for _, tt := range []struct {
+		queried map[string]struct{}
+		pods    []map[string]string
+	}{
+		{map[string]struct{}{"default/foo": {}, "testing/bar": {}}, []map[string]string{foo, bar}},
+		{map[string]struct{}{"default/foo": {}}, []map[string]string{foo}},
+		{map[string]struct{}{"testing/foo": {}, "testing/baz": {}}, []map[string]string{}},
+		{map[string]struct{}{}, []map[string]string{}},
+	} {
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			nil,
+			nil,
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+		chaoskube.CandidateQuery = staticPodQuerier(tt.queried)
+
+		suite.assertCandidates(chaoskube, tt.pods)
+	}
+}
+
+// TestCandidatesMatcher tests that candidates are checked one by one by an external matcher.
+func (suite *Suite) TestCandidatesMatcher() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.CandidateMatcher = podMatcherFunc(func(pod v1.Pod) (bool, error) {
+		return pod.Namespace == "testing", nil
+	})
+
+	suite.assertCandidates(chaoskube, []map[string]string{
+		{"namespace": "testing", "name": "bar"},
+	})
+
+	chaoskube.CandidateMatcher = podMatcherFunc(func(pod v1.Pod) (bool, error) {
+		return false, errors.New("prometheus unavailable")
+	})
+
+	_, err := chaoskube.Candidates(context.Background())
+	suite.EqualError(err, "prometheus unavailable")
+}
+
+// TestCandidatesLister tests that candidates are taken from a configured Lister instead of the API server.
+func (suite *Suite) TestCandidatesLister() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.Lister = staticLister{util.NewPod("cached", "baz", v1.PodRunning)}
+
+	suite.assertCandidates(chaoskube, []map[string]string{
+		{"namespace": "cached", "name": "baz"},
+	})
+}
+
+// TestCandidatesMetadata tests that candidates can be listed via the metadata API only.
+func (suite *Suite) TestCandidatesMetadata() {
+	newMeta := func(namespace, name string, labels map[string]string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		}
+	}
+
+	scheme := metadatafake.NewTestScheme()
+	suite.Require().NoError(metav1.AddMetaToScheme(scheme))
+
+	chaoskube := suite.setupWithPods(
+		labels.SelectorFromSet(labels.Set{"app": "foo"}),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.MetadataClient = metadatafake.NewSimpleMetadataClient(scheme,
+		newMeta("default", "foo", map[string]string{"app": "foo"}),
+		newMeta("default", "bar", map[string]string{"app": "bar"}),
+	)
+
+	suite.assertCandidates(chaoskube, []map[string]string{
+		{"namespace": "default", "name": "foo"},
+	})
+}
+
+// TestListInParallel tests that namespaces are listed with bounded parallelism and in order.
+func (suite *Suite) TestListInParallel() {
+	namespaces := []string{"a", "b", "c", "d", "e"}
+
+	var mutex sync.Mutex
+	active, maxActive := 0, 0
+
+	pods, err := listInParallel(context.Background(), namespaces, 2, func(ctx context.Context, namespace string) ([]v1.Pod, error) {
+		mutex.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mutex.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		active--
+		mutex.Unlock()
+
+		return []v1.Pod{util.NewPod(namespace, "foo", v1.PodRunning)}, nil
+	})
+	suite.Require().NoError(err)
+
+	suite.Equal(2, maxActive)
+	suite.AssertPods(pods, []map[string]string{
+		{"namespace": "a", "name": "foo"},
+		{"namespace": "b", "name": "foo"},
+		{"namespace": "c", "name": "foo"},
+		{"namespace": "d", "name": "foo"},
+		{"namespace": "e", "name": "foo"},
+	})
+
+	_, err = listInParallel(context.Background(), namespaces, 2, func(ctx context.Context, namespace string) ([]v1.Pod, error) {
+		if namespace == "c" {
+			return nil, errors.New("forbidden")
+		}
+		return nil, nil
+	})
+	suite.Error(err)
+}
+
 // TestVictim tests that a random victim is chosen from selected candidates.
 func (suite *Suite) TestVictim() {
 	foo := map[string]string{"namespace": "default", "name": "foo"}
@@ -336,8 +576,6 @@ func (suite *Suite) TestVictim() {
 		{2000, "", bar},
 		{2000, "app=foo", foo},
 	} {
-		rand.Seed(tt.seed)
-
 		labelSelector, err := labels.Parse(tt.labelSelector)
 		suite.Require().NoError(err)
 
@@ -358,6 +596,7 @@ func (suite *Suite) TestVictim() {
 			10,
 			v1.NamespaceAll,
 		)
+		chaoskube.Rand = util.NewRand(tt.seed)
 
 		suite.assertVictim(chaoskube, tt.victim)
 	}
@@ -380,7 +619,7 @@ func (suite *Suite) TestVictims() {
 	bar := t(podsInfo[1])
 	baz := t(podsInfo[2])
 
-	rand.Seed(2) // yields order of bar, baz, foo
+	rnd := util.NewRand(2) // yields order of bar, baz, foo
 
 	for _, tt := range []struct {
 		labelSelector string
@@ -413,14 +652,39 @@ func (suite *Suite) TestVictims() {
 			tt.maxKill,
 			v1.NamespaceAll,
 		)
+		chaoskube.Rand = rnd
 		suite.createPods(chaoskube.Client, podsInfo)
 
 		suite.assertVictims(chaoskube, tt.victims)
 	}
 }
 
-// TestNoVictimReturnsError tests that on missing victim it returns a known error
-func (suite *Suite) TestNoVictimReturnsError() {
+// TestMaxKillPercent tests that a percentage of victims scales with the candidates.
+func (suite *Suite) TestMaxKillPercent() {
+	for _, tt := range []struct {
+		percent    float64
+		min, max   int
+		candidates int
+		expected   int
+	}{
+		{0, 0, 0, 100, 2},
+		{10, 0, 0, 100, 10},
+		{10, 0, 0, 95, 10},
+		{10, 0, 0, 5, 1},
+		{10, 0, 0, 0, 1},
+		{100, 0, 0, 7, 7},
+		{1, 2, 5, 100, 2},
+		{1, 2, 5, 300, 3},
+		{1, 2, 5, 10000, 5},
+		{0, 2, 5, 10000, 2},
+	} {
+		chaoskube := &Chaoskube{MaxKill: 2, MaxKillPercent: tt.percent, MaxKillMin: tt.min, MaxKillMax: tt.max}
+		suite.Equal(tt.expected, chaoskube.maxKill(tt.candidates), "%v%% of %d", tt.percent, tt.candidates)
+	}
+}
+
+// TestVictimsStrategy tests that victims are chosen by the configured strategy.
+func (suite *Suite) TestVictimsStrategy() {
 	chaoskube := suite.setup(
 		labels.Everything(),
 		labels.Everything(),
@@ -436,26 +700,201 @@ func (suite *Suite) TestNoVictimReturnsError() {
 		time.Duration(0),
 		false,
 		10,
-		1,
+		2,
 		v1.NamespaceAll,
 	)
+	chaoskube.Lister = staticLister{
+		util.NewPod("default", "foo", v1.PodRunning),
+		util.NewPod("testing", "bar", v1.PodRunning),
+		util.NewPod("test", "baz", v1.PodRunning),
+	}
+	chaoskube.Strategy = lastStrategy{}
 
-	_, err := chaoskube.Victims(context.Background())
-	suite.Equal(err, errPodNotFound)
-	suite.EqualError(err, "pod not found")
+	suite.assertVictims(chaoskube, []map[string]string{
+		{"namespace": "test", "name": "baz"},
+		{"namespace": "testing", "name": "bar"},
+	})
 }
 
-// TestDeletePod tests that a given pod is deleted and dryRun is respected.
-func (suite *Suite) TestDeletePod() {
-	foo := map[string]string{"namespace": "default", "name": "foo"}
-	bar := map[string]string{"namespace": "testing", "name": "bar"}
+// TestCandidatesOwnerSampler tests that candidates aren't reduced to one per owner for strategies sampling owners themselves.
+func (suite *Suite) TestCandidatesOwnerSampler() {
+	for _, tt := range []struct {
+		strategy strategy.Strategy
+		expected int
+	}{
+		{strategy.Uniform{}, 1},
+		{strategy.Stratified{}, 2},
+	} {
+		chaoskube := suite.setup(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			1,
+			v1.NamespaceAll,
+		)
+		chaoskube.Lister = staticLister{
+			util.NewPodWithOwner("default", "foo", v1.PodRunning, "parent"),
+			util.NewPodWithOwner("default", "foo-1", v1.PodRunning, "parent"),
+		}
+		chaoskube.Strategy = tt.strategy
+
+		candidates, err := chaoskube.Candidates(context.Background())
+		suite.Require().NoError(err)
+		suite.Len(candidates, tt.expected)
+	}
+}
+
+// TestRecordAndReplay tests that candidates and victims are recorded and can be replayed.
+func (suite *Suite) TestRecordAndReplay() {
+	setup := func() *Chaoskube {
+		return suite.setup(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			1,
+			v1.NamespaceAll,
+		)
+	}
+
+	pods := staticLister{
+		util.NewPod("default", "foo", v1.PodRunning),
+		util.NewPod("testing", "bar", v1.PodRunning),
+	}
+
+	var buf bytes.Buffer
+
+	recording := setup()
+	recording.Lister = pods
+	recording.Strategy = lastStrategy{}
+	recording.Recorder = replay.NewRecorder(&buf)
+
+	victims, err := recording.Victims(context.Background())
+	suite.Require().NoError(err)
+	suite.AssertPods(victims, []map[string]string{{"namespace": "testing", "name": "bar"}})
 
 	for _, tt := range []struct {
-		dryRun        bool
-		remainingPods []map[string]string
+		strategy strategy.Strategy
+		differs  bool
 	}{
-		{false, []map[string]string{bar}},
-		{true, []map[string]string{foo, bar}},
+		{lastStrategy{}, false},
+		{strategy.OldestFirst{}, true},
+	} {
+		logOutput.Reset()
+
+		replaying := setup()
+		replaying.Lister = staticLister{}
+		replaying.Strategy = tt.strategy
+		replaying.Player = replay.NewPlayer(bytes.NewReader(buf.Bytes()))
+
+		victims, err := replaying.Victims(context.Background())
+		suite.Require().NoError(err)
+		suite.Len(victims, 1)
+
+		differs := false
+		for _, entry := range logOutput.AllEntries() {
+			differs = differs || entry.Message == "replayed selection differs from recording"
+		}
+		suite.Equal(tt.differs, differs)
+
+		// all recorded ticks have been played
+		_, err = replaying.Victims(context.Background())
+		suite.Equal(errPodNotFound, err)
+	}
+}
+
+// TestColocatedPods tests that candidates on the same node are added to each victim.
+func (suite *Suite) TestColocatedPods() {
+	newPod := func(name, node string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.NodeName = node
+		return pod
+	}
+
+	foo := newPod("foo", "node-1")
+	foo1 := newPod("foo-1", "node-1")
+	foo2 := newPod("foo-2", "node-1")
+	bar := newPod("bar", "node-2")
+	baz := newPod("baz", "")
+	baz1 := newPod("baz-1", "")
+	candidates := []v1.Pod{foo, foo1, foo2, bar, baz, baz1}
+
+	for _, tt := range []struct {
+		name     string
+		victims  []v1.Pod
+		limit    int
+		expected []v1.Pod
+	}{
+		{"one more on the same node", []v1.Pod{foo}, 1, []v1.Pod{foo, foo1}},
+		{"all on the same node", []v1.Pod{foo1}, 5, []v1.Pod{foo1, foo, foo2}},
+		{"nothing else on the node", []v1.Pod{bar}, 5, []v1.Pod{bar}},
+		{"no pod added twice", []v1.Pod{foo, foo2}, 5, []v1.Pod{foo, foo1, foo2}},
+		{"unknown node", []v1.Pod{baz}, 5, []v1.Pod{baz}},
+	} {
+		suite.Equal(tt.expected, colocatedPods(tt.victims, candidates, tt.limit), tt.name)
+	}
+}
+
+// TestCostAwareVictims tests that victims are weighted by cost and respect the daily cost budget.
+func (suite *Suite) TestCostAwareVictims() {
+	costs := staticCostProvider{"default/foo": 5, "testing/bar": 5}
+
+	for _, tt := range []struct {
+		name          string
+		maxCostPerDay float64
+		terminate     bool
+		now           []time.Time
+		victims       []int
+	}{
+		{
+			name:          "unlimited budget",
+			maxCostPerDay: 0,
+			terminate:     true,
+			now:           []time.Time{ThankGodItsFriday{}.Now(), ThankGodItsFriday{}.Now()},
+			victims:       []int{1, 1},
+		},
+		{
+			name:          "budget exhausted on the same day",
+			maxCostPerDay: 10,
+			terminate:     true,
+			now:           []time.Time{ThankGodItsFriday{}.Now(), ThankGodItsFriday{}.Now(), ThankGodItsFriday{}.Now()},
+			victims:       []int{1, 1, 0},
+		},
+		{
+			name:          "budget is reset on the next day",
+			maxCostPerDay: 10,
+			terminate:     true,
+			now:           []time.Time{ThankGodItsFriday{}.Now(), ThankGodItsFriday{}.Now(), ThankGodItsFriday{}.Now().Add(24 * time.Hour)},
+			victims:       []int{1, 1, 1},
+		},
+		{
+			name:          "selection alone doesn't spend the budget",
+			maxCostPerDay: 10,
+			terminate:     false,
+			now:           []time.Time{ThankGodItsFriday{}.Now(), ThankGodItsFriday{}.Now(), ThankGodItsFriday{}.Now()},
+			victims:       []int{1, 1, 1},
+		},
 	} {
 		chaoskube := suite.setupWithPods(
 			labels.Everything(),
@@ -470,23 +909,38 @@ func (suite *Suite) TestDeletePod() {
 			[]time.Time{},
 			time.UTC,
 			time.Duration(0),
-			tt.dryRun,
+			false,
 			10,
 			v1.NamespaceAll,
 		)
+		chaoskube.CostProvider = costs
+		chaoskube.CostWeighting = true
+		chaoskube.MaxCostPerDay = tt.maxCostPerDay
+		// leave the pods in place, so that only the budget limits the victims
+		chaoskube.Terminator = &concurrencyTerminator{}
 
-		victim := util.NewPod("default", "foo", v1.PodRunning)
+		for i, now := range tt.now {
+			chaoskube.Now = func() time.Time { return now }
 
-		err := chaoskube.DeletePod(context.Background(), victim)
-		suite.Require().NoError(err)
+			victims, err := chaoskube.Victims(context.Background())
+			if tt.victims[i] == 0 {
+				suite.Equal(errPodNotFound, err, tt.name)
+				continue
+			}
+			suite.Require().NoError(err, tt.name)
+			suite.Len(victims, tt.victims[i], tt.name)
 
-		suite.AssertLog(logOutput, log.InfoLevel, "terminating pod", log.Fields{"namespace": "default", "name": "foo"})
-		suite.assertCandidates(chaoskube, tt.remainingPods)
+			if tt.terminate {
+				for _, victim := range victims {
+					suite.Require().NoError(chaoskube.DeletePod(context.Background(), victim), tt.name)
+				}
+			}
+		}
 	}
 }
 
-// TestDeletePodNotFound tests missing target pod will return an error.
-func (suite *Suite) TestDeletePodNotFound() {
+// TestNoVictimReturnsError tests that on missing victim it returns a known error
+func (suite *Suite) TestNoVictimReturnsError() {
 	chaoskube := suite.setup(
 		labels.Everything(),
 		labels.Everything(),
@@ -506,219 +960,22 @@ func (suite *Suite) TestDeletePodNotFound() {
 		v1.NamespaceAll,
 	)
 
-	victim := util.NewPod("default", "foo", v1.PodRunning)
+	_, err := chaoskube.Victims(context.Background())
+	suite.Equal(err, errPodNotFound)
+	suite.EqualError(err, "pod not found")
+}
 
-	err := chaoskube.DeletePod(context.Background(), victim)
-	suite.EqualError(err, `pods "foo" not found`)
-}
-
-func (suite *Suite) TestTerminateVictim() {
-	midnight := util.NewTimePeriod(
-		ThankGodItsFriday{}.Now().Add(-16*time.Hour),
-		ThankGodItsFriday{}.Now().Add(-14*time.Hour),
-	)
-	morning := util.NewTimePeriod(
-		ThankGodItsFriday{}.Now().Add(-7*time.Hour),
-		ThankGodItsFriday{}.Now().Add(-6*time.Hour),
-	)
-	afternoon := util.NewTimePeriod(
-		ThankGodItsFriday{}.Now().Add(-1*time.Hour),
-		ThankGodItsFriday{}.Now().Add(+1*time.Hour),
-	)
-
-	australia, err := time.LoadLocation("Australia/Brisbane")
-	suite.Require().NoError(err)
+// TestDeletePod tests that a given pod is deleted and dryRun is respected.
+func (suite *Suite) TestDeletePod() {
+	foo := map[string]string{"namespace": "default", "name": "foo"}
+	bar := map[string]string{"namespace": "testing", "name": "bar"}
 
 	for _, tt := range []struct {
-		excludedWeekdays   []time.Weekday
-		excludedTimesOfDay []util.TimePeriod
-		excludedDaysOfYear []time.Time
-		now                func() time.Time
-		timezone           *time.Location
-		remainingPodCount  int
+		dryRun        bool
+		remainingPods []map[string]string
 	}{
-		// no time is excluded, one pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{},
-			[]time.Time{},
-			ThankGodItsFriday{}.Now,
-			time.UTC,
-			1,
-		},
-		// current weekday is excluded, no pod should be killed
-		{
-			[]time.Weekday{time.Friday},
-			[]util.TimePeriod{},
-			[]time.Time{},
-			ThankGodItsFriday{}.Now,
-			time.UTC,
-			2,
-		},
-		// current time of day is excluded, no pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{afternoon},
-			[]time.Time{},
-			ThankGodItsFriday{}.Now,
-			time.UTC,
-			2,
-		},
-		// one day after an excluded weekday, one pod should be killed
-		{
-			[]time.Weekday{time.Friday},
-			[]util.TimePeriod{},
-			[]time.Time{},
-			func() time.Time { return ThankGodItsFriday{}.Now().Add(24 * time.Hour) },
-			time.UTC,
-			1,
-		},
-		// seven days after an excluded weekday, no pod should be killed
-		{
-			[]time.Weekday{time.Friday},
-			[]util.TimePeriod{},
-			[]time.Time{},
-			func() time.Time { return ThankGodItsFriday{}.Now().Add(7 * 24 * time.Hour) },
-			time.UTC,
-			2,
-		},
-		// one hour after an excluded time period, one pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{afternoon},
-			[]time.Time{},
-			func() time.Time { return ThankGodItsFriday{}.Now().Add(+2 * time.Hour) },
-			time.UTC,
-			1,
-		},
-		// twenty four hours after an excluded time period, no pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{afternoon},
-			[]time.Time{},
-			func() time.Time { return ThankGodItsFriday{}.Now().Add(+24 * time.Hour) },
-			time.UTC,
-			2,
-		},
-		// current weekday is excluded but we are in another time zone, one pod should be killed
-		{
-			[]time.Weekday{time.Friday},
-			[]util.TimePeriod{},
-			[]time.Time{},
-			ThankGodItsFriday{}.Now,
-			australia,
-			1,
-		},
-		// current time period is excluded but we are in another time zone, one pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{afternoon},
-			[]time.Time{},
-			ThankGodItsFriday{}.Now,
-			australia,
-			1,
-		},
-		// one out of two excluded weeksdays match, no pod should be killed
-		{
-			[]time.Weekday{time.Monday, time.Friday},
-			[]util.TimePeriod{},
-			[]time.Time{},
-			ThankGodItsFriday{}.Now,
-			time.UTC,
-			2,
-		},
-		// one out of two excluded time periods match, no pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{morning, afternoon},
-			[]time.Time{},
-			ThankGodItsFriday{}.Now,
-			time.UTC,
-			2,
-		},
-		// we're inside an excluded time period across days, no pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{midnight},
-			[]time.Time{},
-			func() time.Time { return ThankGodItsFriday{}.Now().Add(-15 * time.Hour) },
-			time.UTC,
-			2,
-		},
-		// we're before an excluded time period across days, one pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{midnight},
-			[]time.Time{},
-			func() time.Time { return ThankGodItsFriday{}.Now().Add(-17 * time.Hour) },
-			time.UTC,
-			1,
-		},
-		// we're after an excluded time period across days, one pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{midnight},
-			[]time.Time{},
-			func() time.Time { return ThankGodItsFriday{}.Now().Add(-13 * time.Hour) },
-			time.UTC,
-			1,
-		},
-		// this day of year is excluded, no pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{},
-			[]time.Time{
-				ThankGodItsFriday{}.Now(), // today
-			},
-			func() time.Time { return ThankGodItsFriday{}.Now() },
-			time.UTC,
-			2,
-		},
-		// this day of year in year 0 is excluded, no pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{},
-			[]time.Time{
-				time.Date(0, 9, 24, 0, 00, 00, 00, time.UTC), // same year day
-			},
-			func() time.Time { return ThankGodItsFriday{}.Now() },
-			time.UTC,
-			2,
-		},
-		// matching works fine even when multiple days-of-year are provided, no pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{},
-			[]time.Time{
-				time.Date(0, 9, 25, 10, 00, 00, 00, time.UTC), // different year day
-				time.Date(0, 9, 24, 10, 00, 00, 00, time.UTC), // same year day
-			},
-			func() time.Time { return ThankGodItsFriday{}.Now() },
-			time.UTC,
-			2,
-		},
-		// there is an excluded day of year but it's not today, one pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{},
-			[]time.Time{
-				time.Date(0, 9, 25, 10, 00, 00, 00, time.UTC), // different year day
-			},
-			func() time.Time { return ThankGodItsFriday{}.Now() },
-			time.UTC,
-			1,
-		},
-		// there is an excluded day of year but the month is different, one pod should be killed
-		{
-			[]time.Weekday{},
-			[]util.TimePeriod{},
-			[]time.Time{
-				time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC), // different year day
-			},
-			func() time.Time { return ThankGodItsFriday{}.Now() },
-			time.UTC,
-			1,
-		},
+		{false, []map[string]string{bar}},
+		{true, []map[string]string{foo, bar}},
 	} {
 		chaoskube := suite.setupWithPods(
 			labels.Everything(),
@@ -728,30 +985,29 @@ func (suite *Suite) TestTerminateVictim() {
 			labels.Everything(),
 			&regexp.Regexp{},
 			&regexp.Regexp{},
-			tt.excludedWeekdays,
-			tt.excludedTimesOfDay,
-			tt.excludedDaysOfYear,
-			tt.timezone,
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
 			time.Duration(0),
-			false,
+			tt.dryRun,
 			10,
 			v1.NamespaceAll,
 		)
-		chaoskube.Now = tt.now
 
-		err := chaoskube.TerminateVictims(context.Background())
-		suite.Require().NoError(err)
+		victim := util.NewPod("default", "foo", v1.PodRunning)
 
-		pods, err := chaoskube.Candidates(context.Background())
+		err := chaoskube.DeletePod(context.Background(), victim)
 		suite.Require().NoError(err)
 
-		suite.Len(pods, tt.remainingPodCount)
+		suite.AssertLog(logOutput, log.InfoLevel, "terminating pod", log.Fields{"namespace": "default", "name": "foo"})
+		suite.assertCandidates(chaoskube, tt.remainingPods)
 	}
 }
 
-// TestTerminateNoVictimLogsInfo tests that missing victim prints a log message
-func (suite *Suite) TestTerminateNoVictimLogsInfo() {
-	chaoskube := suite.setup(
+// TestStampOwner tests that the top-level workload of a terminated pod is annotated.
+func (suite *Suite) TestStampOwner() {
+	chaoskube := suite.setupWithPods(
 		labels.Everything(),
 		labels.Everything(),
 		labels.Everything(),
@@ -766,213 +1022,210 @@ func (suite *Suite) TestTerminateNoVictimLogsInfo() {
 		time.Duration(0),
 		false,
 		10,
-		1,
 		v1.NamespaceAll,
 	)
+	chaoskube.StampOwners = true
+	chaoskube.RunID = "run-1"
+	chaoskube.Now = func() time.Time { return time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) }
 
-	err := chaoskube.TerminateVictims(context.Background())
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", UID: "deployment-uid"}}
+	_, err := chaoskube.Client.AppsV1().Deployments("default").Create(context.Background(), deployment, metav1.CreateOptions{})
 	suite.Require().NoError(err)
 
-	suite.AssertLog(logOutput, log.DebugLevel, msgVictimNotFound, log.Fields{})
-}
+	controller := true
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	victim.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "foo", UID: "deployment-uid", Controller: &controller}}
 
-// helper functions
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), victim))
 
-func (suite *Suite) assertCandidates(chaoskube *Chaoskube, expected []map[string]string) {
-	pods, err := chaoskube.Candidates(context.Background())
+	stamped, err := chaoskube.Client.AppsV1().Deployments("default").Get(context.Background(), "foo", metav1.GetOptions{})
 	suite.Require().NoError(err)
-
-	suite.AssertPods(pods, expected)
+	suite.Equal(map[string]string{
+		"chaos.alpha.kubernetes.io/last-chaos-at":     "2024-01-01T12:00:00Z",
+		"chaos.alpha.kubernetes.io/last-chaos-run-id": "run-1",
+		"chaos.alpha.kubernetes.io/last-chaos-result": "terminated",
+	}, stamped.Annotations)
 }
 
-func (suite *Suite) assertVictims(chaoskube *Chaoskube, expected []map[string]string) {
-	victims, err := chaoskube.Victims(context.Background())
-	suite.Require().NoError(err)
-
-	for i, victim := range victims {
-		suite.AssertPod(victim, expected[i])
-	}
+// TestPauseAndStatus tests that no pods are terminated while paused and that the status reflects the last interval.
+func (suite *Suite) TestPauseAndStatus() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.History = history.New(10)
+
+	chaoskube.SetPaused(true)
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+	suite.AssertLog(logOutput, log.DebugLevel, msgPaused, log.Fields{})
+	suite.assertCandidates(chaoskube, []map[string]string{
+		{"namespace": "default", "name": "foo"},
+		{"namespace": "testing", "name": "bar"},
+	})
+	suite.True(chaoskube.Status().Paused)
+	suite.Empty(chaoskube.Status().Victims)
+
+	chaoskube.SetPaused(false)
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+
+	status := chaoskube.Status()
+	suite.False(status.Paused)
+	suite.ElementsMatch([]string{"default/foo", "testing/bar"}, status.Candidates)
+	suite.Len(status.Victims, 1)
 }
 
-func (suite *Suite) assertVictim(chaoskube *Chaoskube, expected map[string]string) {
-	suite.assertVictims(chaoskube, []map[string]string{expected})
+// recordingNotifier remembers the pods it was notified about.
+type recordingNotifier struct {
+	pods []v1.Pod
 }
 
-func (suite *Suite) assertNotified(notifier *notifier.Noop) {
-	suite.Assert().Greater(notifier.Calls, 0)
+func (n *recordingNotifier) NotifyPodTermination(pod v1.Pod) error {
+	n.pods = append(n.pods, pod)
+	return nil
 }
 
-func (suite *Suite) setupWithPods(labelSelector labels.Selector, annotations labels.Selector, kinds labels.Selector, namespaces labels.Selector, namespaceLabels labels.Selector, includedPodNames *regexp.Regexp, excludedPodNames *regexp.Regexp, excludedWeekdays []time.Weekday, excludedTimesOfDay []util.TimePeriod, excludedDaysOfYear []time.Time, timezone *time.Location, minimumAge time.Duration, dryRun bool, gracePeriod time.Duration, clientNamespaceScope string) *Chaoskube {
+// TestDeletePodLogTail tests that the logs of a victim are fetched before its termination.
+func (suite *Suite) TestDeletePodLogTail() {
+	for _, tt := range []struct {
+		containers []string
+		expected   string
+	}{
+		{[]string{}, ""},
+		{[]string{"app"}, "fake logs"},
+		{[]string{"app", "sidecar"}, "==> app <==\nfake logs\n==> sidecar <==\nfake logs"},
+	} {
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+		chaoskube.History = history.New(10)
+		chaoskube.LogTailLines = 20
+		recorder := &recordingNotifier{}
+		chaoskube.Notifier = recorder
+
+		victim := util.NewPod("default", "foo", v1.PodRunning)
+		for _, container := range tt.containers {
+			victim.Spec.Containers = append(victim.Spec.Containers, v1.Container{Name: container})
+		}
+
+		suite.Require().NoError(chaoskube.DeletePod(context.Background(), victim))
+
+		suite.Require().Len(recorder.pods, 1)
+		suite.Equal(tt.expected, recorder.pods[0].Annotations[notifier.LogTailAnnotation])
+
+		// the log tail only goes to the notification, not to the history
+		entries := chaoskube.History.Entries()
+		suite.Require().Len(entries, 1)
+
+		// the given pod is left untouched
+		suite.NotContains(victim.Annotations, notifier.LogTailAnnotation)
+	}
+}
+
+type snapshotStore struct {
+	snapshots []snapshot.Snapshot
+}
+
+func (s *snapshotStore) Save(snapshot snapshot.Snapshot) error {
+	s.snapshots = append(s.snapshots, snapshot)
+	return nil
+}
+
+// TestDeletePodSnapshot tests that the manifest and events of a victim are saved before its termination.
+func (suite *Suite) TestDeletePodSnapshot() {
 	chaoskube := suite.setup(
-		labelSelector,
-		annotations,
-		kinds,
-		namespaces,
-		namespaceLabels,
-		includedPodNames,
-		excludedPodNames,
-		excludedWeekdays,
-		excludedTimesOfDay,
-		excludedDaysOfYear,
-		timezone,
-		minimumAge,
-		dryRun,
-		gracePeriod,
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
 		1,
-		clientNamespaceScope,
+		v1.NamespaceAll,
 	)
+	store := &snapshotStore{}
+	chaoskube.Snapshots = store
 
-	for _, namespace := range []v1.Namespace{
-		util.NewNamespace("default"),
-		util.NewNamespace("testing"),
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	victim.UID = "foo-uid"
+	victim.Spec.NodeName = "node-1"
+	_, err := chaoskube.Client.CoreV1().Pods("default").Create(context.Background(), &victim, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	for _, event := range []v1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo.1"}, InvolvedObject: v1.ObjectReference{UID: "foo-uid"}, Reason: "Started"},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bar.1"}, InvolvedObject: v1.ObjectReference{UID: "bar-uid"}, Reason: "Pulled"},
 	} {
-		_, err := chaoskube.Client.CoreV1().Namespaces().Create(context.Background(), &namespace, metav1.CreateOptions{})
+		_, err := chaoskube.Client.CoreV1().Events("default").Create(context.Background(), &event, metav1.CreateOptions{})
 		suite.Require().NoError(err)
 	}
 
-	pods := []v1.Pod{
-		util.NewPod("default", "foo", v1.PodRunning),
-		util.NewPod("testing", "bar", v1.PodRunning),
-		util.NewPod("testing", "baz", v1.PodPending), // Non-running pods are ignored
-	}
-
-	for _, pod := range pods {
-		_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
-		suite.Require().NoError(err)
-	}
+	// only the metadata is known about the candidate
+	candidate := victim
+	candidate.Spec = v1.PodSpec{}
 
-	return chaoskube
-}
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), candidate))
 
-func (suite *Suite) createPods(client kubernetes.Interface, podsInfo []podInfo) {
-	for _, p := range podsInfo {
-		namespace := util.NewNamespace(p.Namespace)
-		_, err := client.CoreV1().Namespaces().Create(context.Background(), &namespace, metav1.CreateOptions{})
-		suite.Require().NoError(err)
-		pod := util.NewPod(p.Namespace, p.Name, v1.PodRunning)
-		_, err = client.CoreV1().Pods(p.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
-		suite.Require().NoError(err)
-	}
+	suite.Require().Len(store.snapshots, 1)
+	suite.Equal("node-1", store.snapshots[0].Pod.Spec.NodeName)
+	suite.Require().Len(store.snapshots[0].Events, 1)
+	suite.Equal("Started", store.snapshots[0].Events[0].Reason)
 }
 
-func (suite *Suite) setup(labelSelector labels.Selector, annotations labels.Selector, kinds labels.Selector, namespaces labels.Selector, namespaceLabels labels.Selector, includedPodNames *regexp.Regexp, excludedPodNames *regexp.Regexp, excludedWeekdays []time.Weekday, excludedTimesOfDay []util.TimePeriod, excludedDaysOfYear []time.Time, timezone *time.Location, minimumAge time.Duration, dryRun bool, gracePeriod time.Duration, maxKill int, clientNamespaceScope string) *Chaoskube {
-	return suite.setupWithInterval(labelSelector, annotations, kinds, namespaces, namespaceLabels, includedPodNames, excludedPodNames, excludedWeekdays, excludedTimesOfDay, excludedDaysOfYear, timezone, minimumAge, dryRun, gracePeriod, maxKill, clientNamespaceScope, false, 1.0, 10*time.Minute)
+type staticApprover struct {
+	approved bool
 }
 
-func (suite *Suite) setupWithInterval(labelSelector labels.Selector, annotations labels.Selector, kinds labels.Selector, namespaces labels.Selector, namespaceLabels labels.Selector, includedPodNames *regexp.Regexp, excludedPodNames *regexp.Regexp, excludedWeekdays []time.Weekday, excludedTimesOfDay []util.TimePeriod, excludedDaysOfYear []time.Time, timezone *time.Location, minimumAge time.Duration, dryRun bool, gracePeriod time.Duration, maxKill int, clientNamespaceScope string, dynamicInterval bool, dynamicFactor float64, interval time.Duration) *Chaoskube {
-	logOutput.Reset()
-
-	client := fake.NewSimpleClientset()
-	nullLogger, _ := test.NewNullLogger()
-
-	return New(
-		client,
-		labelSelector,
-		annotations,
-		kinds,
-		namespaces,
-		namespaceLabels,
-		includedPodNames,
-		excludedPodNames,
-		excludedWeekdays,
-		excludedTimesOfDay,
-		excludedDaysOfYear,
-		timezone,
-		minimumAge,
-		logger,
-		dryRun,
-		terminator.NewDeletePodTerminator(client, nullLogger, gracePeriod),
-		maxKill,
-		testNotifier,
-		clientNamespaceScope,
-		dynamicInterval,
-		dynamicFactor,
-		interval,
-	)
+func (a staticApprover) Approve(ctx context.Context, victims []v1.Pod) (bool, error) {
+	return a.approved, nil
 }
 
-func (suite *Suite) TestDynamicIntervalCalculation() {
+// TestTerminateVictimsApproval tests that victims are only terminated once approved.
+func (suite *Suite) TestTerminateVictimsApproval() {
 	for _, tt := range []struct {
-		name             string
-		podCount         int
-		annotatedCount   int
-		annotations      labels.Selector
-		dynamicInterval  bool
-		dynamicFactor    float64
-		baseInterval     time.Duration
-		expectedInterval time.Duration
+		approved      bool
+		remainingPods int
 	}{
-		{
-			name:            "100 pods with factor 1.0",
-			podCount:        100,
-			annotatedCount:  100,
-			annotations:     labels.Everything(),
-			dynamicInterval: true,
-			dynamicFactor:   1.0,
-			baseInterval:    10 * time.Minute,
-			// Total working minutes (5 days * 8 hours * 60 minutes) = 2400 minutes
-			// With 100 pods and target of 50%, interval = 2400 / (100 * 0.5 * 1.0) = 48 minutes
-			expectedInterval: 48 * time.Minute,
-		},
-		{
-			name:            "1500 pods with factor 1.0",
-			podCount:        1500,
-			annotatedCount:  1500,
-			annotations:     labels.Everything(),
-			dynamicInterval: true,
-			dynamicFactor:   1.0,
-			baseInterval:    10 * time.Minute,
-			// With 1500 pods and target of 50%, interval = 2400 / (1500 * 0.5 * 1.0) = 3.2 minutes -> rounded to 3 minutes
-			expectedInterval: 3 * time.Minute,
-		},
-		{
-			name:            "1500 pods with factor 2.0",
-			podCount:        1500,
-			annotatedCount:  1500,
-			annotations:     labels.Everything(),
-			dynamicInterval: true,
-			dynamicFactor:   2.0,
-			baseInterval:    10 * time.Minute,
-			// With 1500 pods and factor 2.0, interval = 2400 / (1500 * 0.5 * 2.0) = 1.6 minutes -> rounded to 2 minutes
-			expectedInterval: 2 * time.Minute,
-		},
-		{
-			name:            "50 pods with factor 0.5",
-			podCount:        50,
-			annotatedCount:  50,
-			annotations:     labels.Everything(),
-			dynamicInterval: true,
-			dynamicFactor:   0.5,
-			baseInterval:    10 * time.Minute,
-			// With 50 pods and factor 0.5, interval = 2400 / (50 * 0.5 * 0.5) = 192 minutes
-			expectedInterval: 192 * time.Minute,
-		},
-		{
-			name:            "0 pods fallback to base interval",
-			podCount:        0,
-			annotatedCount:  0,
-			annotations:     labels.Everything(),
-			dynamicInterval: true,
-			dynamicFactor:   1.0,
-			baseInterval:    10 * time.Minute,
-			// Should fall back to base interval with 0 pods
-			expectedInterval: 10 * time.Minute,
-		},
-		{
-			name:            "50 pods with 10 matching annotation",
-			podCount:        50,
-			annotatedCount:  10,
-			annotations:     labels.SelectorFromSet(labels.Set{"chaos": "true"}),
-			dynamicInterval: true,
-			dynamicFactor:   1.0,
-			baseInterval:    10 * time.Minute,
-			// With 10 pods after annotation filtering and target of 50%, interval = 2400 / (10 * 0.5 * 1.0) = 480 minutes
-			expectedInterval: 480 * time.Minute,
-		},
+		{true, 1},
+		{false, 2},
 	} {
-		chaoskube := suite.setupWithInterval(
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
 			labels.Everything(),
-			tt.annotations,
 			labels.Everything(),
 			labels.Everything(),
 			labels.Everything(),
@@ -984,121 +1237,103 @@ func (suite *Suite) TestDynamicIntervalCalculation() {
 			time.UTC,
 			time.Duration(0),
 			false,
-			10*time.Second,
-			1,
+			10,
 			v1.NamespaceAll,
-			tt.dynamicInterval,
-			tt.dynamicFactor,
-			tt.baseInterval,
 		)
+		chaoskube.Approver = staticApprover{approved: tt.approved}
 
-		// Create test pods
-		for i := 0; i < tt.podCount; i++ {
-			pod := util.NewPod("default", fmt.Sprintf("pod-%d", i), v1.PodRunning)
-
-			// Add the annotation to a subset of pods if annotatedCount is specified
-			if i < tt.annotatedCount {
-				pod.Annotations = map[string]string{
-					"chaos": "true",
-				}
-			}
-
-			_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
-			suite.Require().NoError(err)
-		}
+		suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
 
-		interval := chaoskube.CalculateDynamicInterval(context.Background())
-		suite.Equal(tt.expectedInterval, interval, tt.name)
+		pods, err := chaoskube.Candidates(context.Background())
+		suite.Require().NoError(err)
+		suite.Len(pods, tt.remainingPods)
 	}
 }
 
-func TestSuite(t *testing.T) {
-	suite.Run(t, new(Suite))
-}
+// TestTerminateVictimsBudget tests that terminations stop once the kill budget is exhausted.
+func (suite *Suite) TestTerminateVictimsBudget() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
 
-// ThankGodItsFriday is a helper struct that contains a Now() function that always returns a Friday.
-type ThankGodItsFriday struct{}
+	killBudget, err := budget.New(1, 0, "")
+	suite.Require().NoError(err)
+	chaoskube.Budget = killBudget
 
-// Now returns a particular Friday.
-func (t ThankGodItsFriday) Now() time.Time {
-	blackFriday, _ := time.Parse(time.RFC1123, "Fri, 24 Sep 1869 15:04:05 UTC")
-	return blackFriday
-}
+	for _, remainingPods := range []int{1, 1} {
+		suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
 
-func (suite *Suite) TestMinimumAge() {
-	type pod struct {
-		name         string
-		namespace    string
-		creationTime time.Time
+		pods, err := chaoskube.Candidates(context.Background())
+		suite.Require().NoError(err)
+		suite.Len(pods, remainingPods)
 	}
+}
+
+// TestTerminateVictimsBudgetDryRun tests that dry runs don't spend the kill budget.
+func (suite *Suite) TestTerminateVictimsBudgetDryRun() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		true,
+		10,
+		v1.NamespaceAll,
+	)
+
+	killBudget, err := budget.New(1, 0, "")
+	suite.Require().NoError(err)
+	chaoskube.Budget = killBudget
+
+	suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+	suite.Equal(1, killBudget.Remaining(chaoskube.Now()))
+}
+
+// TestCheckWatchdog tests that stalled intervals and terminations are detected.
+func (suite *Suite) TestCheckWatchdog() {
+	started := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	threshold := 30 * time.Minute
 
 	for _, tt := range []struct {
-		minimumAge time.Duration
-		now        func() time.Time
-		pods       []pod
-		candidates int
+		name        string
+		now         time.Time
+		lastTick    time.Time
+		lastKill    time.Time
+		candidates  []string
+		paused      bool
+		tickStalled bool
+		killStalled bool
 	}{
-		// no minimum age set
-		{
-			time.Duration(0),
-			func() time.Time { return time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC) },
-			[]pod{
-				{
-					name:         "test1",
-					namespace:    "test",
-					creationTime: time.Date(0, 10, 24, 9, 00, 00, 00, time.UTC),
-				},
-			},
-			1,
-		},
-		// minimum age set, but pod is too young
-		{
-			time.Hour * 1,
-			func() time.Time { return time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC) },
-			[]pod{
-				{
-					name:         "test1",
-					namespace:    "test",
-					creationTime: time.Date(0, 10, 24, 9, 30, 00, 00, time.UTC),
-				},
-			},
-			0,
-		},
-		// one pod is too young, one matches
-		{
-			time.Hour * 1,
-			func() time.Time { return time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC) },
-			[]pod{
-				// too young
-				{
-					name:         "test1",
-					namespace:    "test",
-					creationTime: time.Date(0, 10, 24, 9, 30, 00, 00, time.UTC),
-				},
-				// matches
-				{
-					name:         "test2",
-					namespace:    "test",
-					creationTime: time.Date(0, 10, 23, 8, 00, 00, 00, time.UTC),
-				},
-			},
-			1,
-		},
-		// exact time - should not match
-		{
-			time.Hour * 1,
-			func() time.Time { return time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC) },
-			[]pod{
-				{
-					name:         "test1",
-					namespace:    "test",
-					creationTime: time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC),
-				},
-			},
-			0,
-		},
+		{"just started", started.Add(10 * time.Minute), time.Time{}, time.Time{}, []string{"default/foo"}, false, false, false},
+		{"never ticked", started.Add(time.Hour), time.Time{}, time.Time{}, nil, false, true, false},
+		{"healthy", started.Add(time.Hour), started.Add(50 * time.Minute), started.Add(40 * time.Minute), []string{"default/foo"}, false, false, false},
+		{"no kills despite candidates", started.Add(time.Hour), started.Add(50 * time.Minute), started.Add(10 * time.Minute), []string{"default/foo"}, false, false, true},
+		{"no kills without candidates", started.Add(time.Hour), started.Add(50 * time.Minute), started.Add(10 * time.Minute), []string{}, false, false, false},
+		{"no kills while paused", started.Add(time.Hour), started.Add(50 * time.Minute), started.Add(10 * time.Minute), []string{"default/foo"}, true, false, false},
 	} {
-		chaoskube := suite.setup(
+		chaoskube := suite.setupWithPods(
 			labels.Everything(),
 			labels.Everything(),
 			labels.Everything(),
@@ -1110,137 +1345,2141 @@ func (suite *Suite) TestMinimumAge() {
 			[]util.TimePeriod{},
 			[]time.Time{},
 			time.UTC,
-			tt.minimumAge,
+			time.Duration(0),
 			false,
 			10,
-			1,
 			v1.NamespaceAll,
 		)
-		chaoskube.Now = tt.now
-
-		for _, p := range tt.pods {
-			pod := util.NewPod(p.namespace, p.name, v1.PodRunning)
-			pod.ObjectMeta.CreationTimestamp = metav1.Time{Time: p.creationTime}
-			_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
-			suite.Require().NoError(err)
-		}
-
-		pods, err := chaoskube.Candidates(context.Background())
-		suite.Require().NoError(err)
+		chaoskube.Now = func() time.Time { return tt.now }
+		chaoskube.lastTick = tt.lastTick
+		chaoskube.lastKill = tt.lastKill
+		chaoskube.lastCandidates = tt.candidates
+		chaoskube.paused = tt.paused
 
-		suite.Len(pods, tt.candidates)
+		tickStalled, killStalled := chaoskube.checkWatchdog(started, threshold)
+		suite.Equal(tt.tickStalled, tickStalled, tt.name)
+		suite.Equal(tt.killStalled, killStalled, tt.name)
 	}
 }
 
-func (suite *Suite) TestFilterDeletedPods() {
-	deletedPod := util.NewPod("default", "deleted", v1.PodRunning)
-	now := metav1.NewTime(time.Now())
-	deletedPod.SetDeletionTimestamp(&now)
-
-	runningPod := util.NewPod("default", "running", v1.PodRunning)
-
-	pods := []v1.Pod{runningPod, deletedPod}
+// TestCleanUp tests that the janitor deletes pods stuck terminating or lingering in a terminal
+// phase, and leaves all other pods alone.
+func (suite *Suite) TestCleanUp() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 
-	filtered := filterTerminatingPods(pods)
-	suite.Equal(len(filtered), 1)
-	suite.Equal(pods[0].Name, "running")
-}
+	newPod := func(name string, phase v1.PodPhase, created, deleted, finished time.Duration) v1.Pod {
+		pod := util.NewPod("default", name, phase)
+		pod.CreationTimestamp = metav1.NewTime(now.Add(-created))
+		if deleted > 0 {
+			deletion := metav1.NewTime(now.Add(-deleted))
+			pod.DeletionTimestamp = &deletion
+		}
+		if finished > 0 {
+			pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+				State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{FinishedAt: metav1.NewTime(now.Add(-finished))}},
+			}}
+		}
+		return pod
+	}
 
-func (suite *Suite) TestFilterByKinds() {
-	foo := util.NewPodWithOwner("default", "foo", v1.PodRunning, "parent-1")
-	foo1 := util.NewPodWithOwner("default", "foo-1", v1.PodRunning, "parent-2")
-	bar := util.NewPodWithOwner("default", "bar", v1.PodRunning, "other-parent")
-	baz := util.NewPod("default", "baz", v1.PodRunning)
-	baz1 := util.NewPod("default", "baz-1", v1.PodRunning)
+	pods := []v1.Pod{
+		newPod("stuck", v1.PodRunning, 72*time.Hour, 2*time.Hour, 0),
+		newPod("terminating", v1.PodRunning, 72*time.Hour, 10*time.Minute, 0),
+		newPod("failed", v1.PodFailed, 72*time.Hour, 0, 48*time.Hour),
+		newPod("succeeded", v1.PodSucceeded, 72*time.Hour, 0, 0),
+		newPod("unknown", v1.PodUnknown, 72*time.Hour, 0, 0),
+		newPod("recent", v1.PodSucceeded, 72*time.Hour, 0, time.Hour),
+		newPod("running", v1.PodRunning, 72*time.Hour, 0, 0),
+	}
 
 	for _, tt := range []struct {
-		name     string
-		kinds    string
-		pods     []v1.Pod
-		expected []v1.Pod
+		name        string
+		dryRun      bool
+		stuckAfter  time.Duration
+		lingerAfter time.Duration
+		remaining   []string
 	}{
-		{
-			name:     "2 pods, one with owner ref",
-			kinds:    "testkind",
-			pods:     []v1.Pod{foo, baz},
-			expected: []v1.Pod{foo},
-		},
-		{
-			name:     "5 pods, 3 with owner ref",
-			kinds:    "!testkind",
-			pods:     []v1.Pod{foo, foo1, baz, bar, baz1},
-			expected: []v1.Pod{baz, baz1},
-		},
-		{
-			name:     "3 pods with owner ref, different kind",
-			kinds:    "!testkind",
-			pods:     []v1.Pod{foo, foo1, bar},
-			expected: []v1.Pod{},
-		},
-		{
-			name:     "3 pods with owner ref, different kind",
-			kinds:    "!testkind,!job",
-			pods:     []v1.Pod{foo, baz},
-			expected: []v1.Pod{baz},
-		},
-		{
-			name:     "3 pods with owner ref, different kind",
-			kinds:    "testkind,job",
-			pods:     []v1.Pod{foo, foo1, bar, baz},
-			expected: []v1.Pod{foo, foo1, bar},
-		},
-		{
-			name:     "3 pods with owner ref, different kind",
-			kinds:    "!testkind,job",
-			pods:     []v1.Pod{foo, foo1, bar, baz},
-			expected: []v1.Pod{},
-		},
-		{
-			name:     "3 pods with owner ref, different kind",
-			kinds:    "testkind,!job",
-			pods:     []v1.Pod{foo, foo1, bar, baz},
-			expected: []v1.Pod{foo, foo1, bar},
-		},
-		{
-			name:     "3 pods with owner ref, different kind",
-			kinds:    "job",
-			pods:     []v1.Pod{foo, foo1, bar, baz},
-			expected: []v1.Pod{},
-		},
+		{"cleans up stuck and lingering pods", false, time.Hour, 24 * time.Hour, []string{"recent", "running", "terminating"}},
+		{"only cleans up stuck pods", false, time.Hour, 0, []string{"failed", "recent", "running", "succeeded", "terminating", "unknown"}},
+		{"only cleans up lingering pods", false, 0, 24 * time.Hour, []string{"recent", "running", "stuck", "terminating"}},
+		{"dry-run", true, time.Hour, 24 * time.Hour, []string{"failed", "recent", "running", "stuck", "succeeded", "terminating", "unknown"}},
 	} {
-		kindsSelector, err := labels.Parse(tt.kinds)
-		suite.Require().NoError(err)
+		chaoskube := suite.setup(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			tt.dryRun,
+			10,
+			1,
+			v1.NamespaceAll,
+		)
+		chaoskube.Now = func() time.Time { return now }
 
-		results, err := filterByKinds(tt.pods, kindsSelector)
-		suite.Require().Len(results, len(tt.expected))
+		for _, pod := range pods {
+			_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+			suite.Require().NoError(err)
+		}
+
+		suite.Require().NoError(chaoskube.cleanUp(context.Background(), tt.stuckAfter, tt.lingerAfter), tt.name)
+
+		remaining, err := chaoskube.Client.CoreV1().Pods(v1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
 		suite.Require().NoError(err)
 
-		for i, result := range results {
-			suite.Assert().Equal(tt.expected[i], result, tt.name)
+		names := []string{}
+		for _, pod := range remaining.Items {
+			names = append(names, pod.Name)
 		}
+		suite.ElementsMatch(tt.remaining, names, tt.name)
 	}
 }
 
-func (suite *Suite) TestFilterByOwnerReference() {
-	foo := util.NewPodWithOwner("default", "foo", v1.PodRunning, "parent")
-	foo1 := util.NewPodWithOwner("default", "foo-1", v1.PodRunning, "parent")
-	bar := util.NewPodWithOwner("default", "bar", v1.PodRunning, "other-parent")
-	baz := util.NewPod("default", "baz", v1.PodRunning)
-	baz1 := util.NewPod("default", "baz-1", v1.PodRunning)
+// TestCleanUpStatefulSetPods tests that stuck pods of StatefulSets are only force-deleted once
+// their node is gone and that the janitor respects the regular opt-out and DaemonSet filters.
+func (suite *Suite) TestCleanUpStatefulSetPods() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	controller := true
 
-	for _, tt := range []struct {
-		seed     int64
-		name     string
-		pods     []v1.Pod
-		expected []v1.Pod
-	}{
-		{
-			seed:     1000,
-			name:     "2 pods, same parent, pick first",
-			pods:     []v1.Pod{foo, foo1},
-			expected: []v1.Pod{foo},
-		},
-		{
+	newPod := func(name, kind, node string, phase v1.PodPhase, deleted time.Duration) v1.Pod {
+		pod := util.NewPod("default", name, phase)
+		pod.CreationTimestamp = metav1.NewTime(now.Add(-72 * time.Hour))
+		pod.Spec.NodeName = node
+		if kind != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, Controller: &controller}}
+		}
+		if deleted > 0 {
+			deletion := metav1.NewTime(now.Add(-deleted))
+			pod.DeletionTimestamp = &deletion
+		}
+		return pod
+	}
+
+	optedOut := newPod("opted-out", "", "alive", v1.PodSucceeded, 0)
+	optedOut.Annotations = map[string]string{"chaos.alpha.kubernetes.io/enabled": "false"}
+
+	pods := []v1.Pod{
+		newPod("stateful-alive", "StatefulSet", "alive", v1.PodRunning, 2*time.Hour),
+		newPod("stateful-gone", "StatefulSet", "gone", v1.PodRunning, 2*time.Hour),
+		newPod("replica-alive", "ReplicaSet", "alive", v1.PodRunning, 2*time.Hour),
+		newPod("daemon", "DaemonSet", "alive", v1.PodSucceeded, 0),
+		optedOut,
+	}
+
+	chaoskube := suite.setup(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		1,
+		v1.NamespaceAll,
+	)
+	chaoskube.Now = func() time.Time { return now }
+
+	_, err := chaoskube.Client.CoreV1().Nodes().Create(context.Background(), &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "alive"}}, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	for _, pod := range pods {
+		_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+	}
+
+	suite.Require().NoError(chaoskube.cleanUp(context.Background(), time.Hour, time.Hour))
+
+	remaining, err := chaoskube.Client.CoreV1().Pods(v1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+	suite.Require().NoError(err)
+
+	names := []string{}
+	for _, pod := range remaining.Items {
+		names = append(names, pod.Name)
+	}
+	suite.ElementsMatch([]string{"daemon", "opted-out", "stateful-alive"}, names)
+}
+
+// TestCleanUpPaused tests that the janitor leaves all pods alone while paused.
+func (suite *Suite) TestCleanUpPaused() {
+	chaoskube := suite.setup(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		1,
+		v1.NamespaceAll,
+	)
+	chaoskube.SetPaused(true)
+
+	pod := util.NewPod("default", "failed", v1.PodFailed)
+	_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(chaoskube.cleanUp(context.Background(), 0, time.Nanosecond))
+
+	remaining, err := chaoskube.Client.CoreV1().Pods(v1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+	suite.Require().NoError(err)
+	suite.Len(remaining.Items, 1)
+}
+
+// TestDeletePodServerDryRun tests that deletions in dry-run mode are sent as server-side dry runs.
+func (suite *Suite) TestDeletePodServerDryRun() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		true,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.ServerDryRun = true
+
+	deletePodTerminator := terminator.NewDeletePodTerminator(chaoskube.Client, logger, 10*time.Second)
+	deletePodTerminator.ServerDryRun = true
+	chaoskube.Terminator = deletePodTerminator
+
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), victim))
+
+	var deletions []ktesting.DeleteAction
+	for _, action := range chaoskube.Client.(*fake.Clientset).Actions() {
+		if deletion, ok := action.(ktesting.DeleteAction); ok {
+			deletions = append(deletions, deletion)
+		}
+	}
+	suite.Require().Len(deletions, 1)
+	suite.Equal([]string{metav1.DryRunAll}, deletions[0].GetDeleteOptions().DryRun)
+
+	// deletions of missing pods fail like real ones
+	suite.Error(chaoskube.DeletePod(context.Background(), util.NewPod("default", "missing", v1.PodRunning)))
+}
+
+// TestDeletePodServerDryRunOtherTerminator tests that other terminators are never called in
+// dry-run mode, even with server-side dry runs enabled.
+func (suite *Suite) TestDeletePodServerDryRunOtherTerminator() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		true,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.ServerDryRun = true
+
+	recorder := &concurrencyTerminator{}
+	chaoskube.Terminator = terminator.NewCordonTerminator(recorder, chaoskube.Client, logger, 0)
+
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), util.NewPod("default", "foo", v1.PodRunning)))
+	suite.Zero(recorder.calls)
+
+	chaoskube.Terminator = recorder
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), util.NewPod("default", "foo", v1.PodRunning)))
+	suite.Zero(recorder.calls)
+}
+
+// TestDeletePodReplaced tests that a victim replaced by a pod of the same name is skipped.
+func (suite *Suite) TestDeletePodReplaced() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.History = history.New(10)
+
+	// the pod in the cluster has a different UID than the selected victim
+	chaoskube.Client.(*fake.Clientset).PrependReactor("delete", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+		preconditions := action.(ktesting.DeleteAction).GetDeleteOptions().Preconditions
+		suite.Require().NotNil(preconditions)
+		suite.Equal(types.UID("old-uid"), *preconditions.UID)
+		return true, nil, apierrors.NewConflict(v1.Resource("pods"), "foo", fmt.Errorf("precondition failed"))
+	})
+
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	victim.UID = "old-uid"
+
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), victim))
+	suite.AssertLog(logOutput, log.WarnLevel, msgPodReplaced, log.Fields{"namespace": "default", "name": "foo", "uid": types.UID("old-uid")})
+	suite.Empty(chaoskube.History.Entries())
+}
+
+// TestDeletePodNotFound tests missing target pod will return an error.
+func (suite *Suite) TestDeletePodNotFound() {
+	chaoskube := suite.setup(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		1,
+		v1.NamespaceAll,
+	)
+
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+
+	err := chaoskube.DeletePod(context.Background(), victim)
+	suite.EqualError(err, `pods "foo" not found`)
+}
+
+func (suite *Suite) TestTerminateVictim() {
+	midnight := util.NewTimePeriod(
+		ThankGodItsFriday{}.Now().Add(-16*time.Hour),
+		ThankGodItsFriday{}.Now().Add(-14*time.Hour),
+	)
+	morning := util.NewTimePeriod(
+		ThankGodItsFriday{}.Now().Add(-7*time.Hour),
+		ThankGodItsFriday{}.Now().Add(-6*time.Hour),
+	)
+	afternoon := util.NewTimePeriod(
+		ThankGodItsFriday{}.Now().Add(-1*time.Hour),
+		ThankGodItsFriday{}.Now().Add(+1*time.Hour),
+	)
+
+	australia, err := time.LoadLocation("Australia/Brisbane")
+	suite.Require().NoError(err)
+
+	for _, tt := range []struct {
+		excludedWeekdays   []time.Weekday
+		excludedTimesOfDay []util.TimePeriod
+		excludedDaysOfYear []time.Time
+		now                func() time.Time
+		timezone           *time.Location
+		remainingPodCount  int
+	}{
+		// no time is excluded, one pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			ThankGodItsFriday{}.Now,
+			time.UTC,
+			1,
+		},
+		// current weekday is excluded, no pod should be killed
+		{
+			[]time.Weekday{time.Friday},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			ThankGodItsFriday{}.Now,
+			time.UTC,
+			2,
+		},
+		// current time of day is excluded, no pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{afternoon},
+			[]time.Time{},
+			ThankGodItsFriday{}.Now,
+			time.UTC,
+			2,
+		},
+		// one day after an excluded weekday, one pod should be killed
+		{
+			[]time.Weekday{time.Friday},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			func() time.Time { return ThankGodItsFriday{}.Now().Add(24 * time.Hour) },
+			time.UTC,
+			1,
+		},
+		// seven days after an excluded weekday, no pod should be killed
+		{
+			[]time.Weekday{time.Friday},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			func() time.Time { return ThankGodItsFriday{}.Now().Add(7 * 24 * time.Hour) },
+			time.UTC,
+			2,
+		},
+		// one hour after an excluded time period, one pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{afternoon},
+			[]time.Time{},
+			func() time.Time { return ThankGodItsFriday{}.Now().Add(+2 * time.Hour) },
+			time.UTC,
+			1,
+		},
+		// twenty four hours after an excluded time period, no pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{afternoon},
+			[]time.Time{},
+			func() time.Time { return ThankGodItsFriday{}.Now().Add(+24 * time.Hour) },
+			time.UTC,
+			2,
+		},
+		// current weekday is excluded but we are in another time zone, one pod should be killed
+		{
+			[]time.Weekday{time.Friday},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			ThankGodItsFriday{}.Now,
+			australia,
+			1,
+		},
+		// current time period is excluded but we are in another time zone, one pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{afternoon},
+			[]time.Time{},
+			ThankGodItsFriday{}.Now,
+			australia,
+			1,
+		},
+		// one out of two excluded weeksdays match, no pod should be killed
+		{
+			[]time.Weekday{time.Monday, time.Friday},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			ThankGodItsFriday{}.Now,
+			time.UTC,
+			2,
+		},
+		// one out of two excluded time periods match, no pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{morning, afternoon},
+			[]time.Time{},
+			ThankGodItsFriday{}.Now,
+			time.UTC,
+			2,
+		},
+		// we're inside an excluded time period across days, no pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{midnight},
+			[]time.Time{},
+			func() time.Time { return ThankGodItsFriday{}.Now().Add(-15 * time.Hour) },
+			time.UTC,
+			2,
+		},
+		// we're before an excluded time period across days, one pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{midnight},
+			[]time.Time{},
+			func() time.Time { return ThankGodItsFriday{}.Now().Add(-17 * time.Hour) },
+			time.UTC,
+			1,
+		},
+		// we're after an excluded time period across days, one pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{midnight},
+			[]time.Time{},
+			func() time.Time { return ThankGodItsFriday{}.Now().Add(-13 * time.Hour) },
+			time.UTC,
+			1,
+		},
+		// this day of year is excluded, no pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{
+				ThankGodItsFriday{}.Now(), // today
+			},
+			func() time.Time { return ThankGodItsFriday{}.Now() },
+			time.UTC,
+			2,
+		},
+		// this day of year in year 0 is excluded, no pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{
+				time.Date(0, 9, 24, 0, 00, 00, 00, time.UTC), // same year day
+			},
+			func() time.Time { return ThankGodItsFriday{}.Now() },
+			time.UTC,
+			2,
+		},
+		// matching works fine even when multiple days-of-year are provided, no pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{
+				time.Date(0, 9, 25, 10, 00, 00, 00, time.UTC), // different year day
+				time.Date(0, 9, 24, 10, 00, 00, 00, time.UTC), // same year day
+			},
+			func() time.Time { return ThankGodItsFriday{}.Now() },
+			time.UTC,
+			2,
+		},
+		// there is an excluded day of year but it's not today, one pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{
+				time.Date(0, 9, 25, 10, 00, 00, 00, time.UTC), // different year day
+			},
+			func() time.Time { return ThankGodItsFriday{}.Now() },
+			time.UTC,
+			1,
+		},
+		// there is an excluded day of year but the month is different, one pod should be killed
+		{
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{
+				time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC), // different year day
+			},
+			func() time.Time { return ThankGodItsFriday{}.Now() },
+			time.UTC,
+			1,
+		},
+	} {
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			tt.excludedWeekdays,
+			tt.excludedTimesOfDay,
+			tt.excludedDaysOfYear,
+			tt.timezone,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+		chaoskube.Now = tt.now
+
+		err := chaoskube.TerminateVictims(context.Background())
+		suite.Require().NoError(err)
+
+		pods, err := chaoskube.Candidates(context.Background())
+		suite.Require().NoError(err)
+
+		suite.Len(pods, tt.remainingPodCount)
+	}
+}
+
+// TestIncludedTimes tests that termination is suspended outside of the included weekdays and
+// times of day, if any.
+func (suite *Suite) TestIncludedTimes() {
+	businessHours, err := util.ParseTimePeriods("09:00-17:00")
+	suite.Require().NoError(err)
+
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+	wednesday := time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name       string
+		weekdays   []time.Weekday
+		timesOfDay []util.TimePeriod
+		now        time.Time
+		excluded   bool
+		message    string
+	}{
+		{"nothing included", nil, nil, wednesday.Add(3 * time.Hour), false, ""},
+		{"included weekday", weekdays, nil, wednesday.Add(3 * time.Hour), false, ""},
+		{"weekend", weekdays, nil, wednesday.Add(3*24*time.Hour + 12*time.Hour), true, msgWeekdayNotIncluded},
+		{"business hours", nil, businessHours, wednesday.Add(12 * time.Hour), false, ""},
+		{"night", nil, businessHours, wednesday.Add(3 * time.Hour), true, msgTimeOfDayNotIncluded},
+		{"business hours on a weekday", weekdays, businessHours, wednesday.Add(12 * time.Hour), false, ""},
+		{"business hours on the weekend", weekdays, businessHours, wednesday.Add(3*24*time.Hour + 12*time.Hour), true, msgWeekdayNotIncluded},
+	} {
+		logOutput.Reset()
+
+		chaoskube := &Chaoskube{
+			Logger:             logger,
+			IncludedWeekdays:   tt.weekdays,
+			IncludedTimesOfDay: tt.timesOfDay,
+		}
+
+		suite.Equal(tt.excluded, chaoskube.excluded(tt.now), tt.name)
+		if tt.message != "" {
+			suite.Equal(tt.message, logOutput.LastEntry().Message, tt.name)
+		}
+	}
+}
+
+// TestExcludedDaysCalendar tests that termination is suspended on the days covered by the
+// calendar of excluded days, in the configured timezone.
+func (suite *Suite) TestExcludedDaysCalendar() {
+	chaoskube := &Chaoskube{
+		Logger:               logger,
+		ExcludedDaysCalendar: staticDayCalendar{"2024-12-24": true},
+	}
+
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	suite.Require().NoError(err)
+
+	for _, tt := range []struct {
+		now      time.Time
+		excluded bool
+	}{
+		{time.Date(2024, 12, 23, 12, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 12, 24, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 12, 23, 20, 0, 0, 0, time.UTC).In(sydney), true},
+	} {
+		logOutput.Reset()
+		suite.Equal(tt.excluded, chaoskube.excluded(tt.now), tt.now.String())
+		if tt.excluded {
+			suite.Equal(msgCalendarExcluded, logOutput.LastEntry().Message)
+		}
+	}
+}
+
+// TestTerminateNoVictimLogsInfo tests that missing victim prints a log message
+// TestTerminateWorkers tests that victims are terminated concurrently by a bounded number of workers.
+func (suite *Suite) TestTerminateWorkers() {
+	for _, tt := range []struct {
+		workers     int
+		victims     int
+		concurrency int
+	}{
+		{0, 4, 1},
+		{1, 4, 1},
+		{2, 4, 2},
+		{8, 4, 4},
+	} {
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			nil,
+			nil,
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+		terminator := &concurrencyTerminator{delay: 10 * time.Millisecond, fail: "victim-0"}
+		chaoskube.Terminator = terminator
+		chaoskube.TerminationWorkers = tt.workers
+
+		victims := []v1.Pod{}
+		for i := 0; i < tt.victims; i++ {
+			victims = append(victims, util.NewPod("default", fmt.Sprintf("victim-%d", i), v1.PodRunning))
+		}
+
+		err := chaoskube.terminate(context.Background(), victims)
+		suite.EqualError(err, "1 error occurred:\n\t* failed to terminate victim-0\n\n")
+
+		suite.Equal(tt.victims, terminator.calls)
+		suite.Equal(tt.concurrency, terminator.maxActive)
+	}
+}
+
+func (suite *Suite) TestTerminateNoVictimLogsInfo() {
+	chaoskube := suite.setup(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		1,
+		v1.NamespaceAll,
+	)
+
+	err := chaoskube.TerminateVictims(context.Background())
+	suite.Require().NoError(err)
+
+	suite.AssertLog(logOutput, log.DebugLevel, msgVictimNotFound, log.Fields{})
+}
+
+// TestTerminateTriggeredVictims tests that triggered terminations only pick victims matching the request.
+func (suite *Suite) TestTerminateTriggeredVictims() {
+	foo := map[string]string{"namespace": "default", "name": "foo"}
+	bar := map[string]string{"namespace": "testing", "name": "bar"}
+
+	for _, tt := range []struct {
+		namespace     string
+		selector      string
+		remainingPods []map[string]string
+	}{
+		{"default", "app=foo", []map[string]string{bar}},
+		{"testing", "app=bar", []map[string]string{foo}},
+		{"testing", "app=foo", []map[string]string{foo, bar}},
+		{v1.NamespaceAll, "app=bar", []map[string]string{foo}},
+	} {
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+
+		selector, err := labels.Parse(tt.selector)
+		suite.Require().NoError(err)
+
+		err = chaoskube.TerminateTriggeredVictims(context.Background(), trigger.Request{
+			Namespace: tt.namespace,
+			Selector:  selector,
+			Reason:    "test",
+		})
+		suite.Require().NoError(err)
+
+		suite.assertCandidates(chaoskube, tt.remainingPods)
+	}
+}
+
+// helper functions
+
+func (suite *Suite) assertCandidates(chaoskube *Chaoskube, expected []map[string]string) {
+	pods, err := chaoskube.Candidates(context.Background())
+	suite.Require().NoError(err)
+
+	suite.AssertPods(pods, expected)
+}
+
+func (suite *Suite) assertVictims(chaoskube *Chaoskube, expected []map[string]string) {
+	victims, err := chaoskube.Victims(context.Background())
+	suite.Require().NoError(err)
+
+	for i, victim := range victims {
+		suite.AssertPod(victim, expected[i])
+	}
+}
+
+func (suite *Suite) assertVictim(chaoskube *Chaoskube, expected map[string]string) {
+	suite.assertVictims(chaoskube, []map[string]string{expected})
+}
+
+func (suite *Suite) assertNotified(notifier *notifier.Noop) {
+	suite.Assert().Greater(notifier.Calls, 0)
+}
+
+func (suite *Suite) setupWithPods(labelSelector labels.Selector, annotations labels.Selector, kinds labels.Selector, namespaces labels.Selector, namespaceLabels labels.Selector, includedPodNames *regexp.Regexp, excludedPodNames *regexp.Regexp, excludedWeekdays []time.Weekday, excludedTimesOfDay []util.TimePeriod, excludedDaysOfYear []time.Time, timezone *time.Location, minimumAge time.Duration, dryRun bool, gracePeriod time.Duration, clientNamespaceScope string) *Chaoskube {
+	chaoskube := suite.setup(
+		labelSelector,
+		annotations,
+		kinds,
+		namespaces,
+		namespaceLabels,
+		includedPodNames,
+		excludedPodNames,
+		excludedWeekdays,
+		excludedTimesOfDay,
+		excludedDaysOfYear,
+		timezone,
+		minimumAge,
+		dryRun,
+		gracePeriod,
+		1,
+		clientNamespaceScope,
+	)
+
+	for _, namespace := range []v1.Namespace{
+		util.NewNamespace("default"),
+		util.NewNamespace("testing"),
+	} {
+		_, err := chaoskube.Client.CoreV1().Namespaces().Create(context.Background(), &namespace, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+	}
+
+	pods := []v1.Pod{
+		util.NewPod("default", "foo", v1.PodRunning),
+		util.NewPod("testing", "bar", v1.PodRunning),
+		util.NewPod("testing", "baz", v1.PodPending), // Non-running pods are ignored
+	}
+
+	for _, pod := range pods {
+		_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+	}
+
+	return chaoskube
+}
+
+func (suite *Suite) createPods(client kubernetes.Interface, podsInfo []podInfo) {
+	for _, p := range podsInfo {
+		namespace := util.NewNamespace(p.Namespace)
+		_, err := client.CoreV1().Namespaces().Create(context.Background(), &namespace, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+		pod := util.NewPod(p.Namespace, p.Name, v1.PodRunning)
+		_, err = client.CoreV1().Pods(p.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+	}
+}
+
+func (suite *Suite) setup(labelSelector labels.Selector, annotations labels.Selector, kinds labels.Selector, namespaces labels.Selector, namespaceLabels labels.Selector, includedPodNames *regexp.Regexp, excludedPodNames *regexp.Regexp, excludedWeekdays []time.Weekday, excludedTimesOfDay []util.TimePeriod, excludedDaysOfYear []time.Time, timezone *time.Location, minimumAge time.Duration, dryRun bool, gracePeriod time.Duration, maxKill int, clientNamespaceScope string) *Chaoskube {
+	return suite.setupWithInterval(labelSelector, annotations, kinds, namespaces, namespaceLabels, includedPodNames, excludedPodNames, excludedWeekdays, excludedTimesOfDay, excludedDaysOfYear, timezone, minimumAge, dryRun, gracePeriod, maxKill, clientNamespaceScope, false, 1.0, 10*time.Minute)
+}
+
+func (suite *Suite) setupWithInterval(labelSelector labels.Selector, annotations labels.Selector, kinds labels.Selector, namespaces labels.Selector, namespaceLabels labels.Selector, includedPodNames *regexp.Regexp, excludedPodNames *regexp.Regexp, excludedWeekdays []time.Weekday, excludedTimesOfDay []util.TimePeriod, excludedDaysOfYear []time.Time, timezone *time.Location, minimumAge time.Duration, dryRun bool, gracePeriod time.Duration, maxKill int, clientNamespaceScope string, dynamicInterval bool, dynamicFactor float64, interval time.Duration) *Chaoskube {
+	logOutput.Reset()
+
+	client := fake.NewSimpleClientset()
+	nullLogger, _ := test.NewNullLogger()
+
+	return New(
+		client,
+		labelSelector,
+		annotations,
+		kinds,
+		namespaces,
+		namespaceLabels,
+		includedPodNames,
+		excludedPodNames,
+		excludedWeekdays,
+		excludedTimesOfDay,
+		excludedDaysOfYear,
+		timezone,
+		minimumAge,
+		logger,
+		dryRun,
+		terminator.NewDeletePodTerminator(client, nullLogger, gracePeriod),
+		maxKill,
+		testNotifier,
+		clientNamespaceScope,
+		dynamicInterval,
+		dynamicFactor,
+		interval,
+	)
+}
+
+func (suite *Suite) TestDynamicIntervalCalculation() {
+	for _, tt := range []struct {
+		name             string
+		podCount         int
+		annotatedCount   int
+		annotations      labels.Selector
+		dynamicInterval  bool
+		dynamicFactor    float64
+		baseInterval     time.Duration
+		workingDays      float64
+		workingHours     float64
+		target           float64
+		expectedInterval time.Duration
+	}{
+		{
+			name:            "100 pods with factor 1.0",
+			podCount:        100,
+			annotatedCount:  100,
+			annotations:     labels.Everything(),
+			dynamicInterval: true,
+			dynamicFactor:   1.0,
+			baseInterval:    10 * time.Minute,
+			// Total working minutes (5 days * 8 hours * 60 minutes) = 2400 minutes
+			// With 100 pods and target of 50%, interval = 2400 / (100 * 0.5 * 1.0) = 48 minutes
+			expectedInterval: 48 * time.Minute,
+		},
+		{
+			name:            "1500 pods with factor 1.0",
+			podCount:        1500,
+			annotatedCount:  1500,
+			annotations:     labels.Everything(),
+			dynamicInterval: true,
+			dynamicFactor:   1.0,
+			baseInterval:    10 * time.Minute,
+			// With 1500 pods and target of 50%, interval = 2400 / (1500 * 0.5 * 1.0) = 3.2 minutes -> rounded to 3 minutes
+			expectedInterval: 3 * time.Minute,
+		},
+		{
+			name:            "1500 pods with factor 2.0",
+			podCount:        1500,
+			annotatedCount:  1500,
+			annotations:     labels.Everything(),
+			dynamicInterval: true,
+			dynamicFactor:   2.0,
+			baseInterval:    10 * time.Minute,
+			// With 1500 pods and factor 2.0, interval = 2400 / (1500 * 0.5 * 2.0) = 1.6 minutes -> rounded to 2 minutes
+			expectedInterval: 2 * time.Minute,
+		},
+		{
+			name:            "50 pods with factor 0.5",
+			podCount:        50,
+			annotatedCount:  50,
+			annotations:     labels.Everything(),
+			dynamicInterval: true,
+			dynamicFactor:   0.5,
+			baseInterval:    10 * time.Minute,
+			// With 50 pods and factor 0.5, interval = 2400 / (50 * 0.5 * 0.5) = 192 minutes
+			expectedInterval: 192 * time.Minute,
+		},
+		{
+			name:            "0 pods fallback to base interval",
+			podCount:        0,
+			annotatedCount:  0,
+			annotations:     labels.Everything(),
+			dynamicInterval: true,
+			dynamicFactor:   1.0,
+			baseInterval:    10 * time.Minute,
+			// Should fall back to base interval with 0 pods
+			expectedInterval: 10 * time.Minute,
+		},
+		{
+			name:            "50 pods with 10 matching annotation",
+			podCount:        50,
+			annotatedCount:  10,
+			annotations:     labels.SelectorFromSet(labels.Set{"chaos": "true"}),
+			dynamicInterval: true,
+			dynamicFactor:   1.0,
+			baseInterval:    10 * time.Minute,
+			// With 10 pods after annotation filtering and target of 50%, interval = 2400 / (10 * 0.5 * 1.0) = 480 minutes
+			expectedInterval: 480 * time.Minute,
+		},
+		{
+			name:            "100 pods around the clock",
+			podCount:        100,
+			annotatedCount:  100,
+			annotations:     labels.Everything(),
+			dynamicInterval: true,
+			dynamicFactor:   1.0,
+			baseInterval:    10 * time.Minute,
+			workingDays:     7,
+			workingHours:    24,
+			target:          1.0,
+			// Total minutes (7 days * 24 hours * 60 minutes) = 10080 minutes
+			// With 100 pods and target of 100%, interval = 10080 / (100 * 1.0 * 1.0) = 100.8 minutes -> rounded to 101 minutes
+			expectedInterval: 101 * time.Minute,
+		},
+	} {
+		chaoskube := suite.setupWithInterval(
+			labels.Everything(),
+			tt.annotations,
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10*time.Second,
+			1,
+			v1.NamespaceAll,
+			tt.dynamicInterval,
+			tt.dynamicFactor,
+			tt.baseInterval,
+		)
+		if tt.target > 0 {
+			chaoskube.DynamicIntervalWorkingDays = tt.workingDays
+			chaoskube.DynamicIntervalWorkingHours = tt.workingHours
+			chaoskube.DynamicIntervalTarget = tt.target
+		}
+
+		// Create test pods
+		for i := 0; i < tt.podCount; i++ {
+			pod := util.NewPod("default", fmt.Sprintf("pod-%d", i), v1.PodRunning)
+
+			// Add the annotation to a subset of pods if annotatedCount is specified
+			if i < tt.annotatedCount {
+				pod.Annotations = map[string]string{
+					"chaos": "true",
+				}
+			}
+
+			_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+			suite.Require().NoError(err)
+		}
+
+		interval := chaoskube.CalculateDynamicInterval(context.Background())
+		suite.Equal(tt.expectedInterval, interval, tt.name)
+	}
+}
+
+// TestSmoothPodCount tests that pod counts are smoothed by a moving average, if enabled.
+func (suite *Suite) TestSmoothPodCount() {
+	for _, tt := range []struct {
+		smoothing float64
+		counts    []int
+		expected  []float64
+	}{
+		{0, []int{100, 200, 0}, []float64{100, 200, 0}},
+		{0.5, []int{100, 200, 0}, []float64{100, 150, 75}},
+		{0.2, []int{100, 1100, 1100}, []float64{100, 300, 460}},
+	} {
+		chaoskube := &Chaoskube{
+			Logger:                   logger,
+			DynamicIntervalSmoothing: tt.smoothing,
+		}
+
+		for i, count := range tt.counts {
+			suite.InDelta(tt.expected[i], chaoskube.smoothPodCount(count), 0.001)
+		}
+	}
+}
+
+// staticPodQuerier is a PodQuerier that always returns the same set of pods.
+type staticPodQuerier map[string]struct{}
+
+func (q staticPodQuerier) Pods(ctx context.Context) (map[string]struct{}, error) {
+	return q, nil
+}
+
+// podMatcherFunc is a PodMatcher backed by a function.
+type podMatcherFunc func(pod v1.Pod) (bool, error)
+
+func (f podMatcherFunc) Matches(ctx context.Context, pod v1.Pod) (bool, error) {
+	return f(pod)
+}
+
+// staticDayCalendar is a DayCalendar covering a fixed set of days.
+type staticDayCalendar map[string]bool
+
+func (c staticDayCalendar) Includes(t time.Time) bool {
+	return c[t.Format(time.DateOnly)]
+}
+
+// concurrencyTerminator is a Terminator that records how many terminations ran at the same time.
+type concurrencyTerminator struct {
+	delay     time.Duration
+	fail      string
+	mutex     sync.Mutex
+	active    int
+	maxActive int
+	calls     int
+}
+
+func (t *concurrencyTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	t.mutex.Lock()
+	t.calls++
+	t.active++
+	if t.active > t.maxActive {
+		t.maxActive = t.active
+	}
+	t.mutex.Unlock()
+
+	time.Sleep(t.delay)
+
+	t.mutex.Lock()
+	t.active--
+	t.mutex.Unlock()
+
+	if victim.Name == t.fail {
+		return fmt.Errorf("failed to terminate %s", victim.Name)
+	}
+	return nil
+}
+
+// staticLister is a Lister that always returns the same pods and no namespaces.
+type staticLister []v1.Pod
+
+func (l staticLister) ListPods(ctx context.Context) ([]v1.Pod, error) {
+	return append([]v1.Pod{}, l...), nil
+}
+
+func (l staticLister) ListNamespaces(ctx context.Context, selector labels.Selector) ([]v1.Namespace, error) {
+	return nil, nil
+}
+
+// lastStrategy is a Strategy that selects the last candidates in reverse order.
+type lastStrategy struct{}
+
+func (lastStrategy) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	victims := []v1.Pod{}
+	for i := len(pods) - 1; i >= 0 && len(victims) < count; i-- {
+		victims = append(victims, pods[i])
+	}
+	return victims
+}
+
+// namespaceLister is a Lister that always returns the same namespaces, regardless of the selector, and no pods.
+type namespaceLister []v1.Namespace
+
+func (l namespaceLister) ListPods(ctx context.Context) ([]v1.Pod, error) {
+	return nil, nil
+}
+
+func (l namespaceLister) ListNamespaces(ctx context.Context, selector labels.Selector) ([]v1.Namespace, error) {
+	return l, nil
+}
+
+// TestSpendCostUnselectedVictims tests that victims not selected by cost, e.g. triggered ones,
+// are charged against the daily cost budget, too.
+func (suite *Suite) TestSpendCostUnselectedVictims() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		&regexp.Regexp{},
+		&regexp.Regexp{},
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.CostProvider = staticCostProvider{"default/foo": 5, "testing/bar": 5}
+	chaoskube.MaxCostPerDay = 5
+	chaoskube.Terminator = &concurrencyTerminator{}
+
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), util.NewPod("default", "foo", v1.PodRunning)))
+
+	_, err := chaoskube.Victims(context.Background())
+	suite.Equal(errPodNotFound, err)
+}
+
+// staticCostProvider is a CostProvider that always returns the same costs.
+type staticCostProvider map[string]float64
+
+func (p staticCostProvider) PodCosts(ctx context.Context) (map[string]float64, error) {
+	return p, nil
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// ThankGodItsFriday is a helper struct that contains a Now() function that always returns a Friday.
+type ThankGodItsFriday struct{}
+
+// Now returns a particular Friday.
+func (t ThankGodItsFriday) Now() time.Time {
+	blackFriday, _ := time.Parse(time.RFC1123, "Fri, 24 Sep 1869 15:04:05 UTC")
+	return blackFriday
+}
+
+func (suite *Suite) TestMinimumAge() {
+	type pod struct {
+		name         string
+		namespace    string
+		creationTime time.Time
+	}
+
+	for _, tt := range []struct {
+		minimumAge time.Duration
+		now        func() time.Time
+		pods       []pod
+		candidates int
+	}{
+		// no minimum age set
+		{
+			time.Duration(0),
+			func() time.Time { return time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC) },
+			[]pod{
+				{
+					name:         "test1",
+					namespace:    "test",
+					creationTime: time.Date(0, 10, 24, 9, 00, 00, 00, time.UTC),
+				},
+			},
+			1,
+		},
+		// minimum age set, but pod is too young
+		{
+			time.Hour * 1,
+			func() time.Time { return time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC) },
+			[]pod{
+				{
+					name:         "test1",
+					namespace:    "test",
+					creationTime: time.Date(0, 10, 24, 9, 30, 00, 00, time.UTC),
+				},
+			},
+			0,
+		},
+		// one pod is too young, one matches
+		{
+			time.Hour * 1,
+			func() time.Time { return time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC) },
+			[]pod{
+				// too young
+				{
+					name:         "test1",
+					namespace:    "test",
+					creationTime: time.Date(0, 10, 24, 9, 30, 00, 00, time.UTC),
+				},
+				// matches
+				{
+					name:         "test2",
+					namespace:    "test",
+					creationTime: time.Date(0, 10, 23, 8, 00, 00, 00, time.UTC),
+				},
+			},
+			1,
+		},
+		// exact time - should not match
+		{
+			time.Hour * 1,
+			func() time.Time { return time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC) },
+			[]pod{
+				{
+					name:         "test1",
+					namespace:    "test",
+					creationTime: time.Date(0, 10, 24, 10, 00, 00, 00, time.UTC),
+				},
+			},
+			0,
+		},
+	} {
+		chaoskube := suite.setup(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			tt.minimumAge,
+			false,
+			10,
+			1,
+			v1.NamespaceAll,
+		)
+		chaoskube.Now = tt.now
+
+		for _, p := range tt.pods {
+			pod := util.NewPod(p.namespace, p.name, v1.PodRunning)
+			pod.ObjectMeta.CreationTimestamp = metav1.Time{Time: p.creationTime}
+			_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+			suite.Require().NoError(err)
+		}
+
+		pods, err := chaoskube.Candidates(context.Background())
+		suite.Require().NoError(err)
+
+		suite.Len(pods, tt.candidates)
+	}
+}
+
+func (suite *Suite) TestFilterDeletedPods() {
+	deletedPod := util.NewPod("default", "deleted", v1.PodRunning)
+	now := metav1.NewTime(time.Now())
+	deletedPod.SetDeletionTimestamp(&now)
+
+	runningPod := util.NewPod("default", "running", v1.PodRunning)
+
+	pods := []v1.Pod{runningPod, deletedPod}
+
+	filtered := filterTerminatingPods(pods)
+	suite.Equal(len(filtered), 1)
+	suite.Equal(pods[0].Name, "running")
+}
+
+// TestCandidatesFieldSelector tests that selectors are passed on to the API server.
+func (suite *Suite) TestCandidatesFieldSelector() {
+	chaoskube := suite.setupWithPods(
+		labels.SelectorFromSet(labels.Set{"app": "foo"}),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		false,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", "node-1")
+
+	_, err := chaoskube.Candidates(context.Background())
+	suite.Require().NoError(err)
+
+	var list ktesting.ListAction
+	for _, action := range chaoskube.Client.(*fake.Clientset).Actions() {
+		if action.Matches("list", "pods") {
+			list = action.(ktesting.ListAction)
+		}
+	}
+	suite.Require().NotNil(list)
+	suite.Equal("app=foo", list.GetListRestrictions().Labels.String())
+	suite.Equal("spec.nodeName=node-1,status.phase=Running", list.GetListRestrictions().Fields.String())
+}
+
+func (suite *Suite) TestFilterByKinds() {
+	foo := util.NewPodWithOwner("default", "foo", v1.PodRunning, "parent-1")
+	foo1 := util.NewPodWithOwner("default", "foo-1", v1.PodRunning, "parent-2")
+	bar := util.NewPodWithOwner("default", "bar", v1.PodRunning, "other-parent")
+	baz := util.NewPod("default", "baz", v1.PodRunning)
+	baz1 := util.NewPod("default", "baz-1", v1.PodRunning)
+
+	for _, tt := range []struct {
+		name     string
+		kinds    string
+		pods     []v1.Pod
+		expected []v1.Pod
+	}{
+		{
+			name:     "2 pods, one with owner ref",
+			kinds:    "testkind",
+			pods:     []v1.Pod{foo, baz},
+			expected: []v1.Pod{foo},
+		},
+		{
+			name:     "5 pods, 3 with owner ref",
+			kinds:    "!testkind",
+			pods:     []v1.Pod{foo, foo1, baz, bar, baz1},
+			expected: []v1.Pod{baz, baz1},
+		},
+		{
+			name:     "3 pods with owner ref, different kind",
+			kinds:    "!testkind",
+			pods:     []v1.Pod{foo, foo1, bar},
+			expected: []v1.Pod{},
+		},
+		{
+			name:     "3 pods with owner ref, different kind",
+			kinds:    "!testkind,!job",
+			pods:     []v1.Pod{foo, baz},
+			expected: []v1.Pod{baz},
+		},
+		{
+			name:     "3 pods with owner ref, different kind",
+			kinds:    "testkind,job",
+			pods:     []v1.Pod{foo, foo1, bar, baz},
+			expected: []v1.Pod{foo, foo1, bar},
+		},
+		{
+			name:     "3 pods with owner ref, different kind",
+			kinds:    "!testkind,job",
+			pods:     []v1.Pod{foo, foo1, bar, baz},
+			expected: []v1.Pod{},
+		},
+		{
+			name:     "3 pods with owner ref, different kind",
+			kinds:    "testkind,!job",
+			pods:     []v1.Pod{foo, foo1, bar, baz},
+			expected: []v1.Pod{foo, foo1, bar},
+		},
+		{
+			name:     "3 pods with owner ref, different kind",
+			kinds:    "job",
+			pods:     []v1.Pod{foo, foo1, bar, baz},
+			expected: []v1.Pod{},
+		},
+	} {
+		kindsSelector, err := ParseKinds(tt.kinds)
+		suite.Require().NoError(err)
+
+		results, err := filterByKinds(context.Background(), tt.pods, kindsSelector, nil)
+		suite.Require().Len(results, len(tt.expected))
+		suite.Require().NoError(err)
+
+		for i, result := range results {
+			suite.Assert().Equal(tt.expected[i], result, tt.name)
+		}
+	}
+}
+
+// TestFilterByQualifiedKinds tests that kinds can be qualified by their API version and match
+// the pods' top-level workloads.
+func (suite *Suite) TestFilterByQualifiedKinds() {
+	controller := true
+	newPod := func(name, apiVersion, kind, owner string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: owner, UID: types.UID(owner), Controller: &controller}}
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("web", "apps/v1", "ReplicaSet", "web-abc"),
+		newPod("custom", "example.com/v1", "Deployment", "custom"),
+		newPod("batch", "batch/v1", "Job", "batch"),
+		util.NewPod("default", "standalone", v1.PodRunning),
+	}
+
+	client := fake.NewSimpleClientset(
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            "web-abc",
+			UID:             "web-abc",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "web", Controller: &controller}},
+		}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", UID: "web"}},
+	)
+
+	for _, tt := range []struct {
+		name     string
+		kinds    string
+		expected []map[string]string
+	}{
+		{"plain kind matches any group", "Deployment", []map[string]string{
+			{"namespace": "default", "name": "web"},
+			{"namespace": "default", "name": "custom"},
+		}},
+		{"qualified kind matches its group only", "apps/v1:Deployment", []map[string]string{
+			{"namespace": "default", "name": "web"},
+		}},
+		{"direct owners still match", "apps/v1:ReplicaSet", []map[string]string{
+			{"namespace": "default", "name": "web"},
+		}},
+		{"excluding qualified kinds", "!apps/v1:Deployment,!batch/v1:Job", []map[string]string{
+			{"namespace": "default", "name": "custom"},
+			{"namespace": "default", "name": "standalone"},
+		}},
+	} {
+		kinds, err := ParseKinds(tt.kinds)
+		suite.Require().NoError(err, tt.name)
+
+		// filters work in place, so each case gets its own copy
+		results, err := filterByKinds(context.Background(), slices.Clone(pods), kinds, workload.NewResolver(client))
+		suite.Require().NoError(err, tt.name)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+// TestFilterByKindsResolution tests that workloads are only resolved if the direct owners can't
+// answer the kind selector and that failing resolutions only leave out the affected pods.
+func (suite *Suite) TestFilterByKindsResolution() {
+	controller := true
+	newPod := func(name, kind, owner string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: owner, UID: types.UID(owner), Controller: &controller}}
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("web", "ReplicaSet", "web-abc"),
+		newPod("db", "StatefulSet", "db"),
+	}
+
+	for _, tt := range []struct {
+		name     string
+		kinds    string
+		actions  int
+		failed   bool
+		expected []map[string]string
+	}{
+		{"direct owners include", "ReplicaSet,StatefulSet", 0, false, []map[string]string{
+			{"namespace": "default", "name": "web"},
+			{"namespace": "default", "name": "db"},
+		}},
+		{"direct owners exclude", "!ReplicaSet,!StatefulSet", 0, false, []map[string]string{}},
+		{"top-level kinds need resolution", "Deployment", 2, true, []map[string]string{}},
+		{"excluded pods aren't resolved", "!StatefulSet,!Deployment", 1, true, []map[string]string{}},
+	} {
+		client := fake.NewSimpleClientset()
+		client.PrependReactor("get", "replicasets", func(action ktesting.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("connection refused")
+		})
+
+		kinds, err := ParseKinds(tt.kinds)
+		suite.Require().NoError(err, tt.name)
+
+		results, err := filterByKinds(context.Background(), slices.Clone(pods), kinds, workload.NewResolver(client))
+		suite.Equal(tt.failed, err != nil, tt.name)
+		suite.Len(client.Actions(), tt.actions, tt.name)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+func (suite *Suite) TestFilterByCooldown() {
+	now := time.Unix(3600, 0)
+	foo := util.NewPodWithOwner("default", "foo", v1.PodRunning, "parent")
+	bar := util.NewPodWithOwner("default", "bar", v1.PodRunning, "other-parent")
+	baz := util.NewPod("default", "baz", v1.PodRunning)
+
+	for _, tt := range []struct {
+		name     string
+		last     map[string]time.Time
+		expected []v1.Pod
+	}{
+		{"no terminations", map[string]time.Time{}, []v1.Pod{foo, bar, baz}},
+		{"owner terminated recently", map[string]time.Time{"parent": now.Add(-10 * time.Minute)}, []v1.Pod{bar, baz}},
+		{"owner terminated long ago", map[string]time.Time{"parent": now.Add(-2 * time.Hour)}, []v1.Pod{foo, bar, baz}},
+		{"pod without owner terminated recently", map[string]time.Time{"default/baz": now.Add(-time.Minute)}, []v1.Pod{foo, bar}},
+	} {
+		results := filterByCooldown([]v1.Pod{foo, bar, baz}, tt.last, time.Hour, now)
+		suite.Equal(tt.expected, results, tt.name)
+	}
+}
+
+// TestCooldown tests that terminations are recorded and respected by the cooldown.
+func (suite *Suite) TestCooldown() {
+	chaoskube := suite.setupWithPods(
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		labels.Everything(),
+		nil,
+		nil,
+		[]time.Weekday{},
+		[]util.TimePeriod{},
+		[]time.Time{},
+		time.UTC,
+		time.Duration(0),
+		true,
+		10,
+		v1.NamespaceAll,
+	)
+	chaoskube.History = history.New(10)
+	chaoskube.Cooldown = time.Hour
+
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), util.NewPod("default", "foo", v1.PodRunning)))
+
+	entries := chaoskube.History.Entries()
+	suite.Require().Len(entries, 1)
+	suite.Equal("foo", entries[0].Name)
+	suite.True(entries[0].DryRun)
+
+	suite.assertCandidates(chaoskube, []map[string]string{
+		{"namespace": "testing", "name": "bar"},
+	})
+}
+
+// TestValidate tests that the configuration is checked against the cluster.
+func (suite *Suite) TestValidate() {
+	for _, tt := range []struct {
+		name       string
+		labels     string
+		namespaces string
+		scope      string
+		candidates int
+		errors     []string
+	}{
+		{"valid", "", "default", "", 1, nil},
+		{"missing namespace", "", "default,staging", "", 1, []string{"namespace staging doesn't exist"}},
+		{"missing excluded namespace", "", "!staging", "", 2, nil},
+		{"scoped client", "", "testing,other", "testing,other", 1, nil},
+		{"no candidates", "app=none", "", "", 0, []string{"no pods match the configured filters"}},
+		{"unsupported namespace selector", "", "default=foo", "", 0, []string{"unsupported operator: ="}},
+	} {
+		labelSelector, err := labels.Parse(tt.labels)
+		suite.Require().NoError(err)
+		namespaceSelector, err := labels.Parse(tt.namespaces)
+		suite.Require().NoError(err)
+
+		chaoskube := suite.setupWithPods(
+			labelSelector,
+			labels.Everything(),
+			labels.Everything(),
+			namespaceSelector,
+			labels.Everything(),
+			nil,
+			nil,
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			true,
+			10,
+			tt.scope,
+		)
+
+		candidates, err := chaoskube.Validate(context.Background())
+		suite.Len(candidates, tt.candidates, tt.name)
+
+		if tt.errors == nil {
+			suite.NoError(err, tt.name)
+			continue
+		}
+		suite.Require().Error(err, tt.name)
+		for _, expected := range tt.errors {
+			suite.Contains(err.Error(), expected, tt.name)
+		}
+	}
+}
+
+// TestIntervalStretch tests that repeatedly slow or throttled list calls stretch the interval.
+func (suite *Suite) TestIntervalStretch() {
+	chaoskube := &Chaoskube{
+		Logger:            logger,
+		BaseInterval:      time.Minute,
+		SlowListThreshold: time.Second,
+	}
+	throttled := apierrors.NewTooManyRequests("slow down", 1)
+
+	for _, tt := range []struct {
+		name     string
+		duration time.Duration
+		err      error
+		expected time.Duration
+	}{
+		{"fast list", 10 * time.Millisecond, nil, time.Minute},
+		{"first slow list", 2 * time.Second, nil, time.Minute},
+		{"second slow list", 2 * time.Second, nil, 2 * time.Minute},
+		{"throttled list", 10 * time.Millisecond, throttled, 4 * time.Minute},
+		{"third slow list", 2 * time.Second, nil, 8 * time.Minute},
+		{"capped", 2 * time.Second, nil, 8 * time.Minute},
+		{"recovering", 10 * time.Millisecond, nil, 4 * time.Minute},
+		{"first slow list again", 2 * time.Second, nil, 4 * time.Minute},
+		{"recovered", 10 * time.Millisecond, nil, 2 * time.Minute},
+	} {
+		chaoskube.observeList(tt.duration, tt.err)
+		suite.Equal(tt.expected, chaoskube.nextInterval(context.Background()), tt.name)
+	}
+}
+
+// TestScheduledInterval tests that a schedule takes precedence over the interval.
+func (suite *Suite) TestScheduledInterval() {
+	schedule, err := util.ParseSchedule("0 10,14 * * MON-FRI")
+	suite.Require().NoError(err)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	suite.Require().NoError(err)
+
+	chaoskube := &Chaoskube{
+		Logger:          logger,
+		BaseInterval:    time.Minute,
+		DynamicInterval: true,
+		Schedule:        schedule,
+		Timezone:        berlin,
+	}
+
+	for _, tt := range []struct {
+		now      time.Time
+		expected time.Duration
+	}{
+		{time.Date(2024, 6, 5, 9, 0, 0, 0, berlin), time.Hour},
+		{time.Date(2024, 6, 5, 10, 0, 0, 0, berlin), 4 * time.Hour},
+		{time.Date(2024, 6, 7, 14, 30, 0, 0, time.UTC), 2*24*time.Hour + 17*time.Hour + 30*time.Minute},
+	} {
+		chaoskube.Now = func() time.Time { return tt.now }
+		suite.Equal(tt.expected, chaoskube.nextInterval(context.Background()), tt.now.String())
+	}
+}
+
+// TestIntervalOverrides tests that the interval of the current weekday replaces the fixed interval.
+func (suite *Suite) TestIntervalOverrides() {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	suite.Require().NoError(err)
+
+	chaoskube := &Chaoskube{
+		Logger:       logger,
+		BaseInterval: time.Hour,
+		IntervalOverrides: map[time.Weekday]time.Duration{
+			time.Monday: 2 * time.Hour,
+			time.Friday: 4 * time.Hour,
+		},
+		Timezone: berlin,
+	}
+
+	for _, tt := range []struct {
+		now      time.Time
+		expected time.Duration
+	}{
+		{time.Date(2024, 6, 3, 12, 0, 0, 0, berlin), 2 * time.Hour},
+		{time.Date(2024, 6, 5, 12, 0, 0, 0, berlin), time.Hour},
+		{time.Date(2024, 6, 7, 12, 0, 0, 0, berlin), 4 * time.Hour},
+		// still Friday in UTC but already Saturday in Berlin
+		{time.Date(2024, 6, 7, 23, 0, 0, 0, time.UTC), time.Hour},
+	} {
+		chaoskube.Now = func() time.Time { return tt.now }
+		suite.Equal(tt.expected, chaoskube.nextInterval(context.Background()), tt.now.String())
+	}
+}
+
+// TestPoissonInterval tests that intervals are drawn at random around the configured interval.
+func (suite *Suite) TestPoissonInterval() {
+	chaoskube := &Chaoskube{
+		Logger:       logger,
+		BaseInterval: time.Minute,
+		Poisson:      true,
+		Rand:         util.NewRand(0),
+	}
+
+	const samples = 10000
+
+	var total time.Duration
+	distinct := map[time.Duration]bool{}
+	for i := 0; i < samples; i++ {
+		interval := chaoskube.nextInterval(context.Background())
+		suite.GreaterOrEqual(interval, time.Duration(0))
+		total += interval
+		distinct[interval] = true
+	}
+
+	suite.InDelta(time.Minute.Seconds(), (total / samples).Seconds(), 3)
+	suite.Greater(len(distinct), samples/2)
+}
+
+// TestPoissonIntervalRand tests that intervals are drawn from their own random source, leaving
+// the one used for selection untouched.
+func (suite *Suite) TestPoissonIntervalRand() {
+	chaoskube := &Chaoskube{
+		Logger:       logger,
+		BaseInterval: time.Minute,
+		Poisson:      true,
+		Rand:         util.NewRand(0),
+		IntervalRand: util.NewRand(1),
+	}
+
+	chaoskube.nextInterval(context.Background())
+
+	suite.Equal(util.NewRand(0).Int63(), chaoskube.Rand.Int63())
+}
+
+// TestFilterByNamespaceLevels tests that pods are thinned out by the chaos level of their namespace.
+func (suite *Suite) TestFilterByNamespaceLevels() {
+	namespace := func(name, level string) v1.Namespace {
+		namespace := util.NewNamespace(name)
+		if level != "" {
+			namespace.Annotations = map[string]string{levelAnnotation: level}
+		}
+		return namespace
+	}
+
+	lister := namespaceLister{
+		namespace("default", ""),
+		namespace("testing", "2"),
+		namespace("test", "0"),
+		namespace("invalid", "high"),
+		namespace("unused", "10"),
+	}
+
+	foo := util.NewPod("default", "foo", v1.PodRunning)
+	bar := util.NewPod("testing", "bar", v1.PodRunning)
+	baz := util.NewPod("test", "baz", v1.PodRunning)
+	qux := util.NewPod("invalid", "qux", v1.PodRunning)
+
+	rnd := util.NewRand(0)
+
+	// without a higher level, pods are kept unless their namespace is excluded
+	results, err := filterByNamespaceLevels(context.Background(), []v1.Pod{foo, baz, qux}, lister, rnd)
+	suite.Require().NoError(err)
+	suite.Equal([]v1.Pod{foo, qux}, results)
+
+	// pods in namespaces with half the highest level are kept about half of the time
+	kept := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		results, err := filterByNamespaceLevels(context.Background(), []v1.Pod{foo, bar, baz}, lister, rnd)
+		suite.Require().NoError(err)
+		for _, pod := range results {
+			kept[pod.Name]++
+		}
+	}
+	suite.Equal(1000, kept["bar"])
+	suite.InDelta(500, kept["foo"], 100)
+	suite.Zero(kept["baz"])
+}
+
+func (suite *Suite) TestFilterByProbability() {
+	pod := func(name, probability string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		if probability != "" {
+			pod.Annotations[probabilityAnnotation] = probability
+		}
+		return pod
+	}
+
+	foo := pod("foo", "")
+	bar := pod("bar", "0.2")
+	baz := pod("baz", "0")
+	qux := pod("qux", "often")
+
+	rnd := util.NewRand(0)
+
+	kept := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		for _, pod := range filterByProbability([]v1.Pod{foo, bar, baz, qux}, rnd) {
+			kept[pod.Name]++
+		}
+	}
+	suite.Equal(1000, kept["foo"])
+	suite.InDelta(200, kept["bar"], 50)
+	suite.Zero(kept["baz"])
+	suite.Equal(1000, kept["qux"])
+}
+
+func (suite *Suite) TestFilterByMaxLifetime() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	pod := func(name, maxLifetime string, age time.Duration) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		if maxLifetime != "" {
+			pod.Annotations[maxLifetimeAnnotation] = maxLifetime
+		}
+		return pod
+	}
+
+	pods := []v1.Pod{
+		pod("expired", "72h", 80*time.Hour),
+		pod("young", "72h", 10*time.Hour),
+		pod("unlimited", "", 800*time.Hour),
+		pod("invalid", "forever", 800*time.Hour),
+		pod("zero", "0s", 800*time.Hour),
+	}
+
+	results := filterByMaxLifetime(pods, now)
+	suite.AssertPods(results, []map[string]string{
+		{"namespace": "default", "name": "expired"},
+	})
+}
+
+func (suite *Suite) TestOldestPerOwner() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	pod := func(name string, owner types.UID, age time.Duration) v1.Pod {
+		pod := util.NewPodWithOwner("default", name, v1.PodRunning, owner)
+		pod.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		return pod
+	}
+
+	pods := []v1.Pod{
+		pod("foo-1", "foo", 1*time.Hour),
+		pod("foo-2", "foo", 3*time.Hour),
+		pod("foo-3", "foo", 2*time.Hour),
+		pod("bar-1", "bar", 4*time.Hour),
+		pod("baz", "", 5*time.Hour),
+		pod("qux", "", 30*time.Minute),
+	}
+
+	for _, tt := range []struct {
+		perOwner int
+		expected []string
+	}{
+		{1, []string{"baz", "bar-1", "foo-2", "qux"}},
+		{2, []string{"baz", "bar-1", "foo-2", "foo-3", "qux"}},
+	} {
+		names := []string{}
+		for _, pod := range oldestPerOwner(pods, tt.perOwner) {
+			names = append(names, pod.Name)
+		}
+		suite.Equal(tt.expected, names, "%d per owner", tt.perOwner)
+	}
+
+	// the given pods are left untouched
+	suite.Equal("foo-1", pods[0].Name)
+}
+
+// TestTerminateVictimsMaxLifetime tests that pods exceeding their maximum lifetime are terminated
+// instead of the random victims, but only if enabled.
+func (suite *Suite) TestTerminateVictimsMaxLifetime() {
+	for _, tt := range []struct {
+		enforce  bool
+		survivor string
+	}{
+		{false, ""},
+		{true, "bar"},
+	} {
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+		chaoskube.EnforceMaxLifetime = tt.enforce
+
+		// only foo expired, so it's the victim with enforcement and a random one without
+		expired, err := chaoskube.Client.CoreV1().Pods("default").Get(context.Background(), "foo", metav1.GetOptions{})
+		suite.Require().NoError(err)
+		expired.CreationTimestamp = metav1.NewTime(time.Now().Add(-100 * time.Hour))
+		expired.Annotations[maxLifetimeAnnotation] = "72h"
+		_, err = chaoskube.Client.CoreV1().Pods("default").Update(context.Background(), expired, metav1.UpdateOptions{})
+		suite.Require().NoError(err)
+
+		suite.Require().NoError(chaoskube.TerminateVictims(context.Background()))
+
+		pods, err := chaoskube.Candidates(context.Background())
+		suite.Require().NoError(err)
+		suite.Require().Len(pods, 1, "enforce: %v", tt.enforce)
+		if tt.survivor != "" {
+			suite.Equal(tt.survivor, pods[0].Name, "enforce: %v", tt.enforce)
+		}
+	}
+}
+
+// TestTerminateExpiredSafety tests that the maximum number of victims and the cooldown also apply
+// to pods exceeding their maximum lifetime.
+func (suite *Suite) TestTerminateExpiredSafety() {
+	for _, tt := range []struct {
+		name      string
+		maxKill   int
+		cooldown  time.Duration
+		remaining int
+	}{
+		{"no limits", 10, 0, 1},
+		{"max kill", 1, 0, 2},
+		{"cooldown", 10, time.Hour, 2},
+	} {
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+		chaoskube.MaxKill = tt.maxKill
+		chaoskube.History = history.New(10)
+		chaoskube.History.Add(history.Entry{Time: time.Now(), Namespace: "default", Name: "foo", Owner: "default/foo"})
+		chaoskube.Cooldown = tt.cooldown
+
+		for _, pod := range []struct{ namespace, name string }{{"default", "foo"}, {"testing", "bar"}} {
+			expired, err := chaoskube.Client.CoreV1().Pods(pod.namespace).Get(context.Background(), pod.name, metav1.GetOptions{})
+			suite.Require().NoError(err)
+			expired.CreationTimestamp = metav1.NewTime(time.Now().Add(-100 * time.Hour))
+			expired.Annotations[maxLifetimeAnnotation] = "72h"
+			_, err = chaoskube.Client.CoreV1().Pods(pod.namespace).Update(context.Background(), expired, metav1.UpdateOptions{})
+			suite.Require().NoError(err)
+		}
+
+		_, err := chaoskube.terminateExpired(context.Background())
+		suite.Require().NoError(err, tt.name)
+
+		// the pending pod never expires
+		pods, err := chaoskube.Client.CoreV1().Pods(v1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		suite.Require().NoError(err)
+		suite.Len(pods.Items, tt.remaining, tt.name)
+	}
+}
+
+// TestTerminateExpiredCountsTowardsMaxKill tests that expired pods and regular victims together
+// don't exceed the maximum number of victims per interval.
+func (suite *Suite) TestTerminateExpiredCountsTowardsMaxKill() {
+	for _, tt := range []struct {
+		name      string
+		maxKill   int
+		remaining int
+	}{
+		{"expired only", 1, 2},
+		{"expired and regular", 2, 1},
+	} {
+		chaoskube := suite.setupWithPods(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			v1.NamespaceAll,
+		)
+		chaoskube.MaxKill = tt.maxKill
+		chaoskube.EnforceMaxLifetime = true
+
+		expired, err := chaoskube.Client.CoreV1().Pods("default").Get(context.Background(), "foo", metav1.GetOptions{})
+		suite.Require().NoError(err)
+		expired.CreationTimestamp = metav1.NewTime(time.Now().Add(-100 * time.Hour))
+		expired.Annotations[maxLifetimeAnnotation] = "72h"
+		_, err = chaoskube.Client.CoreV1().Pods("default").Update(context.Background(), expired, metav1.UpdateOptions{})
+		suite.Require().NoError(err)
+
+		suite.Require().NoError(chaoskube.TerminateVictims(context.Background()), tt.name)
+
+		pods, err := chaoskube.Client.CoreV1().Pods(v1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		suite.Require().NoError(err)
+		suite.Len(pods.Items, tt.remaining, tt.name)
+		for _, pod := range pods.Items {
+			suite.NotEqual("foo", pod.Name, tt.name)
+		}
+	}
+}
+
+// TestFilterBySafetyWorkloadIntervals tests that expired pods respect the minimum interval of
+// their workloads, too.
+func (suite *Suite) TestFilterBySafetyWorkloadIntervals() {
+	controller := true
+	pod := util.NewPod("default", "foo", v1.PodRunning)
+	pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "foo", UID: "foo", Controller: &controller}}
+
+	client := fake.NewSimpleClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        "foo",
+		UID:         "foo",
+		Annotations: map[string]string{minIntervalAnnotation: "24h"},
+	}})
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	chaoskube := &Chaoskube{
+		Client:            client,
+		Logger:            logger,
+		Now:               func() time.Time { return now },
+		History:           history.New(10),
+		WorkloadIntervals: true,
+	}
+	chaoskube.History.Add(history.Entry{Time: now.Add(-time.Hour), Namespace: "default", Name: "bar", Workload: "foo"})
+
+	pods, err := chaoskube.filterBySafety(context.Background(), []v1.Pod{pod})
+	suite.Require().NoError(err)
+	suite.Empty(pods)
+}
+
+func (suite *Suite) TestFilterByOptIn() {
+	namespace := func(name, enabled string) v1.Namespace {
+		namespace := util.NewNamespace(name)
+		if enabled != "" {
+			namespace.Annotations = map[string]string{enabledAnnotation: enabled}
+		}
+		return namespace
+	}
+	pod := func(namespace, name, enabled string) v1.Pod {
+		pod := util.NewPod(namespace, name, v1.PodRunning)
+		if enabled != "" {
+			pod.Annotations[enabledAnnotation] = enabled
+		}
+		return pod
+	}
+
+	lister := namespaceLister{
+		namespace("default", ""),
+		namespace("opted-in", "true"),
+		namespace("opted-out", "false"),
+	}
+
+	pods := []v1.Pod{
+		pod("default", "foo", ""),
+		pod("default", "bar", "false"),
+		pod("opted-in", "baz", ""),
+		pod("opted-out", "qux", ""),
+		pod("opted-out", "quux", "true"),
+	}
+
+	for _, tt := range []struct {
+		defaultOptOut bool
+		expected      []map[string]string
+	}{
+		{false, []map[string]string{
+			{"namespace": "default", "name": "foo"},
+			{"namespace": "opted-in", "name": "baz"},
+			{"namespace": "opted-out", "name": "quux"},
+		}},
+		{true, []map[string]string{
+			{"namespace": "opted-in", "name": "baz"},
+			{"namespace": "opted-out", "name": "quux"},
+		}},
+	} {
+		results, err := filterByOptIn(context.Background(), append([]v1.Pod{}, pods...), lister, tt.defaultOptOut)
+		suite.Require().NoError(err)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+func (suite *Suite) TestFilterByOwnerReference() {
+	foo := util.NewPodWithOwner("default", "foo", v1.PodRunning, "parent")
+	foo1 := util.NewPodWithOwner("default", "foo-1", v1.PodRunning, "parent")
+	bar := util.NewPodWithOwner("default", "bar", v1.PodRunning, "other-parent")
+	baz := util.NewPod("default", "baz", v1.PodRunning)
+	baz1 := util.NewPod("default", "baz-1", v1.PodRunning)
+
+	for _, tt := range []struct {
+		seed     int64
+		name     string
+		pods     []v1.Pod
+		expected []v1.Pod
+	}{
+		{
+			seed:     1000,
+			name:     "2 pods, same parent, pick first",
+			pods:     []v1.Pod{foo, foo1},
+			expected: []v1.Pod{foo},
+		},
+		{
 			seed:     2000,
 			name:     "2 pods, same parent, pick second",
 			pods:     []v1.Pod{foo, foo1},
@@ -1265,45 +3504,1238 @@ func (suite *Suite) TestFilterByOwnerReference() {
 			expected: []v1.Pod{baz, baz1},
 		},
 	} {
-		rand.Seed(tt.seed)
+		results := filterByOwnerReference(tt.pods, 1, false, util.NewRand(tt.seed))
+		suite.Require().Len(results, len(tt.expected))
+
+		// ensure returned pods are ordered by name
+		// to make the following assertion work correctly
+		sort.Slice(results, func(i, j int) bool {
+			return results[i].Name < results[j].Name
+		})
+		for i, result := range results {
+			suite.Assert().Equal(tt.expected[i], result, tt.name)
+		}
+	}
+}
+
+// TestFilterByOwnerReferencePerOwner tests that more than one pod per owner can be kept.
+func (suite *Suite) TestFilterByOwnerReferencePerOwner() {
+	foo := util.NewPodWithOwner("default", "foo", v1.PodRunning, "parent")
+	foo1 := util.NewPodWithOwner("default", "foo-1", v1.PodRunning, "parent")
+	foo2 := util.NewPodWithOwner("default", "foo-2", v1.PodRunning, "parent")
+	bar := util.NewPodWithOwner("default", "bar", v1.PodRunning, "other-parent")
+
+	kept := map[string]int{}
+	for _, pod := range filterByOwnerReference([]v1.Pod{foo, foo1, foo2, bar}, 2, false, util.NewRand(0)) {
+		kept[string(pod.OwnerReferences[0].UID)]++
+	}
+	suite.Equal(map[string]int{"parent": 2, "other-parent": 1}, kept)
+}
+
+// TestFilterByOwnerReferenceDeletionCost tests that the pods with the lowest deletion cost are kept.
+func (suite *Suite) TestFilterByOwnerReferenceDeletionCost() {
+	newPod := func(name, cost string) v1.Pod {
+		pod := util.NewPodWithOwner("default", name, v1.PodRunning, "parent")
+		if cost != "" {
+			pod.Annotations["controller.kubernetes.io/pod-deletion-cost"] = cost
+		}
+		return pod
+	}
+
+	pods := []v1.Pod{newPod("foo", "100"), newPod("bar", "-10"), newPod("baz", ""), newPod("qux", "")}
+
+	rnd := util.NewRand(0)
+	kept := map[string]int{}
+	for i := 0; i < 100; i++ {
+		for _, pod := range filterByOwnerReference(append([]v1.Pod{}, pods...), 2, true, rnd) {
+			kept[pod.Name]++
+		}
+	}
+
+	// the cheapest pod is always kept, the others of the default cost at random
+	suite.Equal(100, kept["bar"])
+	suite.Equal(100, kept["baz"]+kept["qux"])
+	suite.NotZero(kept["baz"])
+	suite.NotZero(kept["qux"])
+	suite.Zero(kept["foo"])
+}
+
+func (suite *Suite) TestFilterPods() {
+	foo := util.NewPod("default", "foo", v1.PodRunning)
+	bar := util.NewPod("default", "bar", v1.PodPending)
+	baz := util.NewPod("default", "baz", v1.PodRunning)
+
+	pods := []v1.Pod{foo, bar, baz}
+	filtered := filterPods(pods, func(pod *v1.Pod) bool {
+		return pod.Status.Phase == v1.PodRunning
+	})
+
+	suite.Equal([]v1.Pod{foo, baz}, filtered)
+
+	// the pods are filtered in place and dropped pods are released
+	suite.Same(&pods[0], &filtered[0])
+	suite.Equal(v1.Pod{}, pods[2])
+}
+
+func (suite *Suite) TestFilterByAnyLabels() {
+	foo := util.NewPod("default", "foo", v1.PodRunning)
+	bar := util.NewPod("default", "bar", v1.PodRunning)
+	baz := util.NewPod("testing", "baz", v1.PodRunning)
+
+	for _, tt := range []struct {
+		selectors []string
+		expected  []v1.Pod
+	}{
+		{[]string{"app=foo"}, []v1.Pod{foo}},
+		{[]string{"app=foo", "app=baz"}, []v1.Pod{foo, baz}},
+		{[]string{"app in (foo,bar)", "app=bar"}, []v1.Pod{foo, bar}},
+		{[]string{"app=qux"}, []v1.Pod{}},
+	} {
+		selectors := []labels.Selector{}
+		for _, selector := range tt.selectors {
+			parsed, err := labels.Parse(selector)
+			suite.Require().NoError(err)
+			selectors = append(selectors, parsed)
+		}
+
+		suite.Equal(tt.expected, filterByAnyLabels([]v1.Pod{foo, bar, baz}, selectors), tt.selectors)
+	}
+}
+
+func (suite *Suite) TestFilterByAnnotationPatterns() {
+	newPod := func(name string, annotations map[string]string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Annotations = annotations
+		return pod
+	}
+
+	foo := newPod("foo", map[string]string{"owner-team": "payments-eu", "tier": "backend"})
+	bar := newPod("bar", map[string]string{"owner-team": "payments-us", "tier": "frontend"})
+	baz := newPod("baz", map[string]string{"owner-team": "search"})
+	qux := newPod("qux", nil)
+
+	for _, tt := range []struct {
+		patterns map[string]string
+		expected []v1.Pod
+	}{
+		{map[string]string{"owner-team": "^payments-.*"}, []v1.Pod{foo, bar}},
+		{map[string]string{"owner-team": "^payments-.*", "tier": "^back"}, []v1.Pod{foo}},
+		{map[string]string{"owner-team": ".*"}, []v1.Pod{foo, bar, baz}},
+		{map[string]string{"owner-team": "^marketing$"}, []v1.Pod{}},
+	} {
+		patterns := map[string]*regexp.Regexp{}
+		for key, pattern := range tt.patterns {
+			patterns[key] = regexp.MustCompile(pattern)
+		}
+
+		suite.Equal(tt.expected, filterByAnnotationPatterns([]v1.Pod{foo, bar, baz, qux}, patterns), tt.patterns)
+	}
+}
+
+func (suite *Suite) TestFilterStaticPods() {
+	// Regular pod without mirror annotation
+	regularPod := util.NewPod("default", "regular", v1.PodRunning)
+
+	// Static pod with mirror annotation
+	staticPod := util.NewPod("default", "static", v1.PodRunning)
+	staticPod.Annotations["kubernetes.io/config.mirror"] = "node-1"
+
+	// Another regular pod
+	anotherRegularPod := util.NewPod("default", "another-regular", v1.PodRunning)
+
+	// Another static pod with mirror annotation
+	anotherStaticPod := util.NewPod("default", "another-static", v1.PodRunning)
+	anotherStaticPod.Annotations["kubernetes.io/config.mirror"] = "node-2"
+
+	pods := []v1.Pod{regularPod, staticPod, anotherRegularPod, anotherStaticPod}
+
+	filtered := filterStaticPods(pods)
+
+	// Should only have the 2 regular pods, static pods should be filtered out
+	suite.Equal(2, len(filtered))
+	suite.Equal("regular", filtered[0].Name)
+	suite.Equal("another-regular", filtered[1].Name)
+}
+
+func (suite *Suite) TestFilterByHelmRelease() {
+	newPod := func(namespace, name, release, chart string) v1.Pod {
+		pod := util.NewPod(namespace, name, v1.PodRunning)
+		pod.Labels["app.kubernetes.io/instance"] = release
+		pod.Labels["helm.sh/chart"] = chart
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("default", "foo", "shop", "nginx-1.2.3"),
+		newPod("testing", "bar", "shop", "redis-17.0.1-rc.1"),
+		newPod("default", "baz", "blog", "nginx-1.2.4"),
+		newPod("default", "qux", "blog", "nginx-2fa-0.1.0"),
+	}
+
+	for _, tt := range []struct {
+		release  string
+		chart    string
+		expected []map[string]string
+	}{
+		{"shop", "", []map[string]string{
+			{"namespace": "default", "name": "foo"},
+			{"namespace": "testing", "name": "bar"},
+		}},
+		{"", "nginx", []map[string]string{
+			{"namespace": "default", "name": "foo"},
+			{"namespace": "default", "name": "baz"},
+		}},
+		{"blog", "nginx-2fa", []map[string]string{
+			{"namespace": "default", "name": "qux"},
+		}},
+	} {
+		results := filterByHelmRelease(append([]v1.Pod{}, pods...), tt.release, tt.chart)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+func (suite *Suite) TestFilterByDisruptionAnnotations() {
+	newPod := func(name string, annotations map[string]string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Annotations = annotations
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("foo", map[string]string{"cluster-autoscaler.kubernetes.io/safe-to-evict": "false"}),
+		newPod("bar", map[string]string{"cluster-autoscaler.kubernetes.io/safe-to-evict": "true"}),
+		newPod("baz", map[string]string{"karpenter.sh/do-not-disrupt": "true"}),
+		newPod("qux", nil),
+	}
+
+	suite.AssertPods(filterByDisruptionAnnotations(pods), []map[string]string{
+		{"namespace": "default", "name": "bar"},
+		{"namespace": "default", "name": "qux"},
+	})
+}
+
+// TestCandidatesDisruptionAnnotationsBeforeOwners tests that pods protected by a disruption
+// annotation are filtered out before the candidates are reduced per owner, so that their owner
+// keeps a candidate.
+func (suite *Suite) TestCandidatesDisruptionAnnotationsBeforeOwners() {
+	for seed := int64(0); seed < 10; seed++ {
+		chaoskube := suite.setup(
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			labels.Everything(),
+			&regexp.Regexp{},
+			&regexp.Regexp{},
+			[]time.Weekday{},
+			[]util.TimePeriod{},
+			[]time.Time{},
+			time.UTC,
+			time.Duration(0),
+			false,
+			10,
+			1,
+			v1.NamespaceAll,
+		)
+		chaoskube.Rand = util.NewRand(seed)
+
+		protected := util.NewPodWithOwner("default", "protected", v1.PodRunning, "owner")
+		protected.Annotations["karpenter.sh/do-not-disrupt"] = "true"
+		for _, pod := range []v1.Pod{protected, util.NewPodWithOwner("default", "unprotected", v1.PodRunning, "owner")} {
+			_, err := chaoskube.Client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+			suite.Require().NoError(err)
+		}
+
+		pods, err := chaoskube.Candidates(context.Background())
+		suite.Require().NoError(err)
+		suite.AssertPods(pods, []map[string]string{{"namespace": "default", "name": "unprotected"}})
+	}
+}
+
+func (suite *Suite) TestFilterDaemonSetPods() {
+	controller := true
+	newPod := func(name, kind string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, Controller: &controller}}
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("foo", "DaemonSet"),
+		newPod("bar", "ReplicaSet"),
+		util.NewPod("default", "baz", v1.PodRunning),
+	}
+
+	suite.AssertPods(filterDaemonSetPods(pods), []map[string]string{
+		{"namespace": "default", "name": "bar"},
+		{"namespace": "default", "name": "baz"},
+	})
+}
+
+func (suite *Suite) TestFilterByArgoRollouts() {
+	newRolloutPod := func(name, hash string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Labels = map[string]string{"app": "checkout", rolloutsPodTemplateHashLabel: hash}
+		return pod
+	}
+
+	stable := newRolloutPod("stable", "aaa")
+	canary := newRolloutPod("canary", "bbb")
+	other := util.NewPod("default", "other", v1.PodRunning)
+
+	newRollout := func(stableRS, currentPodHash string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "Rollout",
+			"metadata":   map[string]interface{}{"namespace": "default", "name": "checkout"},
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"app": "checkout"}},
+			},
+			"status": map[string]interface{}{"stableRS": stableRS, "currentPodHash": currentPodHash},
+		}}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		mode     string
+		rollout  *unstructured.Unstructured
+		expected []v1.Pod
+	}{
+		{
+			name:     "skip canary pods during a rollout",
+			mode:     ArgoRolloutsSkipCanary,
+			rollout:  newRollout("aaa", "bbb"),
+			expected: []v1.Pod{stable, other},
+		},
+		{
+			name:     "keep all pods when no rollout is in progress",
+			mode:     ArgoRolloutsSkipCanary,
+			rollout:  newRollout("bbb", "bbb"),
+			expected: []v1.Pod{stable, canary, other},
+		},
+		{
+			name:     "only stable pods when no rollout is in progress",
+			mode:     ArgoRolloutsStableOnly,
+			rollout:  newRollout("bbb", "bbb"),
+			expected: []v1.Pod{canary, other},
+		},
+		{
+			name:     "only stable pods during a rollout",
+			mode:     ArgoRolloutsStableOnly,
+			rollout:  newRollout("aaa", "bbb"),
+			expected: []v1.Pod{stable, other},
+		},
+	} {
+		client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			rolloutsResource: "RolloutList",
+		}, tt.rollout)
+
+		results, err := filterByArgoRollouts(context.Background(), []v1.Pod{stable, canary, other}, tt.mode, client, []string{v1.NamespaceAll})
+		suite.Require().NoError(err)
+
+		suite.Equal(tt.expected, results, tt.name)
+	}
+}
+
+// TestFilterBySnooze tests that pods are skipped while they, their owner or their namespace are snoozed.
+func (suite *Suite) TestFilterBySnooze() {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	future := now.Add(time.Hour).Format(time.RFC3339)
+	past := now.Add(-time.Hour).Format(time.RFC3339)
+
+	controller := true
+	newPod := func(namespace, name, deployment, snooze string) v1.Pod {
+		pod := util.NewPod(namespace, name, v1.PodRunning)
+		if deployment != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment, UID: types.UID(deployment), Controller: &controller}}
+		}
+		if snooze != "" {
+			pod.Annotations = map[string]string{snoozeAnnotation: snooze}
+		}
+		return pod
+	}
+	newDeployment := func(name, snooze string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        name,
+			UID:         types.UID(name),
+			Annotations: map[string]string{snoozeAnnotation: snooze},
+		}}
+	}
+	newNamespace := func(name, snooze string) v1.Namespace {
+		namespace := util.NewNamespace(name)
+		namespace.Annotations = map[string]string{snoozeAnnotation: snooze}
+		return namespace
+	}
+
+	pods := []v1.Pod{
+		newPod("default", "plain", "", ""),
+		newPod("default", "snoozed", "", future),
+		newPod("default", "woken", "", past),
+		newPod("default", "invalid", "", "tomorrow"),
+		newPod("default", "owner-snoozed", "snoozed", ""),
+		newPod("default", "owner-woken", "woken", ""),
+		newPod("testing", "namespace-snoozed", "", ""),
+		newPod("test", "namespace-woken", "", ""),
+	}
+
+	client := fake.NewSimpleClientset(
+		newDeployment("snoozed", future),
+		newDeployment("woken", past),
+	)
+	lister := namespaceLister{
+		newNamespace("testing", future),
+		newNamespace("test", past),
+	}
+
+	results, err := filterBySnooze(context.Background(), pods, lister, workload.NewResolver(client), now)
+	suite.Require().NoError(err)
+
+	suite.AssertPods(results, []map[string]string{
+		{"namespace": "default", "name": "plain"},
+		{"namespace": "default", "name": "woken"},
+		{"namespace": "default", "name": "invalid"},
+		{"namespace": "default", "name": "owner-woken"},
+		{"namespace": "test", "name": "namespace-woken"},
+	})
+}
+
+// TestFilterByWorkloadIntervals tests that workloads aren't disrupted more often than their annotation allows.
+func (suite *Suite) TestFilterByWorkloadIntervals() {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	controller := true
+	newPod := func(name, deployment string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment, UID: types.UID(deployment), Controller: &controller}}
+		return pod
+	}
+	newDeployment := func(name, interval string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        name,
+			UID:         types.UID(name),
+			Annotations: map[string]string{minIntervalAnnotation: interval},
+		}}
+	}
+
+	pods := []v1.Pod{
+		newPod("recent", "recent"),
+		newPod("long-ago", "long-ago"),
+		newPod("never", "never"),
+		newPod("invalid", "invalid"),
+		newPod("unannotated", "unannotated"),
+		util.NewPod("default", "standalone", v1.PodRunning),
+	}
+
+	client := fake.NewSimpleClientset(
+		newDeployment("recent", "24h"),
+		newDeployment("long-ago", "24h"),
+		newDeployment("never", "24h"),
+		newDeployment("invalid", "daily"),
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unannotated", UID: "unannotated"}},
+	)
+	lastTerminations := map[string]time.Time{
+		"recent":      now.Add(-time.Hour),
+		"long-ago":    now.Add(-48 * time.Hour),
+		"invalid":     now.Add(-time.Hour),
+		"unannotated": now.Add(-time.Hour),
+	}
+
+	results, err := filterByWorkloadIntervals(context.Background(), pods, workload.NewResolver(client), lastTerminations, now)
+	suite.Require().NoError(err)
+
+	suite.AssertPods(results, []map[string]string{
+		{"namespace": "default", "name": "long-ago"},
+		{"namespace": "default", "name": "never"},
+		{"namespace": "default", "name": "invalid"},
+		{"namespace": "default", "name": "unannotated"},
+		{"namespace": "default", "name": "standalone"},
+	})
+
+	// terminations are recorded with their workload
+	chaoskube := &Chaoskube{
+		Client:            client,
+		Logger:            logger,
+		DryRun:            true,
+		Now:               func() time.Time { return now },
+		History:           history.New(10),
+		WorkloadIntervals: true,
+	}
+	suite.Require().NoError(chaoskube.DeletePod(context.Background(), newPod("recent", "recent")))
+	suite.Equal(map[string]time.Time{"recent": now}, chaoskube.History.LastWorkloadTerminations())
+}
+
+// TestFilterByOwnerReadiness tests that pods of workloads with too few ready replicas are skipped.
+func (suite *Suite) TestFilterByOwnerReadiness() {
+	controller := true
+	newPod := func(name, deployment string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment, UID: types.UID(deployment), Controller: &controller}}
+		return pod
+	}
+	newDeployment := func(name string, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(name)},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+		}
+	}
+
+	pods := []v1.Pod{
+		newPod("healthy", "healthy"),
+		newPod("degraded", "degraded"),
+		newPod("exact", "exact"),
+		util.NewPod("default", "standalone", v1.PodRunning),
+	}
+
+	client := fake.NewSimpleClientset(
+		newDeployment("healthy", 3),
+		newDeployment("degraded", 1),
+		newDeployment("exact", 2),
+	)
+
+	results, err := filterByOwnerReadiness(context.Background(), pods, workload.NewResolver(client), 2)
+	suite.Require().NoError(err)
+	suite.AssertPods(results, []map[string]string{
+		{"namespace": "default", "name": "healthy"},
+		{"namespace": "default", "name": "exact"},
+		{"namespace": "default", "name": "standalone"},
+	})
+}
+
+// TestFilterByPVCs tests that pods mounting PersistentVolumeClaims, of certain access modes, are skipped.
+func (suite *Suite) TestFilterByPVCs() {
+	newPod := func(name string, volumes ...v1.Volume) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.Volumes = volumes
+		return pod
+	}
+	claim := func(name string) v1.Volume {
+		return v1.Volume{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: name}}}
+	}
+	newClaim := func(name string, mode v1.PersistentVolumeAccessMode) *v1.PersistentVolumeClaim {
+		return &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       v1.PersistentVolumeClaimSpec{AccessModes: []v1.PersistentVolumeAccessMode{mode}},
+		}
+	}
+
+	pods := []v1.Pod{
+		newPod("foo", claim("foo-data")),
+		newPod("bar", claim("shared")),
+		newPod("baz", v1.Volume{Name: "scratch", VolumeSource: v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{}}}),
+		newPod("qux", v1.Volume{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{}}}),
+	}
+
+	client := fake.NewSimpleClientset(
+		newClaim("foo-data", v1.ReadWriteOnce),
+		newClaim("shared", v1.ReadWriteMany),
+		newClaim("baz-scratch", v1.ReadWriteOnce),
+	)
+
+	for _, tt := range []struct {
+		accessModes []v1.PersistentVolumeAccessMode
+		expected    []map[string]string
+	}{
+		{nil, []map[string]string{
+			{"namespace": "default", "name": "qux"},
+		}},
+		{[]v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, []map[string]string{
+			{"namespace": "default", "name": "bar"},
+			{"namespace": "default", "name": "qux"},
+		}},
+	} {
+		results, err := filterByPVCs(context.Background(), append([]v1.Pod{}, pods...), client, tt.accessModes, []string{v1.NamespaceAll})
+		suite.Require().NoError(err)
+		suite.AssertPods(results, tt.expected)
+	}
+}
 
-		results := filterByOwnerReference(tt.pods)
-		suite.Require().Len(results, len(tt.expected))
+// TestFilterBySoleEndpoints tests that pods are skipped while they're the only ready endpoint of a Service.
+func (suite *Suite) TestFilterBySoleEndpoints() {
+	ready, notReady := true, false
+	newSlice := func(name, service string, endpoints map[string]*bool) *discoveryv1.EndpointSlice {
+		slice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+		}
+		for pod, ready := range endpoints {
+			slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+				Conditions: discoveryv1.EndpointConditions{Ready: ready},
+				TargetRef:  &v1.ObjectReference{Kind: "Pod", Namespace: "default", Name: pod},
+			})
+		}
+		return slice
+	}
 
-		// ensure returned pods are ordered by name
-		// to make the following assertion work correctly
-		sort.Slice(results, func(i, j int) bool {
-			return results[i].Name < results[j].Name
-		})
-		for i, result := range results {
-			suite.Assert().Equal(tt.expected[i], result, tt.name)
+	client := fake.NewSimpleClientset(
+		// a singleton service
+		newSlice("single-abc", "single", map[string]*bool{"single": &ready}),
+		// a service with two ready endpoints, spread over two slices
+		newSlice("replicated-abc", "replicated", map[string]*bool{"foo": &ready}),
+		newSlice("replicated-def", "replicated", map[string]*bool{"bar": nil}),
+		// a service whose other endpoint isn't ready
+		newSlice("degraded-abc", "degraded", map[string]*bool{"baz": &ready, "qux": &notReady}),
+	)
+
+	pods := []v1.Pod{
+		util.NewPod("default", "single", v1.PodRunning),
+		util.NewPod("default", "foo", v1.PodRunning),
+		util.NewPod("default", "bar", v1.PodRunning),
+		util.NewPod("default", "baz", v1.PodRunning),
+		util.NewPod("default", "qux", v1.PodRunning),
+		util.NewPod("default", "standalone", v1.PodRunning),
+	}
+
+	results, err := filterBySoleEndpoints(context.Background(), pods, client, []string{v1.NamespaceAll})
+	suite.Require().NoError(err)
+	suite.AssertPods(results, []map[string]string{
+		{"namespace": "default", "name": "foo"},
+		{"namespace": "default", "name": "bar"},
+		{"namespace": "default", "name": "qux"},
+		{"namespace": "default", "name": "standalone"},
+	})
+}
+
+func (suite *Suite) TestFilterByRollouts() {
+	controller := true
+	replicas := int32(2)
+	newPod := func(name, deployment string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment, UID: types.UID(deployment), Controller: &controller}}
+		return pod
+	}
+	newDeployment := func(name string, updated int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(name)},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: updated, AvailableReplicas: 2},
 		}
 	}
+
+	pods := []v1.Pod{
+		newPod("stable", "stable"),
+		newPod("rolling", "rolling"),
+		util.NewPod("default", "standalone", v1.PodRunning),
+	}
+
+	client := fake.NewSimpleClientset(
+		newDeployment("stable", 2),
+		newDeployment("rolling", 1),
+	)
+
+	results, err := filterByRollouts(context.Background(), pods, workload.NewResolver(client))
+	suite.Require().NoError(err)
+	suite.AssertPods(results, []map[string]string{
+		{"namespace": "default", "name": "stable"},
+		{"namespace": "default", "name": "standalone"},
+	})
 }
 
-func (suite *Suite) TestFilterStaticPods() {
-	// Regular pod without mirror annotation
-	regularPod := util.NewPod("default", "regular", v1.PodRunning)
+// TestFilterByZoneCoverage tests that owners keep at least one pod in each zone they cover.
+func (suite *Suite) TestFilterByZoneCoverage() {
+	controller := true
+	newPod := func(name, owner, node string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.NodeName = node
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner, UID: types.UID(owner), Controller: &controller}}
+		}
+		return pod
+	}
 
-	// Static pod with mirror annotation
-	staticPod := util.NewPod("default", "static", v1.PodRunning)
-	staticPod.Annotations["kubernetes.io/config.mirror"] = "node-1"
+	zones := map[string]string{"node-a1": "a", "node-a2": "a", "node-b1": "b"}
 
-	// Another regular pod
-	anotherRegularPod := util.NewPod("default", "another-regular", v1.PodRunning)
+	pods := []v1.Pod{
+		// two pods in zone a, one in zone b
+		newPod("foo-1", "foo", "node-a1"),
+		newPod("foo-2", "foo", "node-a2"),
+		newPod("foo-3", "foo", "node-b1"),
+		// a single pod, which covers its zone alone
+		newPod("bar-1", "bar", "node-a1"),
+		// pods without owner or zone are kept
+		newPod("baz", "", "node-a1"),
+		newPod("qux-1", "qux", "unknown"),
+	}
+	coverage := zoneCoverage(pods, zones)
 
-	// Another static pod with mirror annotation
-	anotherStaticPod := util.NewPod("default", "another-static", v1.PodRunning)
-	anotherStaticPod.Annotations["kubernetes.io/config.mirror"] = "node-2"
+	rnd := util.NewRand(0)
+	kept := map[string]int{}
+	for i := 0; i < 100; i++ {
+		for _, pod := range filterByZoneCoverage(append([]v1.Pod{}, pods...), zones, coverage, rnd) {
+			kept[pod.Name]++
+		}
+	}
 
-	pods := []v1.Pod{regularPod, staticPod, anotherRegularPod, anotherStaticPod}
+	// exactly one of the pods in zone a is kept each time, at random
+	suite.Equal(100, kept["foo-1"]+kept["foo-2"])
+	suite.NotZero(kept["foo-1"])
+	suite.NotZero(kept["foo-2"])
+	suite.Zero(kept["foo-3"])
+	suite.Zero(kept["bar-1"])
+	suite.Equal(100, kept["baz"])
+	suite.Equal(100, kept["qux-1"])
+}
 
-	filtered := filterStaticPods(pods)
+// TestFilterByVirtualNodes tests that pods on virtual nodes are excluded or exclusively targeted.
+func (suite *Suite) TestFilterByVirtualNodes() {
+	newPod := func(name, node string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.NodeName = node
+		return pod
+	}
+	newNode := func(name string, labels map[string]string, taints ...v1.Taint) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       v1.NodeSpec{Taints: taints},
+		}
+	}
 
-	// Should only have the 2 regular pods, static pods should be filtered out
-	suite.Equal(2, len(filtered))
-	suite.Equal("regular", filtered[0].Name)
-	suite.Equal("another-regular", filtered[1].Name)
+	client := fake.NewSimpleClientset(
+		newNode("regular", nil),
+		newNode("virtual-kubelet", map[string]string{"type": "virtual-kubelet"}),
+		newNode("tainted", nil, v1.Taint{Key: "virtual-kubelet.io/provider", Value: "azure", Effect: v1.TaintEffectNoSchedule}),
+		newNode("fargate", map[string]string{"eks.amazonaws.com/compute-type": "fargate"}),
+	)
+
+	pods := []v1.Pod{
+		newPod("foo", "regular"),
+		newPod("bar", "virtual-kubelet"),
+		newPod("baz", "tainted"),
+		newPod("qux", "fargate"),
+	}
+
+	for _, tt := range []struct {
+		mode     string
+		expected []map[string]string
+	}{
+		{VirtualNodesExclude, []map[string]string{{"namespace": "default", "name": "foo"}}},
+		{VirtualNodesOnly, []map[string]string{
+			{"namespace": "default", "name": "bar"},
+			{"namespace": "default", "name": "baz"},
+			{"namespace": "default", "name": "qux"},
+		}},
+	} {
+		results, err := filterByVirtualNodes(context.Background(), append([]v1.Pod{}, pods...), tt.mode, client)
+		suite.Require().NoError(err)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+// TestFilterByQOSClasses tests that pods are included or excluded by their QoS class.
+func (suite *Suite) TestFilterByQOSClasses() {
+	newPod := func(name string, class v1.PodQOSClass) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Status.QOSClass = class
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("foo", v1.PodQOSGuaranteed),
+		newPod("bar", v1.PodQOSBurstable),
+		newPod("baz", v1.PodQOSBestEffort),
+		newPod("unknown", ""),
+	}
+
+	for _, tt := range []struct {
+		classes  string
+		expected []map[string]string
+	}{
+		{"burstable,besteffort", []map[string]string{
+			{"namespace": "default", "name": "bar"},
+			{"namespace": "default", "name": "baz"},
+		}},
+		{"!guaranteed", []map[string]string{
+			{"namespace": "default", "name": "bar"},
+			{"namespace": "default", "name": "baz"},
+			{"namespace": "default", "name": "unknown"},
+		}},
+		{"guaranteed", []map[string]string{
+			{"namespace": "default", "name": "foo"},
+		}},
+	} {
+		selector, err := labels.Parse(tt.classes)
+		suite.Require().NoError(err)
+
+		results, err := filterByQOSClasses(append([]v1.Pod{}, pods...), selector)
+		suite.Require().NoError(err)
+		suite.AssertPods(results, tt.expected)
+	}
+
+	// only including and excluding classes are supported
+	selector, err := labels.Parse("guaranteed=true")
+	suite.Require().NoError(err)
+	_, err = filterByQOSClasses(pods, selector)
+	suite.Error(err)
+}
+
+// TestFilterByReadiness tests that only pods whose Ready condition is true are kept.
+func (suite *Suite) TestFilterByReadiness() {
+	newPod := func(name string, conditions ...v1.PodCondition) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Status.Conditions = conditions
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("ready", v1.PodCondition{Type: v1.PodScheduled, Status: v1.ConditionTrue}, v1.PodCondition{Type: v1.PodReady, Status: v1.ConditionTrue}),
+		newPod("unready", v1.PodCondition{Type: v1.PodReady, Status: v1.ConditionFalse}),
+		newPod("unknown", v1.PodCondition{Type: v1.PodReady, Status: v1.ConditionUnknown}),
+		newPod("missing"),
+	}
+
+	suite.AssertPods(filterByReadiness(pods), []map[string]string{
+		{"namespace": "default", "name": "ready"},
+	})
+}
+
+// TestFilterByRestarts tests that crash-looping pods and pods that restarted too often recently are skipped.
+func (suite *Suite) TestFilterByRestarts() {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newPod := func(name string, status v1.ContainerStatus) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Status.ContainerStatuses = []v1.ContainerStatus{status}
+		return pod
+	}
+	restarted := func(count int32, ago time.Duration) v1.ContainerStatus {
+		return v1.ContainerStatus{
+			RestartCount: count,
+			LastTerminationState: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{FinishedAt: metav1.NewTime(now.Add(-ago))},
+			},
+		}
+	}
+
+	pods := []v1.Pod{
+		newPod("fresh", v1.ContainerStatus{}),
+		newPod("few", restarted(2, time.Minute)),
+		newPod("many", restarted(10, time.Minute)),
+		newPod("recovered", restarted(10, 2*time.Hour)),
+		newPod("crashing", v1.ContainerStatus{State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}}),
+	}
+
+	suite.AssertPods(filterByRestarts(pods, 3, time.Hour, now), []map[string]string{
+		{"namespace": "default", "name": "fresh"},
+		{"namespace": "default", "name": "few"},
+		{"namespace": "default", "name": "recovered"},
+	})
+}
+
+// TestFilterByPriorityClasses tests that pods are included or excluded by their priority class.
+func (suite *Suite) TestFilterByPriorityClasses() {
+	newPod := func(name, class string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.PriorityClassName = class
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("foo", "system-cluster-critical"),
+		newPod("bar", "high"),
+		newPod("baz", ""),
+	}
+
+	for _, tt := range []struct {
+		included []string
+		excluded []string
+		expected []map[string]string
+	}{
+		{nil, []string{"system-cluster-critical"}, []map[string]string{
+			{"namespace": "default", "name": "bar"},
+			{"namespace": "default", "name": "baz"},
+		}},
+		{[]string{"high", "system-cluster-critical"}, nil, []map[string]string{
+			{"namespace": "default", "name": "foo"},
+			{"namespace": "default", "name": "bar"},
+		}},
+		{[]string{"high", "system-cluster-critical"}, []string{"system-cluster-critical"}, []map[string]string{
+			{"namespace": "default", "name": "bar"},
+		}},
+	} {
+		results := filterByPriorityClasses(append([]v1.Pod{}, pods...), tt.included, tt.excluded)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+func (suite *Suite) TestFilterByNamespaceNames() {
+	pods := []v1.Pod{
+		util.NewPod("team-a-prod", "foo", v1.PodRunning),
+		util.NewPod("team-a-staging", "bar", v1.PodRunning),
+		util.NewPod("team-b-prod", "baz", v1.PodRunning),
+		util.NewPod("kube-system", "qux", v1.PodRunning),
+	}
+
+	for _, tt := range []struct {
+		included *regexp.Regexp
+		excluded *regexp.Regexp
+		expected []map[string]string
+	}{
+		{nil, nil, []map[string]string{
+			{"namespace": "team-a-prod", "name": "foo"},
+			{"namespace": "team-a-staging", "name": "bar"},
+			{"namespace": "team-b-prod", "name": "baz"},
+			{"namespace": "kube-system", "name": "qux"},
+		}},
+		{regexp.MustCompile(`^team-.*-prod$`), nil, []map[string]string{
+			{"namespace": "team-a-prod", "name": "foo"},
+			{"namespace": "team-b-prod", "name": "baz"},
+		}},
+		{nil, regexp.MustCompile(`^kube-`), []map[string]string{
+			{"namespace": "team-a-prod", "name": "foo"},
+			{"namespace": "team-a-staging", "name": "bar"},
+			{"namespace": "team-b-prod", "name": "baz"},
+		}},
+		{regexp.MustCompile(`^team-`), regexp.MustCompile(`-staging$`), []map[string]string{
+			{"namespace": "team-a-prod", "name": "foo"},
+			{"namespace": "team-b-prod", "name": "baz"},
+		}},
+	} {
+		results := filterByNamespaceNames(append([]v1.Pod{}, pods...), tt.included, tt.excluded)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+func (suite *Suite) TestFilterByImages() {
+	newPod := func(name string, images ...string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.Containers = nil
+		for _, image := range images {
+			pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Image: image})
+		}
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("foo", "example.com/app:1.0", "envoyproxy/envoy:v1.30"),
+		newPod("bar", "example.com/app:1.0"),
+		newPod("baz", "redis:7"),
+	}
+
+	for _, tt := range []struct {
+		included *regexp.Regexp
+		excluded *regexp.Regexp
+		expected []map[string]string
+	}{
+		{regexp.MustCompile(`^envoyproxy/envoy:`), nil, []map[string]string{
+			{"namespace": "default", "name": "foo"},
+		}},
+		{nil, regexp.MustCompile(`envoy`), []map[string]string{
+			{"namespace": "default", "name": "bar"},
+			{"namespace": "default", "name": "baz"},
+		}},
+		{regexp.MustCompile(`^example.com/`), regexp.MustCompile(`envoy`), []map[string]string{
+			{"namespace": "default", "name": "bar"},
+		}},
+	} {
+		results := filterByImages(append([]v1.Pod{}, pods...), tt.included, tt.excluded)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+func (suite *Suite) TestFilterByRequests() {
+	newPod := func(name string, requests ...v1.ResourceList) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.Containers = nil
+		for _, request := range requests {
+			pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Resources: v1.ResourceRequirements{Requests: request}})
+		}
+		return pod
+	}
+	cpu := func(quantity string) v1.ResourceList {
+		return v1.ResourceList{v1.ResourceCPU: resource.MustParse(quantity)}
+	}
+
+	pods := []v1.Pod{
+		// two containers adding up to a full core
+		newPod("foo", cpu("500m"), cpu("500m")),
+		newPod("bar", cpu("100m"), v1.ResourceList{v1.ResourceMemory: resource.MustParse("2Gi")}),
+		newPod("baz"),
+	}
+
+	for _, tt := range []struct {
+		min      v1.ResourceList
+		max      v1.ResourceList
+		expected []map[string]string
+	}{
+		{cpu("1"), nil, []map[string]string{
+			{"namespace": "default", "name": "foo"},
+		}},
+		{nil, cpu("500m"), []map[string]string{
+			{"namespace": "default", "name": "bar"},
+			{"namespace": "default", "name": "baz"},
+		}},
+		{v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")}, cpu("500m"), []map[string]string{
+			{"namespace": "default", "name": "bar"},
+		}},
+	} {
+		results := filterByRequests(append([]v1.Pod{}, pods...), tt.min, tt.max)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+func (suite *Suite) TestFilterByServiceAccounts() {
+	newPod := func(name, serviceAccount string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.ServiceAccountName = serviceAccount
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("foo", "cert-manager"),
+		newPod("bar", "app"),
+		newPod("baz", ""),
+	}
+
+	for _, tt := range []struct {
+		included []string
+		excluded []string
+		expected []map[string]string
+	}{
+		{nil, []string{"cert-manager"}, []map[string]string{
+			{"namespace": "default", "name": "bar"},
+			{"namespace": "default", "name": "baz"},
+		}},
+		{[]string{"default"}, nil, []map[string]string{
+			{"namespace": "default", "name": "baz"},
+		}},
+		{[]string{"app", "cert-manager"}, []string{"cert-manager"}, []map[string]string{
+			{"namespace": "default", "name": "bar"},
+		}},
+	} {
+		results := filterByServiceAccounts(append([]v1.Pod{}, pods...), tt.included, tt.excluded)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+// TestFilterByNodes tests that pods are filtered by the labels and names of their nodes.
+func (suite *Suite) TestFilterByNodes() {
+	newPod := func(name, node string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.NodeName = node
+		return pod
+	}
+	newNode := func(name string, labels map[string]string) *v1.Node {
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	client := fake.NewSimpleClientset(
+		newNode("spot-1", map[string]string{"pool": "spot"}),
+		newNode("spot-2", map[string]string{"pool": "spot"}),
+		newNode("on-demand-1", map[string]string{"pool": "on-demand"}),
+	)
+
+	pods := []v1.Pod{
+		newPod("foo", "spot-1"),
+		newPod("bar", "spot-2"),
+		newPod("baz", "on-demand-1"),
+	}
+
+	for _, tt := range []struct {
+		selector string
+		names    *regexp.Regexp
+		expected []map[string]string
+	}{
+		{"pool=spot", nil, []map[string]string{
+			{"namespace": "default", "name": "foo"},
+			{"namespace": "default", "name": "bar"},
+		}},
+		{"", regexp.MustCompile(`-1$`), []map[string]string{
+			{"namespace": "default", "name": "foo"},
+			{"namespace": "default", "name": "baz"},
+		}},
+		{"pool=spot", regexp.MustCompile(`-1$`), []map[string]string{
+			{"namespace": "default", "name": "foo"},
+		}},
+		{"pool=gpu", nil, []map[string]string{}},
+	} {
+		selector, err := labels.Parse(tt.selector)
+		suite.Require().NoError(err)
+
+		results, err := filterByNodes(context.Background(), append([]v1.Pod{}, pods...), client, selector, tt.names)
+		suite.Require().NoError(err)
+		suite.AssertPods(results, tt.expected)
+	}
+}
+
+// TestStatefulSetOrdinals tests that pods of StatefulSets are targeted by their ordinal.
+func (suite *Suite) TestStatefulSetOrdinals() {
+	controller := true
+	newPod := func(name, statefulSet string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "StatefulSet", Name: statefulSet, UID: types.UID(statefulSet), Controller: &controller}}
+		return pod
+	}
+	newStatefulSet := func(name string, replicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(name)},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		}
+	}
+
+	client := fake.NewSimpleClientset(newStatefulSet("db", 3))
+	pods := []v1.Pod{
+		newPod("db-0", "db"),
+		newPod("db-1", "db"),
+		newPod("db-2", "db"),
+		util.NewPod("default", "standalone", v1.PodRunning),
+	}
+
+	chaoskube := &Chaoskube{Logger: logger, DryRun: true, Now: time.Now}
+
+	for _, tt := range []struct {
+		mode     string
+		expected []string
+	}{
+		{StatefulSetsHighestOrdinal, []string{"db-2", "db-2", "db-2", "db-2"}},
+		{StatefulSetsDescending, []string{"db-2", "db-1", "db-0", "db-2"}},
+	} {
+		chaoskube.StatefulSets = tt.mode
+
+		for _, expected := range tt.expected {
+			results, err := filterByStatefulSetOrdinals(context.Background(), append([]v1.Pod{}, pods...), client, chaoskube.targetOrdinal)
+			suite.Require().NoError(err)
+			suite.AssertPods(results, []map[string]string{
+				{"namespace": "default", "name": expected},
+				{"namespace": "default", "name": "standalone"},
+			})
+
+			suite.Require().NoError(chaoskube.DeletePod(context.Background(), results[0]))
+		}
+	}
+}
+
+func (suite *Suite) TestStatefulSetOrdinal() {
+	for _, tt := range []struct {
+		name     string
+		ordinal  int
+		expected bool
+	}{
+		{"db-0", 0, true},
+		{"db-12", 12, true},
+		{"db-primary-0", 0, false},
+		{"other-0", 0, false},
+		{"db-", 0, false},
+	} {
+		ordinal, ok := statefulSetOrdinal(util.NewPod("default", tt.name, v1.PodRunning), "db")
+		suite.Equal(tt.expected, ok, tt.name)
+		suite.Equal(tt.ordinal, ordinal, tt.name)
+	}
+}
+
+// TestFilterByJobProgress tests that pods of Jobs close to completion are skipped.
+func (suite *Suite) TestFilterByJobProgress() {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	controller := true
+	newPod := func(name, job string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "batch/v1", Kind: "Job", Name: job, UID: types.UID(job), Controller: &controller}}
+		return pod
+	}
+	newJob := func(name string, completions *int32, succeeded int32, deadline *int64, running time.Duration) *batchv1.Job {
+		started := metav1.NewTime(now.Add(-running))
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(name)},
+			Spec:       batchv1.JobSpec{Completions: completions, ActiveDeadlineSeconds: deadline},
+			Status:     batchv1.JobStatus{Succeeded: succeeded, StartTime: &started},
+		}
+	}
+	ten := int32(10)
+	hour := int64(3600)
+
+	client := fake.NewSimpleClientset(
+		newJob("started", &ten, 1, nil, time.Minute),
+		newJob("almost-done", &ten, 9, nil, time.Minute),
+		newJob("almost-expired", nil, 0, &hour, 55*time.Minute),
+		newJob("plenty-of-time", nil, 0, &hour, 10*time.Minute),
+	)
+
+	pods := []v1.Pod{
+		newPod("started-abc", "started"),
+		newPod("almost-done-abc", "almost-done"),
+		newPod("almost-done-def", "almost-done"),
+		newPod("almost-expired-abc", "almost-expired"),
+		newPod("plenty-of-time-abc", "plenty-of-time"),
+		util.NewPod("default", "standalone", v1.PodRunning),
+	}
+
+	results, err := filterByJobProgress(context.Background(), pods, client, 0.9, now)
+	suite.Require().NoError(err)
+
+	suite.AssertPods(results, []map[string]string{
+		{"namespace": "default", "name": "started-abc"},
+		{"namespace": "default", "name": "plenty-of-time-abc"},
+		{"namespace": "default", "name": "standalone"},
+	})
+}
+
+func (suite *Suite) TestFilterByCanaries() {
+	newPod := func(name, replicaSet string) v1.Pod {
+		controller := true
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: replicaSet, UID: types.UID(replicaSet), Controller: &controller}}
+		return pod
+	}
+	newReplicaSet := func(name, deployment string) *appsv1.ReplicaSet {
+		controller := true
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            name,
+			UID:             types.UID(name),
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment, UID: types.UID(deployment), Controller: &controller}},
+		}}
+	}
+	newCanary := func(target, phase string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "flagger.app/v1beta1",
+			"kind":       "Canary",
+			"metadata":   map[string]interface{}{"namespace": "default", "name": target},
+			"spec": map[string]interface{}{
+				"targetRef": map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": target},
+			},
+			"status": map[string]interface{}{"phase": phase},
+		}}
+	}
+
+	podinfo := newPod("podinfo", "podinfo-abc")
+	primary := newPod("podinfo-primary", "podinfo-primary-abc")
+	other := newPod("other", "other-abc")
+	standalone := util.NewPod("default", "standalone", v1.PodRunning)
+
+	for _, tt := range []struct {
+		name     string
+		canary   *unstructured.Unstructured
+		expected []v1.Pod
+	}{
+		{
+			name:     "canary analysis in progress",
+			canary:   newCanary("podinfo", "Progressing"),
+			expected: []v1.Pod{other, standalone},
+		},
+		{
+			name:     "canary analysis succeeded",
+			canary:   newCanary("podinfo", "Succeeded"),
+			expected: []v1.Pod{podinfo, primary, other, standalone},
+		},
+	} {
+		client := fake.NewSimpleClientset(
+			newReplicaSet("podinfo-abc", "podinfo"),
+			newReplicaSet("podinfo-primary-abc", "podinfo-primary"),
+			newReplicaSet("other-abc", "other"),
+		)
+		dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			canariesResource: "CanaryList",
+		}, tt.canary)
+
+		results, err := filterByCanaries(context.Background(), []v1.Pod{podinfo, primary, other, standalone}, dynamicClient, workload.NewResolver(client), []string{v1.NamespaceAll})
+		suite.Require().NoError(err)
+
+		suite.Equal(tt.expected, results, tt.name)
+	}
 }
 
 func (suite *Suite) TestNotifierCall() {
@@ -0,0 +1,182 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/linki/chaoskube/auth"
+)
+
+// Entry is a single pod termination.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	// the key of the pod's owner, see OwnerKey
+	Owner string `json:"owner"`
+	// the UID of the pod's top-level workload, e.g. a Deployment, if it was resolved
+	Workload string `json:"workload,omitempty"`
+	DryRun   bool   `json:"dryRun"`
+}
+
+// History keeps the most recent terminations in a ring buffer of fixed size, so that its
+// memory usage is bounded no matter how long chaoskube runs.
+type History struct {
+	mutex   sync.RWMutex
+	entries []Entry
+	next    int
+	full    bool
+}
+
+// New creates and returns a History object keeping up to size entries.
+func New(size int) *History {
+	if size < 1 {
+		size = 1
+	}
+	return &History{entries: make([]Entry, size)}
+}
+
+// Add records a termination, replacing the oldest one if the history is full.
+func (h *History) Add(entry Entry) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.entries[h.next] = entry
+	h.next = (h.next + 1) % len(h.entries)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// Entries returns a copy of all recorded terminations, oldest first.
+func (h *History) Entries() []Entry {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if !h.full {
+		return append([]Entry{}, h.entries[:h.next]...)
+	}
+	return append(append([]Entry{}, h.entries[h.next:]...), h.entries[:h.next]...)
+}
+
+// LastTerminations returns the time of the most recent termination per owner key.
+func (h *History) LastTerminations() map[string]time.Time {
+	last := map[string]time.Time{}
+	for _, entry := range h.Entries() {
+		if entry.Time.After(last[entry.Owner]) {
+			last[entry.Owner] = entry.Time
+		}
+	}
+	return last
+}
+
+// LastWorkloadTerminations returns the time of the most recent termination per workload UID,
+// for entries whose workload was resolved.
+func (h *History) LastWorkloadTerminations() map[string]time.Time {
+	last := map[string]time.Time{}
+	for _, entry := range h.Entries() {
+		if entry.Workload != "" && entry.Time.After(last[entry.Workload]) {
+			last[entry.Workload] = entry.Time
+		}
+	}
+	return last
+}
+
+// Handler is an http.Handler that serves the recorded terminations. Requests must carry a bearer
+// token and only see the terminations in namespaces the caller is authorized for.
+type Handler struct {
+	logger     log.FieldLogger
+	authorizer auth.Authorizer
+	history    *History
+}
+
+// NewHandler creates and returns a Handler object serving the given history.
+func NewHandler(logger log.FieldLogger, authorizer auth.Authorizer, history *History) *Handler {
+	return &Handler{
+		logger:     logger,
+		authorizer: authorizer,
+		history:    history,
+	}
+}
+
+// ServeHTTP returns the recorded terminations the caller may see as JSON, most recent first.
+func (h *Handler) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		http.Error(res, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	entries := h.history.Entries()
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	// callers allowed in all namespaces see everything, everyone else is checked per namespace
+	allowed := map[string]bool{}
+	_, err := h.authorizer.Authorize(req.Context(), token, "")
+	switch {
+	case err == nil:
+		for _, entry := range entries {
+			allowed[entry.Namespace] = true
+		}
+	case errors.Is(err, auth.ErrForbidden):
+		for _, entry := range entries {
+			if _, ok := allowed[entry.Namespace]; ok {
+				continue
+			}
+			_, err := h.authorizer.Authorize(req.Context(), token, entry.Namespace)
+			if err != nil && !errors.Is(err, auth.ErrForbidden) {
+				h.fail(res, err)
+				return
+			}
+			allowed[entry.Namespace] = err == nil
+		}
+	default:
+		h.fail(res, err)
+		return
+	}
+
+	visible := []Entry{}
+	for _, entry := range entries {
+		if allowed[entry.Namespace] {
+			visible = append(visible, entry)
+		}
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(res).Encode(map[string]interface{}{"terminations": visible}); err != nil {
+		http.Error(res, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// fail responds to a request that couldn't be authorized.
+func (h *Handler) fail(res http.ResponseWriter, err error) {
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		http.Error(res, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	h.logger.WithField("err", err).Error("failed to authorize status request")
+	http.Error(res, "failed to authorize request", http.StatusInternalServerError)
+}
+
+// OwnerKey returns the key that identifies the owner of a pod, i.e. the UID of its controller,
+// or the pod itself if it isn't controlled by anything.
+func OwnerKey(pod v1.Pod) string {
+	if ref := metav1.GetControllerOf(&pod); ref != nil {
+		return string(ref.UID)
+	}
+	if refs := pod.GetOwnerReferences(); len(refs) > 0 {
+		return string(refs[0].UID)
+	}
+	return pod.Namespace + "/" + pod.Name
+}
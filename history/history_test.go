@@ -0,0 +1,151 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/auth"
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HistorySuite struct {
+	testutil.TestSuite
+}
+
+func entry(name string, minutes int) Entry {
+	return Entry{
+		Time:      time.Date(2024, 1, 1, 12, minutes, 0, 0, time.UTC),
+		Namespace: "default",
+		Name:      name,
+		Owner:     "owner-" + name,
+	}
+}
+
+func (suite *HistorySuite) TestEntries() {
+	for _, tt := range []struct {
+		name     string
+		size     int
+		added    []Entry
+		expected []Entry
+	}{
+		{"empty", 3, nil, []Entry{}},
+		{"partially filled", 3, []Entry{entry("foo", 1), entry("bar", 2)}, []Entry{entry("foo", 1), entry("bar", 2)}},
+		{"exactly full", 2, []Entry{entry("foo", 1), entry("bar", 2)}, []Entry{entry("foo", 1), entry("bar", 2)}},
+		{"wrapped around", 2, []Entry{entry("foo", 1), entry("bar", 2), entry("baz", 3)}, []Entry{entry("bar", 2), entry("baz", 3)}},
+		{"invalid size", 0, []Entry{entry("foo", 1), entry("bar", 2)}, []Entry{entry("bar", 2)}},
+	} {
+		history := New(tt.size)
+		for _, e := range tt.added {
+			history.Add(e)
+		}
+
+		suite.Equal(tt.expected, history.Entries(), tt.name)
+	}
+}
+
+func (suite *HistorySuite) TestLastTerminations() {
+	history := New(10)
+	history.Add(Entry{Time: time.Unix(100, 0), Owner: "foo"})
+	history.Add(Entry{Time: time.Unix(300, 0), Owner: "foo"})
+	history.Add(Entry{Time: time.Unix(200, 0), Owner: "bar"})
+
+	suite.Equal(map[string]time.Time{
+		"foo": time.Unix(300, 0),
+		"bar": time.Unix(200, 0),
+	}, history.LastTerminations())
+}
+
+func (suite *HistorySuite) TestLastWorkloadTerminations() {
+	history := New(10)
+	history.Add(Entry{Time: time.Unix(100, 0), Owner: "foo-1", Workload: "foo"})
+	history.Add(Entry{Time: time.Unix(300, 0), Owner: "foo-2", Workload: "foo"})
+	history.Add(Entry{Time: time.Unix(200, 0), Owner: "bar"})
+
+	suite.Equal(map[string]time.Time{
+		"foo": time.Unix(300, 0),
+	}, history.LastWorkloadTerminations())
+}
+
+var logger, _ = test.NewNullLogger()
+
+// namespaceAuthorizer allows its token in a single namespace only.
+type namespaceAuthorizer struct {
+	token     string
+	namespace string
+}
+
+func (a namespaceAuthorizer) Authorize(ctx context.Context, token, namespace string) (string, error) {
+	if token != a.token {
+		return "", auth.ErrUnauthenticated
+	}
+	if namespace != a.namespace {
+		return "team", auth.ErrForbidden
+	}
+	return "team", nil
+}
+
+func (suite *HistorySuite) TestServeHTTP() {
+	other := entry("baz", 3)
+	other.Namespace = "other"
+
+	history := New(10)
+	history.Add(entry("foo", 1))
+	history.Add(entry("bar", 2))
+	history.Add(other)
+
+	for _, tt := range []struct {
+		name       string
+		authorizer auth.Authorizer
+		token      string
+		expected   int
+		entries    []Entry
+	}{
+		{"all namespaces", auth.NewStaticToken("secret"), "secret", http.StatusOK, []Entry{other, entry("bar", 2), entry("foo", 1)}},
+		{"single namespace", namespaceAuthorizer{token: "secret", namespace: "default"}, "secret", http.StatusOK, []Entry{entry("bar", 2), entry("foo", 1)}},
+		{"foreign namespace", namespaceAuthorizer{token: "secret", namespace: "team"}, "secret", http.StatusOK, []Entry{}},
+		{"missing token", auth.NewStaticToken("secret"), "", http.StatusUnauthorized, nil},
+		{"wrong token", auth.NewStaticToken("secret"), "wrong", http.StatusUnauthorized, nil},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		res := httptest.NewRecorder()
+
+		NewHandler(logger, tt.authorizer, history).ServeHTTP(res, req)
+
+		suite.Equal(tt.expected, res.Code, tt.name)
+		if tt.expected != http.StatusOK {
+			continue
+		}
+
+		var body struct {
+			Terminations []Entry `json:"terminations"`
+		}
+		suite.Require().NoError(json.NewDecoder(res.Body).Decode(&body), tt.name)
+		suite.Equal(tt.entries, body.Terminations, tt.name)
+	}
+}
+
+func (suite *HistorySuite) TestOwnerKey() {
+	owned := util.NewPodWithOwner("default", "foo", v1.PodRunning, "parent")
+	standalone := util.NewPod("default", "bar", v1.PodRunning)
+
+	suite.Equal("parent", OwnerKey(owned))
+	suite.Equal("default/bar", OwnerKey(standalone))
+}
+
+func TestHistorySuite(t *testing.T) {
+	suite.Run(t, new(HistorySuite))
+}
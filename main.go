@@ -12,58 +12,233 @@ import (
 	"path"
 	"regexp"
 	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/alecthomas/units"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/term"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog"
 
+	"github.com/linki/chaoskube/approval"
+	"github.com/linki/chaoskube/auth"
+	"github.com/linki/chaoskube/budget"
+	"github.com/linki/chaoskube/cache"
+	"github.com/linki/chaoskube/calendar"
 	"github.com/linki/chaoskube/chaoskube"
+	"github.com/linki/chaoskube/history"
+	"github.com/linki/chaoskube/nodemetrics"
 	"github.com/linki/chaoskube/notifier"
+	"github.com/linki/chaoskube/opencost"
+	"github.com/linki/chaoskube/profile"
+	"github.com/linki/chaoskube/promquery"
+	"github.com/linki/chaoskube/replay"
+	"github.com/linki/chaoskube/snapshot"
+	"github.com/linki/chaoskube/strategy"
 	"github.com/linki/chaoskube/terminator"
+	"github.com/linki/chaoskube/throttle"
+	"github.com/linki/chaoskube/trigger"
+	"github.com/linki/chaoskube/tui"
 	"github.com/linki/chaoskube/util"
 )
 
 const envVarPrefix = "CHAOSKUBE_"
 
+const (
+	terminatorDeletePod     = "delete-pod"
+	terminatorKillContainer = "kill-container"
+	terminatorDrainNode     = "drain-node"
+	terminatorRestart       = "rollout-restart"
+	terminatorNetworkChaos  = "network-chaos"
+	terminatorStress        = "stress"
+	terminatorEvictPod      = "evict-pod"
+	terminatorScaleToZero   = "scale-to-zero"
+	terminatorDNSChaos      = "dns-chaos"
+	terminatorExecPrefix    = "exec:"
+	pdbFallbackSkip         = "skip"
+	pdbFallbackDelete       = "delete"
+	terminatorModeRandom    = "random"
+	terminatorModeSequence  = "sequence"
+)
+
 var version = "undefined"
 
 var (
-	labelString            string
+	labelStrings           []string
 	annString              string
 	kindsString            string
 	nsString               string
 	nsLabelString          string
-	includedPodNames       *regexp.Regexp
-	excludedPodNames       *regexp.Regexp
+	helmRelease            string
+	helmChart              string
+	includedNsNames        *regexp.Regexp
+	excludedNsNames        *regexp.Regexp
+	includedPodNames       []*regexp.Regexp
+	includedImages         *regexp.Regexp
+	excludedImages         *regexp.Regexp
+	excludedPodNames       []*regexp.Regexp
 	excludedWeekdays       string
 	excludedTimesOfDay     string
+	excludedDaysCalendar   string
+	calendarRefresh        time.Duration
+	includedWeekdays       string
+	includedTimesOfDay     string
 	excludedDaysOfYear     string
 	timezone               string
 	minimumAge             time.Duration
 	maxRuntime             time.Duration
-	maxKill                int
+	maxKill                string
+	maxKillPerOwner        int
+	maxKillMin             int
+	maxKillMax             int
+	byDeletionCost         bool
 	master                 string
 	kubeconfig             string
 	interval               time.Duration
+	schedule               string
+	intervalOverrides      string
+	poisson                bool
 	dynamicIntervalEnabled bool
 	dynamicIntervalFactor  float64
+	dynamicWorkingDays     float64
+	dynamicWorkingHours    float64
+	dynamicTarget          float64
+	dynamicSmoothing       float64
+	dynamicSamplePeriod    time.Duration
 	dryRun                 bool
 	debug                  bool
 	metricsAddress         string
 	gracePeriod            time.Duration
+	gracePeriodMin         time.Duration
+	gracePeriodMax         time.Duration
 	logFormat              string
 	logCaller              bool
 	slackWebhook           string
 	clientNamespaceScope   string
+	triggerOnRollout       bool
+	triggerDelay           time.Duration
+	webhookToken           string
+	webhookRateLimit       int
+	webhookMaxWithin       time.Duration
+	webhookKubernetesAuth  bool
+	prometheusAddress      string
+	candidatePromQL        string
+	candidateQuery         string
+	opencostAddress        string
+	opencostWindow         string
+	costWeighting          bool
+	maxCostPerDay          float64
+	argoRollouts           string
+	pauseDuringCanary      bool
+	informerCache          bool
+	fieldSelectorString    string
+	terminationWorkers     int
+	clientQPS              float64
+	clientBurst            int
+	clientMaxBackoff       time.Duration
+	metadataOnly           bool
+	listParallelism        int
+	historySize            int
+	cooldown               time.Duration
+	slowListThreshold      time.Duration
+	selectionStrategy      string
+	seed                   int64
+	recordFile             string
+	replayFile             string
+	namespaceLevels        bool
+	snooze                 bool
+	ageWeightExponent      float64
+	workloadIntervals      bool
+	profileName            string
+	validate               bool
+	virtualNodes           string
+	nodeLabelString        string
+	nodeNames              *regexp.Regexp
+	minOwnerReady          int
+	preserveZones          bool
+	skipRollouts           bool
+	excludePVCs            bool
+	pvcAccessModes         string
+	protectSoleEndpoints   bool
+	defaultOptIn           bool
+	excludeDaemonSets      bool
+	excludeStaticPods      bool
+	respectDoNotDisrupt    bool
+	enforceMaxLifetime     bool
+	qosClassString         string
+	includedPriorities     string
+	excludedPriorities     string
+	includedSAs            string
+	excludedSAs            string
+	onlyReadyPods          bool
+	minCPURequest          string
+	maxCPURequest          string
+	minMemoryRequest       string
+	maxMemoryRequest       string
+	maxRestarts            int
+	restartWindow          time.Duration
+	statefulSets           string
+	jobProgressThreshold   float64
+	colocatedBlast         int
+	stampOwners            bool
+	runID                  string
+	anyLabelStrings        []string
+	annotationRegexes      map[string]string
+	tuiEnabled             bool
+	logTailLines           int64
+	snapshotDir            string
+	pushgatewayAddress     string
+	pushgatewayJob         string
+	slackSigningSecret     string
+	approvalTimeout        time.Duration
+	approvalDefault        string
+	watchdogThreshold      time.Duration
+	janitorInterval        time.Duration
+	janitorStuckAfter      time.Duration
+	janitorLingerAfter     time.Duration
+	maxKillsPerHour        int
+	maxKillsPerDay         int
+	budgetFile             string
+	serverDryRun           bool
+	terminatorSpecs        []string
+	terminatorMode         string
+	containerSignal        string
+	signalAll              bool
+	drainGracePeriod       time.Duration
+	uncordonAfter          time.Duration
+	cordonBeforeKill       bool
+	forceDeleteAfter       time.Duration
+	networkLatency         time.Duration
+	networkJitter          time.Duration
+	networkLoss            float64
+	networkChaosFor        time.Duration
+	networkChaosImage      string
+	stressCPUWorkers       int
+	stressMemory           units.Base2Bytes
+	stressDuration         time.Duration
+	stressImage            string
+	pdbTimeout             time.Duration
+	pdbFallback            string
+	scaleToZeroPause       time.Duration
 )
 
 func cliEnvVar(name string) string {
@@ -71,40 +246,193 @@ func cliEnvVar(name string) string {
 }
 
 func init() {
-	rand.Seed(time.Now().UTC().UnixNano())
 	klog.SetOutput(io.Discard)
 
-	kingpin.Flag("labels", "A set of labels to restrict the list of affected pods. Defaults to everything.").Envar(cliEnvVar("LABELS")).StringVar(&labelString)
+	kingpin.Flag("labels", "A set of labels to restrict the list of affected pods. Repeat the flag to OR several sets, which are then evaluated by chaoskube instead of the API server. Defaults to everything.").Envar(cliEnvVar("LABELS")).StringsVar(&labelStrings)
 	kingpin.Flag("annotations", "A set of annotations to restrict the list of affected pods. Defaults to everything.").Envar(cliEnvVar("ANNOTATIONS")).StringVar(&annString)
-	kingpin.Flag("kinds", "A set of kinds to restrict the list of affected pods. Defaults to everything.").Envar(cliEnvVar("KINDS")).StringVar(&kindsString)
+	kingpin.Flag("kinds", "A set of owner kinds to restrict the list of affected pods, matching direct owners and top-level workloads, e.g. Deployment or apps/v1:Deployment. Defaults to everything.").Envar(cliEnvVar("KINDS")).StringVar(&kindsString)
 	kingpin.Flag("namespaces", "A set of namespaces to restrict the list of affected pods. Defaults to everything.").Envar(cliEnvVar("NAMESPACES")).StringVar(&nsString)
 	kingpin.Flag("namespace-labels", "A set of labels to restrict the list of affected namespaces. Defaults to everything.").Envar(cliEnvVar("NAMESPACE_LABELS")).StringVar(&nsLabelString)
-	kingpin.Flag("included-pod-names", "Regular expression that defines which pods to include. All included by default.").Envar(cliEnvVar("INCLUDED_POD_NAMES")).RegexpVar(&includedPodNames)
-	kingpin.Flag("excluded-pod-names", "Regular expression that defines which pods to exclude. None excluded by default.").Envar(cliEnvVar("EXCLUDED_POD_NAMES")).RegexpVar(&excludedPodNames)
+	kingpin.Flag("helm-release", "The name of a Helm release whose pods to target across namespaces, by their app.kubernetes.io/instance label.").Envar(cliEnvVar("HELM_RELEASE")).StringVar(&helmRelease)
+	kingpin.Flag("helm-chart", "The name of a Helm chart whose pods to target, by their helm.sh/chart label regardless of the chart's version.").Envar(cliEnvVar("HELM_CHART")).StringVar(&helmChart)
+	kingpin.Flag("included-namespace-names", "Regular expression that defines which namespaces to include. All included by default.").Envar(cliEnvVar("INCLUDED_NAMESPACE_NAMES")).RegexpVar(&includedNsNames)
+	kingpin.Flag("excluded-namespace-names", "Regular expression that defines which namespaces to exclude. None excluded by default.").Envar(cliEnvVar("EXCLUDED_NAMESPACE_NAMES")).RegexpVar(&excludedNsNames)
+	kingpin.Flag("included-pod-names", "Regular expression that defines which pods to include. Repeat the flag to include pods matching any of several expressions. All included by default.").Envar(cliEnvVar("INCLUDED_POD_NAMES")).RegexpListVar(&includedPodNames)
+	kingpin.Flag("excluded-pod-names", "Regular expression that defines which pods to exclude. Repeat the flag to exclude pods matching any of several expressions. None excluded by default.").Envar(cliEnvVar("EXCLUDED_POD_NAMES")).RegexpListVar(&excludedPodNames)
+	kingpin.Flag("included-image-regexp", "Regular expression that defines which container images to include, matching pods with any such container. All included by default. Not supported with --metadata-only.").Envar(cliEnvVar("INCLUDED_IMAGE_REGEXP")).RegexpVar(&includedImages)
+	kingpin.Flag("excluded-image-regexp", "Regular expression that defines which container images to exclude, matching pods with any such container. None excluded by default. Not supported with --metadata-only.").Envar(cliEnvVar("EXCLUDED_IMAGE_REGEXP")).RegexpVar(&excludedImages)
 	kingpin.Flag("excluded-weekdays", "A list of weekdays when termination is suspended, e.g. Sat,Sun").Envar(cliEnvVar("EXCLUDED_WEEKDAYS")).StringVar(&excludedWeekdays)
 	kingpin.Flag("excluded-times-of-day", "A list of time periods of a day when termination is suspended, e.g. 22:00-08:00").Envar(cliEnvVar("EXCLUDED_TIMES_OF_DAY")).StringVar(&excludedTimesOfDay)
+	kingpin.Flag("excluded-days-calendar", "URL or path of an iCalendar feed, e.g. of company holidays or change freezes, whose events suspend termination for the days they cover.").Envar(cliEnvVar("EXCLUDED_DAYS_CALENDAR")).StringVar(&excludedDaysCalendar)
+	kingpin.Flag("excluded-days-calendar-refresh", "How often to read --excluded-days-calendar again.").Envar(cliEnvVar("EXCLUDED_DAYS_CALENDAR_REFRESH")).Default("1h").DurationVar(&calendarRefresh)
+	kingpin.Flag("included-weekdays", "A list of weekdays outside of which termination is suspended, e.g. Mon,Tue,Wed,Thu,Fri. All included by default.").Envar(cliEnvVar("INCLUDED_WEEKDAYS")).StringVar(&includedWeekdays)
+	kingpin.Flag("included-times-of-day", "A list of time periods of a day outside of which termination is suspended, e.g. 09:00-17:00. All included by default.").Envar(cliEnvVar("INCLUDED_TIMES_OF_DAY")).StringVar(&includedTimesOfDay)
 	kingpin.Flag("excluded-days-of-year", "A list of days of a year when termination is suspended, e.g. Apr1,Dec24").Envar(cliEnvVar("EXCLUDED_DAYS_OF_YEAR")).StringVar(&excludedDaysOfYear)
 	kingpin.Flag("timezone", "The timezone by which to interpret the excluded weekdays and times of day, e.g. UTC, Local, Europe/Berlin. Defaults to UTC.").Envar(cliEnvVar("TIMEZONE")).Default("UTC").StringVar(&timezone)
 	kingpin.Flag("minimum-age", "Minimum age of pods to consider for termination").Envar(cliEnvVar("MINIMUM_AGE")).Default("0s").DurationVar(&minimumAge)
 	kingpin.Flag("max-runtime", "Maximum runtime before chaoskube exits").Envar(cliEnvVar("MAX_RUNTIME")).Default("-1s").DurationVar(&maxRuntime)
-	kingpin.Flag("max-kill", "Specifies the maximum number of pods to be terminated per interval.").Envar(cliEnvVar("MAX_KILL")).Default("1").IntVar(&maxKill)
+	kingpin.Flag("max-kill", "Specifies the maximum number of pods to be terminated per interval, either as a number or as a percentage of the candidates, e.g. 10%.").Envar(cliEnvVar("MAX_KILL")).Default("1").StringVar(&maxKill)
+	kingpin.Flag("max-kill-min", "Minimum number of pods to terminate per interval when --max-kill is a percentage, e.g. with --dynamic-interval to keep up a baseline in small clusters.").Envar(cliEnvVar("MAX_KILL_MIN")).Default("1").IntVar(&maxKillMin)
+	kingpin.Flag("max-kill-max", "Maximum number of pods to terminate per interval when --max-kill is a percentage, e.g. with --dynamic-interval to bound the blast radius in large clusters. Defaults to 0, which means unbounded.").Envar(cliEnvVar("MAX_KILL_MAX")).Default("0").IntVar(&maxKillMax)
+	kingpin.Flag("max-kill-per-owner", "Specifies the maximum number of pods of the same owner to be terminated per interval, e.g. to test quorum loss.").Envar(cliEnvVar("MAX_KILL_PER_OWNER")).Default("1").IntVar(&maxKillPerOwner)
+	kingpin.Flag("prefer-low-deletion-cost", "Among the pods of an owner, prefer those with the lowest controller.kubernetes.io/pod-deletion-cost annotation, like the ReplicaSet controller does when scaling down.").Envar(cliEnvVar("PREFER_LOW_DELETION_COST")).BoolVar(&byDeletionCost)
 	kingpin.Flag("master", "The address of the Kubernetes cluster to target").Envar(cliEnvVar("MASTER")).StringVar(&master)
 	kingpin.Flag("kubeconfig", "Path to a kubeconfig file").Envar(cliEnvVar("KUBECONFIG")).StringVar(&kubeconfig)
 	kingpin.Flag("interval", "Interval between Pod terminations").Envar(cliEnvVar("INTERVAL")).Default("10m").DurationVar(&interval)
+	kingpin.Flag("schedule", "Cron expression in --timezone to terminate pods at instead of every --interval, e.g. '0 10,14 * * MON-FRI' for 10:00 and 14:00 on weekdays. Takes precedence over --interval and --dynamic-interval.").Envar(cliEnvVar("SCHEDULE")).StringVar(&schedule)
+	kingpin.Flag("interval-overrides", "Intervals for individual weekdays in --timezone, replacing --interval and the dynamic interval on those days, e.g. Mon=2h,Fri=4h.").Envar(cliEnvVar("INTERVAL_OVERRIDES")).StringVar(&intervalOverrides)
+	kingpin.Flag("poisson", "Draw the time between terminations from an exponential distribution with --interval, or the dynamic interval, as its mean, so that terminations happen at random like real failures rather than periodically.").Envar(cliEnvVar("POISSON")).BoolVar(&poisson)
 	kingpin.Flag("dynamic-interval", "Enable dynamic interval calculation based on pod count").Envar(cliEnvVar("DYNAMIC_INTERVAL")).Default("false").BoolVar(&dynamicIntervalEnabled)
 	kingpin.Flag("dynamic-interval-factor", "Factor to adjust dynamic interval calculation (higher values make intervals change more dramatically)").Envar(cliEnvVar("DYNAMIC_INTERVAL_FACTOR")).Default("1.0").Float64Var(&dynamicIntervalFactor)
+	kingpin.Flag("dynamic-interval-working-days", "Number of days per week chaos happens on, which the dynamic interval spreads the terminations over, e.g. 7 in 24/7 environments.").Envar(cliEnvVar("DYNAMIC_INTERVAL_WORKING_DAYS")).Default("5").Float64Var(&dynamicWorkingDays)
+	kingpin.Flag("dynamic-interval-working-hours", "Number of hours per day chaos happens in, which the dynamic interval spreads the terminations over, e.g. 24 in 24/7 environments.").Envar(cliEnvVar("DYNAMIC_INTERVAL_WORKING_HOURS")).Default("8").Float64Var(&dynamicWorkingHours)
+	kingpin.Flag("dynamic-interval-target", "Fraction of the candidates the dynamic interval aims to terminate within a working week, e.g. 1.0 to terminate each of them once a week.").Envar(cliEnvVar("DYNAMIC_INTERVAL_TARGET")).Default("0.5").Float64Var(&dynamicTarget)
+	kingpin.Flag("dynamic-interval-smoothing", "Weight of each new pod count in the moving average the dynamic interval is based on, between 0 and 1, e.g. 0.2, so that sudden scale events don't make the interval jump. Defaults to 0, which disables smoothing.").Envar(cliEnvVar("DYNAMIC_INTERVAL_SMOOTHING")).Default("0").Float64Var(&dynamicSmoothing)
+	kingpin.Flag("dynamic-interval-sample-period", "How often to count the pods for the moving average of --dynamic-interval-smoothing between terminations. Use with --informer-cache to count pods without listing them.").Envar(cliEnvVar("DYNAMIC_INTERVAL_SAMPLE_PERIOD")).Default("1m").DurationVar(&dynamicSamplePeriod)
 	kingpin.Flag("dry-run", "Don't actually kill any pod. Turned on by default. Turn off with `--no-dry-run`.").Envar(cliEnvVar("DRY_RUN")).Default("true").BoolVar(&dryRun)
 	kingpin.Flag("debug", "Enable debug logging.").Envar(cliEnvVar("DEBUG")).BoolVar(&debug)
 	kingpin.Flag("metrics-address", "Listening address for metrics handler").Envar(cliEnvVar("METRICS_ADDRESS")).Default(":8080").StringVar(&metricsAddress)
 	kingpin.Flag("grace-period", "Grace period to terminate Pods. Negative values will use the Pod's grace period.").Envar(cliEnvVar("GRACE_PERIOD")).Default("-1s").DurationVar(&gracePeriod)
+	kingpin.Flag("grace-period-min", "Lower bound of a random grace period per termination, see --grace-period-max.").Envar(cliEnvVar("GRACE_PERIOD_MIN")).Default("0s").DurationVar(&gracePeriodMin)
+	kingpin.Flag("grace-period-max", "Upper bound of a random grace period per termination, which exercises both abrupt and slow shutdowns. Takes precedence over --grace-period. Zero disables it.").Envar(cliEnvVar("GRACE_PERIOD_MAX")).Default("0s").DurationVar(&gracePeriodMax)
 	kingpin.Flag("log-format", "Specify the format of the log messages. Options are text and json. Defaults to text.").Envar(cliEnvVar("LOG_FORMAT")).Default("text").EnumVar(&logFormat, "text", "json")
 	kingpin.Flag("log-caller", "Include the calling function name and location in the log messages.").Envar(cliEnvVar("LOG_CALLER")).BoolVar(&logCaller)
 	kingpin.Flag("slack-webhook", "The address of the slack webhook for notifications").Envar(cliEnvVar("SLACK_WEBHOOK")).StringVar(&slackWebhook)
-	kingpin.Flag("client-namespace-scope", "Scope Kubernetes API calls to the given namespace. Defaults to v1.NamespaceAll which requires global read permission.").Envar(cliEnvVar("CLIENT_NAMESPACE_SCOPE")).Default(v1.NamespaceAll).StringVar(&clientNamespaceScope)
+	kingpin.Flag("client-namespace-scope", "Scope Kubernetes API calls to the given comma-separated list of namespaces. Defaults to v1.NamespaceAll which requires global read permission.").Envar(cliEnvVar("CLIENT_NAMESPACE_SCOPE")).Default(v1.NamespaceAll).StringVar(&clientNamespaceScope)
+	kingpin.Flag("trigger-on-rollout", "Terminate a pod of a Deployment or StatefulSet shortly after each of its rollouts completed.").Envar(cliEnvVar("TRIGGER_ON_ROLLOUT")).BoolVar(&triggerOnRollout)
+	kingpin.Flag("trigger-delay", "Delay between a completed rollout and the triggered pod termination.").Envar(cliEnvVar("TRIGGER_DELAY")).Default("1m").DurationVar(&triggerDelay)
+	kingpin.Flag("webhook-token", "Bearer token that enables the /trigger, /status, /pause and /resume endpoints for external chaos requests, e.g. from CI/CD pipelines.").Envar(cliEnvVar("WEBHOOK_TOKEN")).StringVar(&webhookToken)
+	kingpin.Flag("webhook-rate-limit", "Maximum number of requests per minute accepted by the /trigger endpoint.").Envar(cliEnvVar("WEBHOOK_RATE_LIMIT")).Default("6").IntVar(&webhookRateLimit)
+	kingpin.Flag("webhook-max-within", "Maximum time window a /trigger request may ask its termination to happen in.").Envar(cliEnvVar("WEBHOOK_MAX_WITHIN")).Default("1h").DurationVar(&webhookMaxWithin)
+	kingpin.Flag("webhook-kubernetes-auth", "Enables the /trigger, /status, /pause and /resume endpoints for Kubernetes service account and user tokens. Callers may only request chaos in namespaces where they are allowed to delete pods, and only pause chaos if they are allowed to delete pods in all namespaces.").Envar(cliEnvVar("WEBHOOK_KUBERNETES_AUTH")).BoolVar(&webhookKubernetesAuth)
+	kingpin.Flag("prometheus-address", "The address of the Prometheus server to evaluate queries against, e.g. http://prometheus:9090").Envar(cliEnvVar("PROMETHEUS_ADDRESS")).StringVar(&prometheusAddress)
+	kingpin.Flag("candidate-promql", "A PromQL expression whose result, identified by its namespace and pod labels, restricts the list of affected pods.").Envar(cliEnvVar("CANDIDATE_PROMQL")).StringVar(&candidatePromQL)
+	kingpin.Flag("candidate-query", "A PromQL expression evaluated for each candidate, templated with the pod, e.g. {{.Namespace}} and {{.Name}}. Candidates are dropped unless the result holds a non-zero value.").Envar(cliEnvVar("CANDIDATE_QUERY")).StringVar(&candidateQuery)
+	kingpin.Flag("opencost-address", "The address of an OpenCost or Kubecost API to fetch pod costs from, e.g. http://opencost:9003").Envar(cliEnvVar("OPENCOST_ADDRESS")).StringVar(&opencostAddress)
+	kingpin.Flag("opencost-window", "The time window to aggregate pod costs over.").Envar(cliEnvVar("OPENCOST_WINDOW")).Default("1d").StringVar(&opencostWindow)
+	kingpin.Flag("cost-weighting", "Prefer expensive pods when selecting victims. Requires --opencost-address.").Envar(cliEnvVar("COST_WEIGHTING")).BoolVar(&costWeighting)
+	kingpin.Flag("max-cost-per-day", "Maximum total cost of pods to kill per day. Requires --opencost-address. Defaults to unlimited.").Envar(cliEnvVar("MAX_COST_PER_DAY")).Default("0").Float64Var(&maxCostPerDay)
+	kingpin.Flag("argo-rollouts", "How to treat pods managed by Argo Rollouts. Options are ignore, skip-canary (skip canary and preview pods while a rollout is in progress) and stable-only (only target pods of the stable ReplicaSet).").Envar(cliEnvVar("ARGO_ROLLOUTS")).Default(chaoskube.ArgoRolloutsIgnore).EnumVar(&argoRollouts, chaoskube.ArgoRolloutsIgnore, chaoskube.ArgoRolloutsSkipCanary, chaoskube.ArgoRolloutsStableOnly)
+	kingpin.Flag("pause-during-canary", "Don't terminate pods of workloads while a Flagger canary analysis is in progress for them.").Envar(cliEnvVar("PAUSE_DURING_CANARY")).BoolVar(&pauseDuringCanary)
+	kingpin.Flag("informer-cache", "Serve pods and namespaces from a watch-based cache instead of listing them on every interval. Reduces API server load on large clusters.").Envar(cliEnvVar("INFORMER_CACHE")).BoolVar(&informerCache)
+	kingpin.Flag("field-selector", "A field selector to restrict the list of affected pods, e.g. spec.nodeName=node-1. Evaluated by the API server. Defaults to everything.").Envar(cliEnvVar("FIELD_SELECTOR")).StringVar(&fieldSelectorString)
+	kingpin.Flag("termination-workers", "Number of victims to terminate concurrently, e.g. when --max-kill is large.").Envar(cliEnvVar("TERMINATION_WORKERS")).Default("1").IntVar(&terminationWorkers)
+	kingpin.Flag("client-qps", "Maximum queries per second to the Kubernetes API server.").Envar(cliEnvVar("CLIENT_QPS")).Default("5").Float64Var(&clientQPS)
+	kingpin.Flag("client-burst", "Maximum burst of queries to the Kubernetes API server.").Envar(cliEnvVar("CLIENT_BURST")).Default("10").IntVar(&clientBurst)
+	kingpin.Flag("client-max-backoff", "Maximum delay between requests when the Kubernetes API server responds with 429 Too Many Requests. Set to 0s to disable adaptive throttling.").Envar(cliEnvVar("CLIENT_MAX_BACKOFF")).Default("30s").DurationVar(&clientMaxBackoff)
+	kingpin.Flag("metadata-only", "List only the metadata of pods and namespaces, which uses considerably less memory on large clusters. Refuses to start with flags that depend on the spec or status of pods.").Envar(cliEnvVar("METADATA_ONLY")).BoolVar(&metadataOnly)
+	kingpin.Flag("list-parallelism", "Number of namespaces to list concurrently when --client-namespace-scope contains multiple namespaces.").Envar(cliEnvVar("LIST_PARALLELISM")).Default("10").IntVar(&listParallelism)
+	kingpin.Flag("history-size", "Number of recent terminations to keep in memory for the /status endpoint and the cooldown. /status requires --webhook-token or --webhook-kubernetes-auth.").Envar(cliEnvVar("HISTORY_SIZE")).Default("100").IntVar(&historySize)
+	kingpin.Flag("cooldown", "Minimum time between terminations of pods of the same owner, e.g. a Deployment. Limited by --history-size. Defaults to no cooldown.").Envar(cliEnvVar("COOLDOWN")).Default("0s").DurationVar(&cooldown)
+	kingpin.Flag("slow-list-threshold", "Stretch the interval between terminations when listing pods repeatedly takes longer than this or gets throttled. Set to 0s to only react to throttling.").Envar(cliEnvVar("SLOW_LIST_THRESHOLD")).Default("10s").DurationVar(&slowListThreshold)
+	kingpin.Flag("selection-strategy", "How to select victims from the candidates: uniform (at random), weighted (at random, by the chaoskube.io/weight annotation), spread (at random, across as many nodes as possible), oldest-first, round-robin (at random, cycling through namespaces), least-recently-killed (by owner, limited by --history-size), stratified (one pod per owner, proportionally to owner size), node (all pods of a random node, up to --max-kill), age-weighted (at random, by age, see --age-weight-exponent) or node-pressure (at random, by the CPU or memory usage of their nodes as reported by metrics-server).").Envar(cliEnvVar("SELECTION_STRATEGY")).Default("uniform").EnumVar(&selectionStrategy, "uniform", "weighted", "spread", "oldest-first", "round-robin", "least-recently-killed", "stratified", "node", "age-weighted", "node-pressure")
+	kingpin.Flag("seed", "Seed for the random source used to select victims, to reproduce a previous run. Defaults to a random seed, which is logged at startup.").Envar(cliEnvVar("SEED")).Int64Var(&seed)
+	kingpin.Flag("record-file", "Append the candidates and victims of each interval to this file, as JSON lines, to replay them later.").Envar(cliEnvVar("RECORD_FILE")).StringVar(&recordFile)
+	kingpin.Flag("replay-file", "Select victims from the candidates recorded in this file instead of the cluster, one interval per recorded interval. Use with --dry-run or a test cluster.").Envar(cliEnvVar("REPLAY_FILE")).StringVar(&replayFile)
+	kingpin.Flag("namespace-levels", "Scale the selection probability of namespaces by their chaos.alpha.kubernetes.io/level annotation, e.g. 0.5 or 2.0.").Envar(cliEnvVar("NAMESPACE_LEVELS")).BoolVar(&namespaceLevels)
+	kingpin.Flag("snooze", "Skip pods while they, their owner, e.g. a Deployment, or their namespace carry a chaos.alpha.kubernetes.io/snooze-until annotation in the future.").Envar(cliEnvVar("SNOOZE")).BoolVar(&snooze)
+	kingpin.Flag("age-weight-exponent", "Exponent applied to the age of pods in hours for the age-weighted selection strategy, e.g. 1 for a linear and 2 for a quadratic preference of older pods.").Envar(cliEnvVar("AGE_WEIGHT_EXPONENT")).Default("1").Float64Var(&ageWeightExponent)
+	kingpin.Flag("workload-intervals", "Terminate pods of workloads, e.g. Deployments, at most as often as their chaos.alpha.kubernetes.io/min-interval annotation allows, e.g. 24h. Limited by --history-size.").Envar(cliEnvVar("WORKLOAD_INTERVALS")).BoolVar(&workloadIntervals)
+	kingpin.Flag("profile", "A preset of defaults for the interval, max-kill, grace period, protected namespaces and safety gates: conservative, standard or aggressive. Individual flags override the preset. Dry-run mode stays on regardless.").Envar(cliEnvVar("PROFILE")).EnumVar(&profileName, profile.Names()...)
+	kingpin.Flag("validate", "Validate the configuration against the cluster, print the number of candidates and exit without terminating any pod. Exits non-zero on problems.").Envar(cliEnvVar("VALIDATE")).BoolVar(&validate)
+	kingpin.Flag("virtual-nodes", "How to treat pods on virtual nodes, e.g. virtual-kubelet or AWS Fargate. Options are include, exclude and only. Not supported with --metadata-only.").Envar(cliEnvVar("VIRTUAL_NODES")).Default(chaoskube.VirtualNodesInclude).EnumVar(&virtualNodes, chaoskube.VirtualNodesInclude, chaoskube.VirtualNodesExclude, chaoskube.VirtualNodesOnly)
+	kingpin.Flag("node-label-selector", "A set of labels to restrict the list of affected pods to pods on matching nodes, e.g. a spot node pool. Not supported with --metadata-only.").Envar(cliEnvVar("NODE_LABEL_SELECTOR")).StringVar(&nodeLabelString)
+	kingpin.Flag("node-name-regexp", "Regular expression that defines on which nodes to affect pods. Not supported with --metadata-only.").Envar(cliEnvVar("NODE_NAME_REGEXP")).RegexpVar(&nodeNames)
+	kingpin.Flag("min-owner-ready", "Skip pods whose top-level workload, e.g. a Deployment, has fewer ready replicas than this, so that the last healthy replicas of a service are left alone. Defaults to 0, which disables the check.").Envar(cliEnvVar("MIN_OWNER_READY")).Default("0").IntVar(&minOwnerReady)
+	kingpin.Flag("preserve-zone-coverage", "Never terminate the last pods of an owner in a topology zone, by the topology.kubernetes.io/zone label of nodes, so that owners keep covering all of their zones. Not supported with --metadata-only.").Envar(cliEnvVar("PRESERVE_ZONE_COVERAGE")).BoolVar(&preserveZones)
+	kingpin.Flag("skip-rollouts", "Skip pods whose Deployment, StatefulSet or DaemonSet is rolling out a change, since terminations mid-deploy create noise and can mask rollout failures.").Envar(cliEnvVar("SKIP_ROLLOUTS")).BoolVar(&skipRollouts)
+	kingpin.Flag("exclude-pods-with-pvc", "Skip pods mounting PersistentVolumeClaims, including generic ephemeral volumes. Not supported with --metadata-only.").Envar(cliEnvVar("EXCLUDE_PODS_WITH_PVC")).BoolVar(&excludePVCs)
+	kingpin.Flag("pvc-access-modes", "A comma-separated list of access modes, e.g. ReadWriteOnce,ReadWriteOncePod, to restrict --exclude-pods-with-pvc to claims with one of them. Defaults to all claims.").Envar(cliEnvVar("PVC_ACCESS_MODES")).StringVar(&pvcAccessModes)
+	kingpin.Flag("protect-sole-endpoints", "Skip pods that are the only ready endpoint of a Service, so that singleton services without a disruption budget don't suffer full outages.").Envar(cliEnvVar("PROTECT_SOLE_ENDPOINTS")).BoolVar(&protectSoleEndpoints)
+	kingpin.Flag("default-opt-in", "Target pods unless they or their namespace opt out with the chaos.alpha.kubernetes.io/enabled=false annotation. Use --no-default-opt-in to only target pods that opt in with true instead.").Envar(cliEnvVar("DEFAULT_OPT_IN")).Default("true").BoolVar(&defaultOptIn)
+	kingpin.Flag("exclude-daemonsets", "Exclude pods controlled by a DaemonSet. Use --no-exclude-daemonsets to include them.").Envar(cliEnvVar("EXCLUDE_DAEMONSETS")).Default("true").BoolVar(&excludeDaemonSets)
+	kingpin.Flag("exclude-static-pods", "Exclude static pods (mirror pods). Use --no-exclude-static-pods to include them.").Envar(cliEnvVar("EXCLUDE_STATIC_PODS")).Default("true").BoolVar(&excludeStaticPods)
+	kingpin.Flag("respect-do-not-disrupt", "Exclude pods annotated with cluster-autoscaler.kubernetes.io/safe-to-evict=false or karpenter.sh/do-not-disrupt=true. Use --no-respect-do-not-disrupt to include them.").Envar(cliEnvVar("RESPECT_DO_NOT_DISRUPT")).Default("true").BoolVar(&respectDoNotDisrupt)
+	kingpin.Flag("enforce-max-lifetime", "Terminate pods older than the maximum lifetime they declare with the chaos.alpha.kubernetes.io/max-lifetime annotation, e.g. 72h, in each interval. They count towards --max-kill, leaving fewer random victims. At most --max-kill-per-owner pods of an owner are recycled at once.").Envar(cliEnvVar("ENFORCE_MAX_LIFETIME")).BoolVar(&enforceMaxLifetime)
+	kingpin.Flag("qos-classes", "A set of QoS classes to restrict the list of affected pods, e.g. burstable,besteffort or !guaranteed. Defaults to everything. Not supported with --metadata-only.").Envar(cliEnvVar("QOS_CLASSES")).StringVar(&qosClassString)
+	kingpin.Flag("included-priority-classes", "A comma-separated list of priority classes whose pods to include. All included by default. Not supported with --metadata-only.").Envar(cliEnvVar("INCLUDED_PRIORITY_CLASSES")).StringVar(&includedPriorities)
+	kingpin.Flag("excluded-priority-classes", "A comma-separated list of priority classes whose pods to exclude, e.g. system-cluster-critical,system-node-critical. None excluded by default. Not supported with --metadata-only.").Envar(cliEnvVar("EXCLUDED_PRIORITY_CLASSES")).StringVar(&excludedPriorities)
+	kingpin.Flag("included-service-accounts", "A comma-separated list of service accounts whose pods to include. All included by default. Not supported with --metadata-only.").Envar(cliEnvVar("INCLUDED_SERVICE_ACCOUNTS")).StringVar(&includedSAs)
+	kingpin.Flag("excluded-service-accounts", "A comma-separated list of service accounts whose pods to exclude, e.g. those of operators and controllers. None excluded by default. Not supported with --metadata-only.").Envar(cliEnvVar("EXCLUDED_SERVICE_ACCOUNTS")).StringVar(&excludedSAs)
+	kingpin.Flag("only-ready-pods", "Only terminate pods whose Ready condition is true, since terminating unhealthy pods wastes chaos and skews experiments. Not supported with --metadata-only.").Envar(cliEnvVar("ONLY_READY_PODS")).BoolVar(&onlyReadyPods)
+	kingpin.Flag("min-cpu-request", "Only terminate pods whose containers request at least this much CPU in total, e.g. 500m. Not supported with --metadata-only.").Envar(cliEnvVar("MIN_CPU_REQUEST")).StringVar(&minCPURequest)
+	kingpin.Flag("max-cpu-request", "Only terminate pods whose containers request at most this much CPU in total, e.g. 2. Not supported with --metadata-only.").Envar(cliEnvVar("MAX_CPU_REQUEST")).StringVar(&maxCPURequest)
+	kingpin.Flag("min-memory-request", "Only terminate pods whose containers request at least this much memory in total, e.g. 256Mi. Not supported with --metadata-only.").Envar(cliEnvVar("MIN_MEMORY_REQUEST")).StringVar(&minMemoryRequest)
+	kingpin.Flag("max-memory-request", "Only terminate pods whose containers request at most this much memory in total, e.g. 1Gi. Not supported with --metadata-only.").Envar(cliEnvVar("MAX_MEMORY_REQUEST")).StringVar(&maxMemoryRequest)
+	kingpin.Flag("max-container-restarts", "Skip pods in CrashLoopBackOff or with a container that restarted more than this many times within --restart-window. Negative values disable the check. Not supported with --metadata-only.").Envar(cliEnvVar("MAX_CONTAINER_RESTARTS")).Default("-1").IntVar(&maxRestarts)
+	kingpin.Flag("restart-window", "How recently a container must have last restarted to count towards --max-container-restarts.").Envar(cliEnvVar("RESTART_WINDOW")).Default("1h").DurationVar(&restartWindow)
+	kingpin.Flag("statefulsets", "In which order to target the pods of StatefulSets. Options are any, highest-ordinal (only the pod with the highest ordinal) and descending (from the highest to the lowest ordinal across intervals).").Envar(cliEnvVar("STATEFULSETS")).Default(chaoskube.StatefulSetsAny).EnumVar(&statefulSets, chaoskube.StatefulSetsAny, chaoskube.StatefulSetsHighestOrdinal, chaoskube.StatefulSetsDescending)
+	kingpin.Flag("job-progress-threshold", "Skip pods of Jobs that progressed this far towards their completions or active deadline, e.g. 0.9. Defaults to 0, which disables the check.").Envar(cliEnvVar("JOB_PROGRESS_THRESHOLD")).Default("0").Float64Var(&jobProgressThreshold)
+	kingpin.Flag("colocated-blast", "Number of further candidates running on the same node to terminate along with each victim, to simulate correlated failures. 0 disables it.").Envar(cliEnvVar("COLOCATED_BLAST")).Default("0").IntVar(&colocatedBlast)
+	kingpin.Flag("stamp-owners", "Annotate the top-level workload of each victim with the time, run ID and result of the termination.").Envar(cliEnvVar("STAMP_OWNERS")).BoolVar(&stampOwners)
+	kingpin.Flag("run-id", "Identifier of this run in the annotations stamped by --stamp-owners. Defaults to a random UUID.").Envar(cliEnvVar("RUN_ID")).StringVar(&runID)
+	kingpin.Flag("any-labels", "A set of labels of which any given set must match, since --labels cannot express disjunction. Repeat the flag to OR several sets, e.g. --any-labels app=foo --any-labels team=bar.").Envar(cliEnvVar("ANY_LABELS")).StringsVar(&anyLabelStrings)
+	kingpin.Flag("annotation-regex", "Only include pods whose annotation matches the given regular expression, e.g. owner-team=^payments-.*. Repeat the flag to require several annotations.").Envar(cliEnvVar("ANNOTATION_REGEX")).StringMapVar(&annotationRegexes)
+	kingpin.Flag("tui", "Show a live terminal dashboard with the candidates, the countdown to the next termination and recent victims instead of logs. Press p to pause or resume and q to quit.").Envar(cliEnvVar("TUI")).BoolVar(&tuiEnabled)
+	kingpin.Flag("log-tail-lines", "Number of log lines per container to fetch from each victim right before its termination and include in notifications. Defaults to 0, which disables it.").Envar(cliEnvVar("LOG_TAIL_LINES")).Default("0").Int64Var(&logTailLines)
+	kingpin.Flag("snapshot-dir", "Directory to save the full manifest and events of each victim to right before its termination, e.g. a volume backed by object storage.").Envar(cliEnvVar("SNAPSHOT_DIR")).StringVar(&snapshotDir)
+	kingpin.Flag("pushgateway-address", "Address of a Prometheus Pushgateway to push the final metrics to when chaoskube exits, e.g. at the end of --max-runtime in a CronJob.").Envar(cliEnvVar("PUSHGATEWAY_ADDRESS")).StringVar(&pushgatewayAddress)
+	kingpin.Flag("pushgateway-job", "Job name to push the metrics under to the Pushgateway.").Envar(cliEnvVar("PUSHGATEWAY_JOB")).Default("chaoskube").StringVar(&pushgatewayJob)
+	kingpin.Flag("slack-signing-secret", "Signing secret of the Slack app owning --slack-webhook. Enables proposing victims in Slack and only terminating them once approved. Point the interactivity request URL of the app to /slack/interactions on the metrics address.").Envar(cliEnvVar("SLACK_SIGNING_SECRET")).StringVar(&slackSigningSecret)
+	kingpin.Flag("approval-timeout", "How long to wait for a decision on proposed victims in Slack.").Envar(cliEnvVar("APPROVAL_TIMEOUT")).Default("5m").DurationVar(&approvalTimeout)
+	kingpin.Flag("approval-default", "What to do with proposed victims when nobody decides within --approval-timeout. Options are skip and approve.").Envar(cliEnvVar("APPROVAL_DEFAULT")).Default("skip").EnumVar(&approvalDefault, "skip", "approve")
+	kingpin.Flag("watchdog-threshold", "Report chaoskube as stalled when no interval completed, or no pod was terminated although there were candidates, for this long. Defaults to 0, which disables the watchdog.").Envar(cliEnvVar("WATCHDOG_THRESHOLD")).Default("0").DurationVar(&watchdogThreshold)
+	kingpin.Flag("janitor-interval", "Clean up pods in bad terminal states within the selected pods at this interval, independent of chaos. Defaults to 0, which disables the janitor.").Envar(cliEnvVar("JANITOR_INTERVAL")).Default("0").DurationVar(&janitorInterval)
+	kingpin.Flag("janitor-stuck-after", "Let the janitor force-delete pods still terminating this long after their deletion. Set to 0 to leave them alone.").Envar(cliEnvVar("JANITOR_STUCK_AFTER")).Default("1h").DurationVar(&janitorStuckAfter)
+	kingpin.Flag("janitor-linger-after", "Let the janitor delete pods lingering in the Failed, Succeeded or Unknown phase for this long. Set to 0 to leave them alone.").Envar(cliEnvVar("JANITOR_LINGER_AFTER")).Default("24h").DurationVar(&janitorLingerAfter)
+	kingpin.Flag("max-kills-per-hour", "Maximum number of pods to terminate within any hour, across intervals and triggers. Defaults to 0, which means unlimited.").Envar(cliEnvVar("MAX_KILLS_PER_HOUR")).Default("0").IntVar(&maxKillsPerHour)
+	kingpin.Flag("max-kills-per-day", "Maximum number of pods to terminate within any 24 hours, across intervals and triggers. Defaults to 0, which means unlimited.").Envar(cliEnvVar("MAX_KILLS_PER_DAY")).Default("0").IntVar(&maxKillsPerDay)
+	kingpin.Flag("budget-file", "File to persist the terminations counted by --max-kills-per-hour and --max-kills-per-day to, e.g. on a volume, so that restarts don't reset the budget.").Envar(cliEnvVar("BUDGET_FILE")).StringVar(&budgetFile)
+	kingpin.Flag("server-dry-run", "In dry-run mode, send deletions to the API server with the server-side dry-run option instead of skipping them, so they are validated by admission webhooks and RBAC exactly like a real termination. Only supported with --terminator=delete-pod.").Envar(cliEnvVar("SERVER_DRY_RUN")).BoolVar(&serverDryRun)
+	kingpin.Flag("terminator", "How to terminate victims. Options are delete-pod, kill-container, which kills the main process of a random container of the victim instead of deleting it, drain-node, which cordons and drains the victim's node, rollout-restart, which restarts the victim's Deployment, StatefulSet or DaemonSet, network-chaos, which degrades the victim's network for a while, stress, which puts CPU and memory load on the victim for a while, evict-pod, which evicts the victim while respecting PodDisruptionBudgets, scale-to-zero, which scales the victim's Deployment or StatefulSet to zero and back, dns-chaos, which breaks the victim's name resolution for a while, and exec:<command>, which runs a command with the victim's metadata as JSON on stdin. Repeat the flag to combine terminators, optionally weighted, e.g. --terminator delete-pod=80 --terminator drain-node=20.").Envar(cliEnvVar("TERMINATOR")).Default(terminatorDeletePod).StringsVar(&terminatorSpecs)
+	kingpin.Flag("terminator-mode", "How to combine several terminators. Options are random, which picks one per victim in proportion to the weights, and sequence, which applies all of them in order.").Envar(cliEnvVar("TERMINATOR_MODE")).Default(terminatorModeRandom).EnumVar(&terminatorMode, terminatorModeRandom, terminatorModeSequence)
+	kingpin.Flag("container-signal", "Signal to send to the main process of a container with --terminator=kill-container. PID 1 only receives signals it handles when sent from within its container, which rules out KILL.").Envar(cliEnvVar("CONTAINER_SIGNAL")).Default("TERM").StringVar(&containerSignal)
+	kingpin.Flag("signal-all-containers", "Send --container-signal to all containers of the victim instead of a random one, which exercises the graceful shutdown of the whole pod without deleting it.").Envar(cliEnvVar("SIGNAL_ALL_CONTAINERS")).BoolVar(&signalAll)
+	kingpin.Flag("drain-grace-period", "Grace period of the evictions with --terminator=drain-node. Negative values will use the Pod's grace period.").Envar(cliEnvVar("DRAIN_GRACE_PERIOD")).Default("-1s").DurationVar(&drainGracePeriod)
+	kingpin.Flag("uncordon-after", "How long a node drained with --terminator=drain-node or cordoned with --cordon-before-kill stays cordoned. Nodes still cordoned when chaoskube starts or exits are uncordoned right away. Zero leaves it cordoned.").Envar(cliEnvVar("UNCORDON_AFTER")).Default("10m").DurationVar(&uncordonAfter)
+	kingpin.Flag("cordon-before-kill", "Cordon the victim's node before terminating the victim, so that its replacement must be scheduled on another node. See --uncordon-after.").Envar(cliEnvVar("CORDON_BEFORE_KILL")).BoolVar(&cordonBeforeKill)
+	kingpin.Flag("force-delete-after", "Force-delete victims that are still terminating after this duration, e.g. due to stuck finalizers. Zero disables it.").Envar(cliEnvVar("FORCE_DELETE_AFTER")).Default("0s").DurationVar(&forceDeleteAfter)
+	kingpin.Flag("network-latency", "Latency to add to the victim's outgoing packets with --terminator=network-chaos.").Envar(cliEnvVar("NETWORK_LATENCY")).Default("0s").DurationVar(&networkLatency)
+	kingpin.Flag("network-jitter", "Random variation of the latency with --terminator=network-chaos.").Envar(cliEnvVar("NETWORK_JITTER")).Default("0s").DurationVar(&networkJitter)
+	kingpin.Flag("network-loss", "Percentage of the victim's outgoing packets to drop with --terminator=network-chaos.").Envar(cliEnvVar("NETWORK_LOSS")).Default("0").Float64Var(&networkLoss)
+	kingpin.Flag("network-chaos-duration", "How long to degrade the victim's network with --terminator=network-chaos or dns-chaos.").Envar(cliEnvVar("NETWORK_CHAOS_DURATION")).Default("1m").DurationVar(&networkChaosFor)
+	kingpin.Flag("network-chaos-image", "Image of the ephemeral container applying the fault with --terminator=network-chaos or dns-chaos. It must provide sh, sleep, tc and iptables.").Envar(cliEnvVar("NETWORK_CHAOS_IMAGE")).Default("nicolaka/netshoot").StringVar(&networkChaosImage)
+	kingpin.Flag("stress-cpu-workers", "Number of workers spinning on the CPU with --terminator=stress.").Envar(cliEnvVar("STRESS_CPU_WORKERS")).Default("0").IntVar(&stressCPUWorkers)
+	kingpin.Flag("stress-memory", "Amount of memory to allocate with --terminator=stress, e.g. 256MB.").Envar(cliEnvVar("STRESS_MEMORY")).Default("0B").BytesVar(&stressMemory)
+	kingpin.Flag("stress-duration", "How long to stress the victim with --terminator=stress.").Envar(cliEnvVar("STRESS_DURATION")).Default("5m").DurationVar(&stressDuration)
+	kingpin.Flag("stress-image", "Image of the ephemeral container putting load on the victim with --terminator=stress. It must provide stress-ng.").Envar(cliEnvVar("STRESS_IMAGE")).Default("ghcr.io/colinianking/stress-ng").StringVar(&stressImage)
+	kingpin.Flag("pdb-timeout", "How long to retry evictions blocked by a PodDisruptionBudget with --terminator=evict-pod.").Envar(cliEnvVar("PDB_TIMEOUT")).Default("1m").DurationVar(&pdbTimeout)
+	kingpin.Flag("pdb-fallback", "What to do with victims whose eviction is still blocked after --pdb-timeout. Options are skip and delete, which deletes them regardless of the budget.").Envar(cliEnvVar("PDB_FALLBACK")).Default(pdbFallbackSkip).EnumVar(&pdbFallback, pdbFallbackSkip, pdbFallbackDelete)
+	kingpin.Flag("scale-to-zero-pause", "How long workloads stay scaled to zero with --terminator=scale-to-zero.").Envar(cliEnvVar("SCALE_TO_ZERO_PAUSE")).Default("5m").DurationVar(&scaleToZeroPause)
+}
+
+// profileFromArgs returns the name of the profile given by flag or environment variable,
+// ahead of parsing all flags.
+func profileFromArgs(args []string) string {
+	name := os.Getenv(cliEnvVar("PROFILE"))
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, "--profile="); ok {
+			name = value
+		}
+		if arg == "--profile" && i+1 < len(args) {
+			name = args[i+1]
+		}
+	}
+	return name
 }
 
 func main() {
 	kingpin.Version(version)
+
+	// a profile only changes the defaults, so it must be applied before parsing the flags
+	defaults, err := profile.Defaults(profileFromArgs(os.Args[1:]))
+	if err != nil {
+		kingpin.Fatalf("%s", err)
+	}
+	for flag, value := range defaults {
+		kingpin.CommandLine.GetFlag(flag).Default(value)
+	}
+
 	kingpin.Parse()
 
 	if debug {
@@ -121,32 +449,159 @@ func main() {
 	log.SetReportCaller(logCaller)
 
 	log.WithFields(log.Fields{
-		"labels":                 labelString,
+		"labels":                 labelStrings,
 		"annotations":            annString,
 		"kinds":                  kindsString,
 		"namespaces":             nsString,
 		"namespaceLabels":        nsLabelString,
+		"helmRelease":            helmRelease,
+		"helmChart":              helmChart,
+		"includedNsNames":        includedNsNames,
+		"excludedNsNames":        excludedNsNames,
 		"includedPodNames":       includedPodNames,
+		"includedImages":         includedImages,
+		"excludedImages":         excludedImages,
 		"excludedPodNames":       excludedPodNames,
 		"excludedWeekdays":       excludedWeekdays,
 		"excludedTimesOfDay":     excludedTimesOfDay,
+		"excludedDaysCalendar":   excludedDaysCalendar,
+		"calendarRefresh":        calendarRefresh,
+		"includedWeekdays":       includedWeekdays,
+		"includedTimesOfDay":     includedTimesOfDay,
 		"excludedDaysOfYear":     excludedDaysOfYear,
 		"timezone":               timezone,
 		"minimumAge":             minimumAge,
 		"maxRuntime":             maxRuntime,
 		"maxKill":                maxKill,
+		"maxKillPerOwner":        maxKillPerOwner,
+		"maxKillMin":             maxKillMin,
+		"maxKillMax":             maxKillMax,
+		"byDeletionCost":         byDeletionCost,
 		"master":                 master,
 		"kubeconfig":             kubeconfig,
 		"interval":               interval,
+		"schedule":               schedule,
+		"intervalOverrides":      intervalOverrides,
+		"poisson":                poisson,
 		"dynamicIntervalEnabled": dynamicIntervalEnabled,
 		"dynamicIntervalFactor":  dynamicIntervalFactor,
+		"dynamicWorkingDays":     dynamicWorkingDays,
+		"dynamicWorkingHours":    dynamicWorkingHours,
+		"dynamicTarget":          dynamicTarget,
+		"dynamicSmoothing":       dynamicSmoothing,
+		"dynamicSamplePeriod":    dynamicSamplePeriod,
 		"dryRun":                 dryRun,
 		"debug":                  debug,
 		"metricsAddress":         metricsAddress,
 		"gracePeriod":            gracePeriod,
+		"gracePeriodMin":         gracePeriodMin,
+		"gracePeriodMax":         gracePeriodMax,
 		"logFormat":              logFormat,
 		"slackWebhook":           slackWebhook,
 		"clientNamespaceScope":   clientNamespaceScope,
+		"triggerOnRollout":       triggerOnRollout,
+		"triggerDelay":           triggerDelay,
+		"webhookRateLimit":       webhookRateLimit,
+		"webhookMaxWithin":       webhookMaxWithin,
+		"webhookKubernetesAuth":  webhookKubernetesAuth,
+		"prometheusAddress":      prometheusAddress,
+		"candidatePromQL":        candidatePromQL,
+		"candidateQuery":         candidateQuery,
+		"opencostAddress":        opencostAddress,
+		"opencostWindow":         opencostWindow,
+		"costWeighting":          costWeighting,
+		"maxCostPerDay":          maxCostPerDay,
+		"argoRollouts":           argoRollouts,
+		"pauseDuringCanary":      pauseDuringCanary,
+		"informerCache":          informerCache,
+		"fieldSelector":          fieldSelectorString,
+		"terminationWorkers":     terminationWorkers,
+		"clientQPS":              clientQPS,
+		"clientBurst":            clientBurst,
+		"clientMaxBackoff":       clientMaxBackoff,
+		"metadataOnly":           metadataOnly,
+		"listParallelism":        listParallelism,
+		"historySize":            historySize,
+		"cooldown":               cooldown,
+		"slowListThreshold":      slowListThreshold,
+		"selectionStrategy":      selectionStrategy,
+		"seed":                   seed,
+		"recordFile":             recordFile,
+		"replayFile":             replayFile,
+		"namespaceLevels":        namespaceLevels,
+		"snooze":                 snooze,
+		"ageWeightExponent":      ageWeightExponent,
+		"workloadIntervals":      workloadIntervals,
+		"profileName":            profileName,
+		"validate":               validate,
+		"virtualNodes":           virtualNodes,
+		"nodeLabelSelector":      nodeLabelString,
+		"nodeNameRegexp":         nodeNames,
+		"minOwnerReady":          minOwnerReady,
+		"preserveZones":          preserveZones,
+		"skipRollouts":           skipRollouts,
+		"excludePVCs":            excludePVCs,
+		"pvcAccessModes":         pvcAccessModes,
+		"protectSoleEndpoints":   protectSoleEndpoints,
+		"defaultOptIn":           defaultOptIn,
+		"excludeDaemonSets":      excludeDaemonSets,
+		"excludeStaticPods":      excludeStaticPods,
+		"respectDoNotDisrupt":    respectDoNotDisrupt,
+		"enforceMaxLifetime":     enforceMaxLifetime,
+		"qosClasses":             qosClassString,
+		"includedPriorities":     includedPriorities,
+		"excludedPriorities":     excludedPriorities,
+		"includedSAs":            includedSAs,
+		"excludedSAs":            excludedSAs,
+		"onlyReadyPods":          onlyReadyPods,
+		"minCPURequest":          minCPURequest,
+		"maxCPURequest":          maxCPURequest,
+		"minMemoryRequest":       minMemoryRequest,
+		"maxMemoryRequest":       maxMemoryRequest,
+		"maxContainerRestarts":   maxRestarts,
+		"restartWindow":          restartWindow,
+		"statefulSets":           statefulSets,
+		"jobProgressThreshold":   jobProgressThreshold,
+		"colocatedBlast":         colocatedBlast,
+		"stampOwners":            stampOwners,
+		"runID":                  runID,
+		"anyLabels":              anyLabelStrings,
+		"annotationRegex":        annotationRegexes,
+		"tui":                    tuiEnabled,
+		"logTailLines":           logTailLines,
+		"snapshotDir":            snapshotDir,
+		"pushgatewayAddress":     pushgatewayAddress,
+		"pushgatewayJob":         pushgatewayJob,
+		"approvalTimeout":        approvalTimeout,
+		"approvalDefault":        approvalDefault,
+		"watchdogThreshold":      watchdogThreshold,
+		"janitorInterval":        janitorInterval,
+		"janitorStuckAfter":      janitorStuckAfter,
+		"janitorLingerAfter":     janitorLingerAfter,
+		"maxKillsPerHour":        maxKillsPerHour,
+		"maxKillsPerDay":         maxKillsPerDay,
+		"budgetFile":             budgetFile,
+		"serverDryRun":           serverDryRun,
+		"terminators":            terminatorSpecs,
+		"terminatorMode":         terminatorMode,
+		"containerSignal":        containerSignal,
+		"signalAllContainers":    signalAll,
+		"drainGracePeriod":       drainGracePeriod,
+		"uncordonAfter":          uncordonAfter,
+		"cordonBeforeKill":       cordonBeforeKill,
+		"forceDeleteAfter":       forceDeleteAfter,
+		"networkLatency":         networkLatency,
+		"networkJitter":          networkJitter,
+		"networkLoss":            networkLoss,
+		"networkChaosDuration":   networkChaosFor,
+		"networkChaosImage":      networkChaosImage,
+		"stressCPUWorkers":       stressCPUWorkers,
+		"stressMemory":           stressMemory,
+		"stressDuration":         stressDuration,
+		"stressImage":            stressImage,
+		"pdbTimeout":             pdbTimeout,
+		"pdbFallback":            pdbFallback,
+		"scaleToZeroPause":       scaleToZeroPause,
 	}).Debug("reading config")
 
 	log.WithFields(log.Fields{
@@ -158,27 +613,115 @@ func main() {
 		"maxRuntime":            maxRuntime,
 	}).Info("starting up")
 
-	client, err := newClient()
+	config, err := newConfig()
+	if err != nil {
+		log.WithField("err", err).Fatal("failed to load cluster config")
+	}
+
+	client, err := newClient(config)
 	if err != nil {
 		log.WithField("err", err).Fatal("failed to connect to cluster")
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		log.WithField("err", err).Fatal("failed to create dynamic client")
+	}
+
+	metadataClient, err := metadata.NewForConfig(config)
+	if err != nil {
+		log.WithField("err", err).Fatal("failed to create metadata client")
+	}
+
 	var (
-		labelSelector   = parseSelector(labelString)
 		annotations     = parseSelector(annString)
-		kinds           = parseSelector(kindsString)
+		kinds           = parseKinds(kindsString)
 		namespaces      = parseSelector(nsString)
 		namespaceLabels = parseSelector(nsLabelString)
+		fieldSelector   = parseFieldSelector(fieldSelectorString)
+		nodeLabels      = parseSelector(nodeLabelString)
+		qosClasses      = parseSelector(strings.ToLower(qosClassString))
+		anyLabels       = []labels.Selector{}
 	)
 
+	for _, str := range anyLabelStrings {
+		anyLabels = append(anyLabels, parseSelector(str))
+	}
+
+	labelSelector, orLabels, err := util.ParseLabelSelectors(labelStrings)
+	if err != nil {
+		log.WithField("err", err).Fatal("failed to parse labels")
+	}
+	// several sets of labels are ORed on the client, just like --any-labels
+	if len(orLabels) > 0 {
+		if len(anyLabels) > 0 {
+			log.Fatal("--labels can only be repeated without --any-labels")
+		}
+		anyLabels = orLabels
+	}
+
+	maxKillCount, maxKillPercent, err := util.ParseMaxKill(maxKill)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"maxKill": maxKill,
+			"err":     err,
+		}).Fatal("failed to parse max kill")
+	}
+	if metadataOnly {
+		if unsupported := metadataOnlyUnsupported(); len(unsupported) > 0 {
+			log.WithField("flags", unsupported).Fatal("--metadata-only lacks the pod spec and status these flags depend on")
+		}
+	}
+	if serverDryRun && !serverDryRunSupported() {
+		log.Fatal("--server-dry-run only supports --terminator=delete-pod without --cordon-before-kill and --force-delete-after, other terminators would cause real chaos")
+	}
+	if maxKillMax > 0 && maxKillMax < maxKillMin {
+		log.Fatal("--max-kill-max must be at least --max-kill-min")
+	}
+
+	if dynamicWorkingDays <= 0 || dynamicWorkingDays > 7 {
+		log.Fatal("--dynamic-interval-working-days must be greater than zero and at most 7")
+	}
+	if dynamicWorkingHours <= 0 || dynamicWorkingHours > 24 {
+		log.Fatal("--dynamic-interval-working-hours must be greater than zero and at most 24")
+	}
+	if dynamicTarget <= 0 {
+		log.Fatal("--dynamic-interval-target must be greater than zero")
+	}
+	if dynamicSmoothing < 0 || dynamicSmoothing > 1 {
+		log.Fatal("--dynamic-interval-smoothing must be between 0 and 1")
+	}
+
+	annotationPatterns := map[string]*regexp.Regexp{}
+	for key, str := range annotationRegexes {
+		pattern, err := regexp.Compile(str)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"annotation": key,
+				"regex":      str,
+				"err":        err,
+			}).Fatal("failed to parse annotation regex")
+		}
+		annotationPatterns[key] = pattern
+	}
+
 	log.WithFields(log.Fields{
 		"labels":           labelSelector.String(),
+		"anyLabels":        anyLabelStrings,
 		"annotations":      annotations.String(),
+		"annotationRegex":  annotationRegexes,
 		"kinds":            kinds.String(),
 		"namespaces":       namespaces.String(),
 		"namespaceLabels":  namespaceLabels.String(),
+		"fieldSelector":    fieldSelector.String(),
+		"helmRelease":      helmRelease,
+		"helmChart":        helmChart,
+		"includedNsNames":  includedNsNames,
+		"excludedNsNames":  excludedNsNames,
 		"includedPodNames": includedPodNames,
 		"excludedPodNames": excludedPodNames,
+		"includedImages":   includedImages,
+		"excludedImages":   excludedImages,
 		"minimumAge":       minimumAge,
 		"maxKill":          maxKill,
 	}).Info("setting pod filter")
@@ -191,6 +734,14 @@ func main() {
 			"err":        err,
 		}).Fatal("failed to parse times of day")
 	}
+	parsedIncludedWeekdays := util.ParseWeekdays(includedWeekdays)
+	parsedIncludedTimes, err := util.ParseTimePeriods(includedTimesOfDay)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"timesOfDay": includedTimesOfDay,
+			"err":        err,
+		}).Fatal("failed to parse times of day")
+	}
 	parsedDaysOfYear, err := util.ParseDays(excludedDaysOfYear)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -205,6 +756,13 @@ func main() {
 		"daysOfYear": util.FormatDays(parsedDaysOfYear),
 	}).Info("setting quiet times")
 
+	if len(parsedIncludedWeekdays) > 0 || len(parsedIncludedTimes) > 0 {
+		log.WithFields(log.Fields{
+			"weekdays":   parsedIncludedWeekdays,
+			"timesOfDay": includedTimesOfDay,
+		}).Info("setting chaos windows")
+	}
+
 	parsedTimezone, err := time.LoadLocation(timezone)
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -220,8 +778,54 @@ func main() {
 		"offset":   offset / int(time.Hour/time.Second),
 	}).Info("setting timezone")
 
+	var parsedSchedule *util.Schedule
+	if schedule != "" {
+		parsedSchedule, err = util.ParseSchedule(schedule)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"schedule": schedule,
+				"err":      err,
+			}).Fatal("failed to parse schedule")
+		}
+
+		log.WithFields(log.Fields{
+			"schedule": parsedSchedule,
+			"next":     parsedSchedule.Next(time.Now().In(parsedTimezone)),
+		}).Info("setting schedule")
+	}
+
+	parsedIntervalOverrides, err := util.ParseWeekdayDurations(intervalOverrides)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"intervalOverrides": intervalOverrides,
+			"err":               err,
+		}).Fatal("failed to parse interval overrides")
+	}
+
+	var holidays *calendar.Calendar
+	if excludedDaysCalendar != "" {
+		holidays = calendar.New(excludedDaysCalendar, parsedTimezone, log.StandardLogger())
+		if err := holidays.Refresh(context.Background()); err != nil {
+			log.WithFields(log.Fields{
+				"calendar": excludedDaysCalendar,
+				"err":      err,
+			}).Fatal("failed to read calendar")
+		}
+
+		log.WithFields(log.Fields{
+			"calendar": excludedDaysCalendar,
+			"days":     holidays.Days(),
+		}).Info("setting excluded days calendar")
+	}
+
 	notifiers := createNotifier()
 
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	log.WithField("seed", seed).Info("seeding random source")
+	rnd := util.NewRand(seed)
+
 	chaoskube := chaoskube.New(
 		client,
 		labelSelector,
@@ -229,8 +833,8 @@ func main() {
 		kinds,
 		namespaces,
 		namespaceLabels,
-		includedPodNames,
-		excludedPodNames,
+		util.MatchAny(includedPodNames),
+		util.MatchAny(excludedPodNames),
 		parsedWeekdays,
 		parsedTimesOfDay,
 		parsedDaysOfYear,
@@ -238,8 +842,8 @@ func main() {
 		minimumAge,
 		log.StandardLogger(),
 		dryRun,
-		terminator.NewDeletePodTerminator(client, log.StandardLogger(), gracePeriod),
-		maxKill,
+		createTerminator(client, config, rnd),
+		maxKillCount,
 		notifiers,
 		clientNamespaceScope,
 		dynamicIntervalEnabled,
@@ -247,6 +851,203 @@ func main() {
 		interval,
 	)
 
+	chaoskube.DynamicClient = dynamicClient
+	chaoskube.Schedule = parsedSchedule
+	chaoskube.Poisson = poisson
+	chaoskube.DynamicIntervalWorkingDays = dynamicWorkingDays
+	chaoskube.DynamicIntervalWorkingHours = dynamicWorkingHours
+	chaoskube.DynamicIntervalTarget = dynamicTarget
+	chaoskube.DynamicIntervalSmoothing = dynamicSmoothing
+	chaoskube.IntervalOverrides = parsedIntervalOverrides
+	if holidays != nil {
+		chaoskube.ExcludedDaysCalendar = holidays
+	}
+	chaoskube.IncludedWeekdays = parsedIncludedWeekdays
+	chaoskube.IncludedTimesOfDay = parsedIncludedTimes
+	chaoskube.HelmRelease = helmRelease
+	chaoskube.HelmChart = helmChart
+	chaoskube.IncludedNamespaceNames = includedNsNames
+	chaoskube.ExcludedNamespaceNames = excludedNsNames
+	chaoskube.IncludedImages = includedImages
+	chaoskube.ExcludedImages = excludedImages
+	chaoskube.MaxKillPercent = maxKillPercent
+	chaoskube.MaxKillMin = maxKillMin
+	chaoskube.MaxKillMax = maxKillMax
+	chaoskube.MaxKillPerOwner = maxKillPerOwner
+	chaoskube.PreferLowDeletionCost = byDeletionCost
+	chaoskube.ArgoRollouts = argoRollouts
+	chaoskube.PauseDuringCanary = pauseDuringCanary
+	chaoskube.VirtualNodes = virtualNodes
+	chaoskube.NodeLabels = nodeLabels
+	chaoskube.NodeNames = nodeNames
+	chaoskube.MinOwnerReady = minOwnerReady
+	chaoskube.PreserveZoneCoverage = preserveZones
+	chaoskube.SkipRollouts = skipRollouts
+	chaoskube.ExcludePVCs = excludePVCs
+	for _, mode := range util.ParseList(pvcAccessModes) {
+		chaoskube.PVCAccessModes = append(chaoskube.PVCAccessModes, v1.PersistentVolumeAccessMode(mode))
+	}
+	chaoskube.ProtectSoleEndpoints = protectSoleEndpoints
+	chaoskube.DefaultOptOut = !defaultOptIn
+	chaoskube.IncludeDaemonSets = !excludeDaemonSets
+	chaoskube.IncludeStaticPods = !excludeStaticPods
+	chaoskube.IgnoreDisruptionAnnotations = !respectDoNotDisrupt
+	chaoskube.EnforceMaxLifetime = enforceMaxLifetime
+	chaoskube.QOSClasses = qosClasses
+	chaoskube.IncludedPriorityClasses = util.ParseList(includedPriorities)
+	chaoskube.ExcludedPriorityClasses = util.ParseList(excludedPriorities)
+	chaoskube.IncludedServiceAccounts = util.ParseList(includedSAs)
+	chaoskube.ExcludedServiceAccounts = util.ParseList(excludedSAs)
+	chaoskube.OnlyReady = onlyReadyPods
+	chaoskube.MinRequests = parseResources(map[v1.ResourceName]string{v1.ResourceCPU: minCPURequest, v1.ResourceMemory: minMemoryRequest})
+	chaoskube.MaxRequests = parseResources(map[v1.ResourceName]string{v1.ResourceCPU: maxCPURequest, v1.ResourceMemory: maxMemoryRequest})
+	if maxRestarts >= 0 {
+		chaoskube.MaxContainerRestarts = maxRestarts
+		chaoskube.RestartWindow = restartWindow
+	}
+	chaoskube.StatefulSets = statefulSets
+	chaoskube.JobProgressThreshold = jobProgressThreshold
+	chaoskube.ColocatedBlast = colocatedBlast
+	chaoskube.AnyLabels = anyLabels
+	chaoskube.AnnotationPatterns = annotationPatterns
+	chaoskube.LogTailLines = logTailLines
+	chaoskube.ServerDryRun = serverDryRun
+	chaoskube.FieldSelector = fieldSelector
+	chaoskube.TerminationWorkers = terminationWorkers
+	chaoskube.SlowListThreshold = slowListThreshold
+	chaoskube.NamespaceLevels = namespaceLevels
+	chaoskube.Snooze = snooze
+
+	if stampOwners {
+		if runID == "" {
+			runID = string(uuid.NewUUID())
+		}
+		log.WithField("runID", runID).Info("stamping owners")
+	}
+	chaoskube.StampOwners = stampOwners
+	chaoskube.RunID = runID
+
+	chaoskube.Rand = rnd
+	// derived from the seed, so that the intervals are reproducible, too
+	chaoskube.IntervalRand = util.NewRand(seed + 1)
+	chaoskube.ListParallelism = listParallelism
+
+	if metadataOnly {
+		chaoskube.MetadataClient = metadataClient
+	}
+
+	if maxKillsPerHour > 0 || maxKillsPerDay > 0 {
+		killBudget, err := budget.New(maxKillsPerHour, maxKillsPerDay, budgetFile)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"budgetFile": budgetFile,
+				"err":        err,
+			}).Fatal("failed to load kill budget")
+		}
+		chaoskube.Budget = killBudget
+	}
+
+	chaoskube.History = history.New(historySize)
+	chaoskube.Cooldown = cooldown
+	chaoskube.WorkloadIntervals = workloadIntervals
+
+	selection, err := strategy.New(selectionStrategy, strategy.Options{
+		History:      chaoskube.History,
+		AgeExponent:  ageWeightExponent,
+		Now:          chaoskube.Now,
+		NodePressure: nodemetrics.NewClient(client, dynamicClient, log.StandardLogger()).Pressure,
+	})
+	if err != nil {
+		log.WithField("err", err).Fatal("failed to create selection strategy")
+	}
+	chaoskube.Strategy = selection
+
+	if recordFile != "" {
+		file, err := os.OpenFile(recordFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			log.WithField("err", err).Fatal("failed to open record file")
+		}
+		defer file.Close()
+		chaoskube.Recorder = replay.NewRecorder(file)
+	}
+
+	if snapshotDir != "" {
+		directory, err := snapshot.NewDirectory(snapshotDir)
+		if err != nil {
+			log.WithField("err", err).Fatal("failed to create snapshot directory")
+		}
+		chaoskube.Snapshots = directory
+	}
+
+	if replayFile != "" {
+		file, err := os.Open(replayFile)
+		if err != nil {
+			log.WithField("err", err).Fatal("failed to open replay file")
+		}
+		defer file.Close()
+		chaoskube.Player = replay.NewPlayer(file)
+	}
+
+	if candidatePromQL != "" {
+		query, err := promquery.NewPodQuery(prometheusAddress, candidatePromQL)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"address": prometheusAddress,
+				"err":     err,
+			}).Fatal("failed to create prometheus client")
+		}
+		chaoskube.CandidateQuery = query
+	}
+
+	if candidateQuery != "" {
+		query, err := promquery.NewTemplateQuery(prometheusAddress, candidateQuery)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"address": prometheusAddress,
+				"query":   candidateQuery,
+				"err":     err,
+			}).Fatal("failed to create candidate query")
+		}
+		chaoskube.CandidateMatcher = query
+	}
+
+	if opencostAddress != "" {
+		chaoskube.CostProvider = opencost.NewClient(opencostAddress, opencostWindow)
+		chaoskube.CostWeighting = costWeighting
+		chaoskube.MaxCostPerDay = maxCostPerDay
+	}
+
+	if slackSigningSecret != "" {
+		if slackWebhook == "" {
+			log.Fatal("--slack-signing-secret requires --slack-webhook")
+		}
+		approver := approval.NewSlack(log.StandardLogger(), slackWebhook, slackSigningSecret, approvalTimeout, approvalDefault == "approve")
+		http.Handle("/slack/interactions", approver)
+		chaoskube.Approver = approver
+	}
+
+	var authorizer auth.Authorizer
+	switch {
+	case webhookKubernetesAuth:
+		authorizer = auth.NewKubernetes(client)
+	case webhookToken != "":
+		authorizer = auth.NewStaticToken(webhookToken)
+	}
+
+	var webhook *trigger.Webhook
+	if authorizer != nil {
+		webhook = trigger.NewWebhook(log.StandardLogger(), authorizer, webhookRateLimit, webhookMaxWithin)
+		http.Handle("/trigger", webhook)
+		http.Handle("/status", history.NewHandler(log.StandardLogger(), authorizer, chaoskube.History))
+		for path, paused := range map[string]bool{"/pause": true, "/resume": false} {
+			pause, err := trigger.NewPause(log.StandardLogger(), authorizer, chaoskube, paused)
+			if err != nil {
+				log.WithField("err", err).Fatal("failed to create pause handler")
+			}
+			http.Handle(path, pause)
+		}
+	}
+
 	if metricsAddress != "" {
 		go serveMetrics()
 	}
@@ -267,13 +1068,130 @@ func main() {
 		cancel()
 	}()
 
+	pauses := make(chan os.Signal, 1)
+	signal.Notify(pauses, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	go func() {
+		for sig := range pauses {
+			chaoskube.SetPaused(sig == syscall.SIGUSR1)
+		}
+	}()
+
+	if informerCache {
+		var podCache *cache.Cache
+		if metadataOnly {
+			podCache = cache.NewMetadata(metadataClient, util.ParseNamespaceScope(clientNamespaceScope), labelSelector, chaoskube.PodFieldSelector(), v1.PodRunning, !namespaceLabels.Empty() || namespaceLevels || snooze)
+		} else {
+			podCache = cache.New(client, util.ParseNamespaceScope(clientNamespaceScope), labelSelector, chaoskube.PodFieldSelector(), !namespaceLabels.Empty() || namespaceLevels || snooze)
+		}
+		if err := podCache.Start(ctx); err != nil {
+			log.WithField("err", err).Fatal("failed to start informer cache")
+		}
+		chaoskube.Lister = podCache
+	}
+
+	candidates, err := chaoskube.Validate(ctx)
+	if err != nil {
+		if validate {
+			log.WithField("err", err).Fatal("invalid configuration")
+		}
+		log.WithField("err", err).Warn("invalid configuration")
+	}
+	log.WithField("candidates", len(candidates)).Info("validated configuration")
+
+	if validate {
+		return
+	}
+
+	if triggerOnRollout {
+		for _, namespace := range util.ParseNamespaceScope(clientNamespaceScope) {
+			rolloutTrigger := trigger.NewRolloutTrigger(client, log.StandardLogger(), namespace, triggerDelay)
+			go rolloutTrigger.Run(ctx)
+			go chaoskube.RunTriggers(ctx, rolloutTrigger.Requests())
+		}
+	}
+
+	if webhook != nil {
+		go webhook.Run(ctx)
+		go chaoskube.RunTriggers(ctx, webhook.Requests())
+	}
+
+	// workloads may have been left scaled to zero by a previous run that exited during the pause
+	if scalesToZero() {
+		restoreScaledToZero(ctx, client)
+	}
+	// the same goes for nodes left cordoned
+	if cordons() {
+		restoreCordoned(ctx, client)
+	}
+
+	if watchdogThreshold > 0 {
+		go chaoskube.RunWatchdog(ctx, watchdogThreshold)
+	}
+
+	if dynamicIntervalEnabled && dynamicSmoothing > 0 && dynamicSamplePeriod > 0 {
+		go chaoskube.RunPodCountSampler(ctx, dynamicSamplePeriod)
+	}
+
+	if janitorInterval > 0 {
+		go chaoskube.RunJanitor(ctx, janitorInterval, janitorStuckAfter, janitorLingerAfter)
+	}
+
+	if holidays != nil {
+		go holidays.Run(ctx, calendarRefresh)
+	}
+
 	tickerChan, stopTicker := chaoskube.NewTicker(ctx)
 	defer stopTicker()
 
+	if tuiEnabled {
+		fd := int(os.Stdin.Fd())
+		if !term.IsTerminal(fd) {
+			log.Fatal("--tui requires a terminal")
+		}
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			log.WithField("err", err).Fatal("failed to set up terminal")
+		}
+		defer term.Restore(fd, state)
+
+		// the dashboard takes over the terminal, so logs would only garble it
+		log.SetOutput(io.Discard)
+
+		go func() {
+			if err := tui.New(chaoskube, os.Stdin, os.Stdout).Run(ctx); err != nil {
+				log.WithField("err", err).Error("failed to run dashboard")
+			}
+			cancel()
+		}()
+	}
+
 	chaoskube.Run(ctx, tickerChan)
+
+	// pending scale-backs and uncordons outlive this process, so end them now
+	if scalesToZero() || cordons() {
+		restoreCtx, cancelRestore := context.WithTimeout(context.Background(), 30*time.Second)
+		if scalesToZero() {
+			restoreScaledToZero(restoreCtx, client)
+		}
+		if cordons() {
+			restoreCordoned(restoreCtx, client)
+		}
+		cancelRestore()
+	}
+
+	// short-lived runs are never scraped, so they push their final metrics instead
+	if pushgatewayAddress != "" {
+		if err := push.New(pushgatewayAddress, pushgatewayJob).Gatherer(prometheus.DefaultGatherer).Push(); err != nil {
+			log.WithFields(log.Fields{
+				"address": pushgatewayAddress,
+				"err":     err,
+			}).Error("failed to push metrics")
+		}
+	}
 }
 
-func newClient() (*kubernetes.Clientset, error) {
+func newConfig() (*rest.Config, error) {
 	if kubeconfig == "" {
 		if _, err := os.Stat(clientcmd.RecommendedHomeFile); err == nil {
 			kubeconfig = clientcmd.RecommendedHomeFile
@@ -290,6 +1208,19 @@ func newClient() (*kubernetes.Clientset, error) {
 		return nil, err
 	}
 
+	config.QPS = float32(clientQPS)
+	config.Burst = clientBurst
+
+	if clientMaxBackoff > 0 {
+		config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return throttle.NewRoundTripper(rt, clientMaxBackoff)
+		})
+	}
+
+	return config, nil
+}
+
+func newClient(config *rest.Config) (*kubernetes.Clientset, error) {
 	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
@@ -308,6 +1239,36 @@ func newClient() (*kubernetes.Clientset, error) {
 	return client, nil
 }
 
+func parseKinds(str string) labels.Selector {
+	selector, err := chaoskube.ParseKinds(str)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"kinds": str,
+			"err":   err,
+		}).Fatal("failed to parse kinds")
+	}
+	return selector
+}
+
+func parseResources(quantities map[v1.ResourceName]string) v1.ResourceList {
+	resources := v1.ResourceList{}
+	for name, str := range quantities {
+		if str == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(str)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"resource": name,
+				"quantity": str,
+				"err":      err,
+			}).Fatal("failed to parse resource quantity")
+		}
+		resources[name] = quantity
+	}
+	return resources
+}
+
 func parseSelector(str string) labels.Selector {
 	selector, err := labels.Parse(str)
 	if err != nil {
@@ -319,6 +1280,17 @@ func parseSelector(str string) labels.Selector {
 	return selector
 }
 
+func parseFieldSelector(str string) fields.Selector {
+	selector, err := fields.ParseSelector(str)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"selector": str,
+			"err":      err,
+		}).Fatal("failed to parse field selector")
+	}
+	return selector
+}
+
 func createNotifier() notifier.Notifier {
 	notifiers := notifier.New()
 	if slackWebhook != "" {
@@ -328,6 +1300,208 @@ func createNotifier() notifier.Notifier {
 	return notifiers
 }
 
+// metadataOnlyUnsupported returns the configured flags that depend on the spec or status of pods,
+// which --metadata-only doesn't list. Filters would silently let all pods through or none.
+func metadataOnlyUnsupported() []string {
+	unsupported := []string{}
+	for flag, set := range map[string]bool{
+		"--included-image-regexp":     includedImages != nil,
+		"--excluded-image-regexp":     excludedImages != nil,
+		"--virtual-nodes":             virtualNodes != chaoskube.VirtualNodesInclude,
+		"--node-label-selector":       nodeLabelString != "",
+		"--node-name-regexp":          nodeNames != nil,
+		"--preserve-zone-coverage":    preserveZones,
+		"--exclude-pods-with-pvc":     excludePVCs,
+		"--qos-classes":               qosClassString != "",
+		"--included-priority-classes": includedPriorities != "",
+		"--excluded-priority-classes": excludedPriorities != "",
+		"--included-service-accounts": includedSAs != "",
+		"--excluded-service-accounts": excludedSAs != "",
+		"--only-ready-pods":           onlyReadyPods,
+		"--min-cpu-request":           minCPURequest != "",
+		"--max-cpu-request":           maxCPURequest != "",
+		"--min-memory-request":        minMemoryRequest != "",
+		"--max-memory-request":        maxMemoryRequest != "",
+		"--max-container-restarts":    maxRestarts >= 0,
+		"--colocated-blast":           colocatedBlast > 0,
+		"--log-tail-lines":            logTailLines > 0,
+		"--selection-strategy":        selectionStrategy == "spread" || selectionStrategy == "node" || selectionStrategy == "node-pressure",
+	} {
+		if set {
+			unsupported = append(unsupported, flag)
+		}
+	}
+
+	// the exec terminator passes the victim's node on to the command as is
+	for _, spec := range terminatorSpecs {
+		if strings.HasPrefix(spec, terminatorExecPrefix) {
+			unsupported = append(unsupported, "--terminator")
+			break
+		}
+	}
+
+	slices.Sort(unsupported)
+	return unsupported
+}
+
+// serverDryRunSupported returns true iff the configured terminator is a plain delete-pod
+// terminator, the only one that can send its terminations as server-side dry runs.
+func serverDryRunSupported() bool {
+	if len(terminatorSpecs) != 1 || cordonBeforeKill || forceDeleteAfter > 0 {
+		return false
+	}
+	name, _ := parseTerminatorSpec(terminatorSpecs[0])
+	return name == terminatorDeletePod
+}
+
+// scalesToZero returns true iff one of the configured terminators is scale-to-zero.
+func scalesToZero() bool {
+	for _, spec := range terminatorSpecs {
+		if name, _ := parseTerminatorSpec(spec); name == terminatorScaleToZero {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreScaledToZero scales back all workloads in scope that were left scaled to zero.
+func restoreScaledToZero(ctx context.Context, client kubernetes.Interface) {
+	if err := terminator.RestoreScaledToZero(ctx, client, log.StandardLogger(), util.ParseNamespaceScope(clientNamespaceScope)); err != nil {
+		log.WithField("err", err).Error("failed to restore workloads scaled to zero")
+	}
+}
+
+// cordons returns true iff the configured terminators cordon nodes for a while.
+func cordons() bool {
+	if uncordonAfter <= 0 {
+		return false
+	}
+	if cordonBeforeKill {
+		return true
+	}
+	for _, spec := range terminatorSpecs {
+		if name, _ := parseTerminatorSpec(spec); name == terminatorDrainNode {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreCordoned uncordons all nodes that were left cordoned.
+func restoreCordoned(ctx context.Context, client kubernetes.Interface) {
+	if err := terminator.RestoreCordoned(ctx, client, log.StandardLogger()); err != nil {
+		log.WithField("err", err).Error("failed to uncordon nodes")
+	}
+}
+
+func createTerminator(client kubernetes.Interface, config *rest.Config, rnd *rand.Rand) terminator.Terminator {
+	podTerminator := combineTerminators(client, config, rnd)
+	if cordonBeforeKill {
+		podTerminator = terminator.NewCordonTerminator(podTerminator, client, log.StandardLogger(), uncordonAfter)
+	}
+	if forceDeleteAfter > 0 {
+		return terminator.NewForceDeleteTerminator(podTerminator, client, log.StandardLogger(), forceDeleteAfter)
+	}
+	return podTerminator
+}
+
+func combineTerminators(client kubernetes.Interface, config *rest.Config, rnd *rand.Rand) terminator.Terminator {
+	if len(terminatorSpecs) == 1 {
+		name, _ := parseTerminatorSpec(terminatorSpecs[0])
+		return newTerminator(name, client, config, rnd)
+	}
+
+	terminators := []terminator.Terminator{}
+	weighted := terminator.NewWeighted(rnd)
+	for _, spec := range terminatorSpecs {
+		name, weight := parseTerminatorSpec(spec)
+		terminators = append(terminators, newTerminator(name, client, config, rnd))
+		weighted.Add(terminators[len(terminators)-1], weight)
+	}
+
+	if terminatorMode == terminatorModeSequence {
+		return terminator.NewSequence(terminators...)
+	}
+	return weighted
+}
+
+// parseTerminatorSpec splits a --terminator value into the terminator's name and weight,
+// which defaults to 1. Commands of exec terminators may contain = themselves, so only a
+// trailing integer counts as their weight.
+func parseTerminatorSpec(spec string) (string, int) {
+	name, weight := spec, 1
+	if i := strings.LastIndex(spec, "="); i >= 0 {
+		parsed, err := strconv.Atoi(spec[i+1:])
+		switch {
+		case err == nil && parsed > 0:
+			name, weight = spec[:i], parsed
+		case !strings.HasPrefix(spec, terminatorExecPrefix):
+			log.WithField("terminator", spec).Fatal("terminator weights must be positive integers")
+		}
+	}
+
+	if strings.HasPrefix(name, terminatorExecPrefix) {
+		return name, weight
+	}
+
+	switch name {
+	case terminatorDeletePod, terminatorKillContainer, terminatorDrainNode, terminatorRestart, terminatorNetworkChaos, terminatorStress, terminatorEvictPod, terminatorScaleToZero, terminatorDNSChaos:
+		return name, weight
+	default:
+		log.WithField("terminator", spec).Fatal("unknown terminator")
+		return "", 0
+	}
+}
+
+func newTerminator(name string, client kubernetes.Interface, config *rest.Config, rnd *rand.Rand) terminator.Terminator {
+	if command, ok := strings.CutPrefix(name, terminatorExecPrefix); ok {
+		return terminator.NewExecTerminator(command, log.StandardLogger())
+	}
+
+	switch name {
+	case terminatorKillContainer:
+		killContainerTerminator := terminator.NewKillContainerTerminator(client, config, log.StandardLogger(), containerSignal, rnd)
+		killContainerTerminator.AllContainers = signalAll
+		return killContainerTerminator
+	case terminatorDrainNode:
+		return terminator.NewNodeDrainTerminator(client, log.StandardLogger(), drainGracePeriod, uncordonAfter)
+	case terminatorRestart:
+		return terminator.NewRolloutRestartTerminator(client, log.StandardLogger())
+	case terminatorNetworkChaos:
+		fault := terminator.NetworkFault{Latency: networkLatency, Jitter: networkJitter, Loss: networkLoss}
+		if fault == (terminator.NetworkFault{}) {
+			log.Fatal("--terminator=network-chaos requires --network-latency, --network-jitter or --network-loss")
+		}
+		return terminator.NewNetworkChaosTerminator(client, log.StandardLogger(), networkChaosImage, fault, networkChaosFor)
+	case terminatorStress:
+		stress := terminator.Stress{CPUWorkers: stressCPUWorkers, MemoryBytes: int64(stressMemory)}
+		if stress == (terminator.Stress{}) {
+			log.Fatal("--terminator=stress requires --stress-cpu-workers or --stress-memory")
+		}
+		return terminator.NewStressTerminator(client, log.StandardLogger(), stressImage, stress, stressDuration)
+	case terminatorEvictPod:
+		var fallback terminator.Terminator
+		if pdbFallback == pdbFallbackDelete {
+			fallback = newTerminator(terminatorDeletePod, client, config, rnd)
+		}
+		return terminator.NewEvictPodTerminator(client, log.StandardLogger(), gracePeriod, pdbTimeout, fallback)
+	case terminatorScaleToZero:
+		return terminator.NewScaleToZeroTerminator(client, log.StandardLogger(), scaleToZeroPause)
+	case terminatorDNSChaos:
+		return terminator.NewDNSChaosTerminator(client, log.StandardLogger(), networkChaosImage, networkChaosFor)
+	default:
+		deletePodTerminator := terminator.NewDeletePodTerminator(client, log.StandardLogger(), gracePeriod)
+		if gracePeriodMax > 0 {
+			if gracePeriodMin < 0 || gracePeriodMin > gracePeriodMax {
+				log.Fatal("--grace-period-min must be between zero and --grace-period-max")
+			}
+			deletePodTerminator = terminator.NewDeletePodTerminatorWithGracePeriodRange(client, log.StandardLogger(), gracePeriodMin, gracePeriodMax, rnd)
+		}
+		deletePodTerminator.ServerDryRun = dryRun && serverDryRun
+		return deletePodTerminator
+	}
+}
+
 func serveMetrics() {
 	http.Handle("/metrics", promhttp.Handler())
 	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
@@ -354,6 +1528,8 @@ var adminPage = `<html>
 		<h1>chaoskube</h1>
 		<p><a href="/metrics">Metrics</a></p>
 		<p><a href="/healthz">Health Check</a></p>
+		<p><a href="/status">Recent Terminations</a></p>
+		<p><code>POST /trigger</code> Chaos Webhook</p>
 		<p><a href="/debug/pprof">pprof</a></p>
 	</body>
 </html>`
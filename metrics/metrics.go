@@ -18,6 +18,12 @@ var (
 		Name:      "intervals_total",
 		Help:      "The total number of pod termination logic runs",
 	})
+	// PodsReplacedTotal is the total number of victims that were replaced by a pod of the same name before their termination.
+	PodsReplacedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chaoskube",
+		Name:      "pods_replaced_total",
+		Help:      "The total number of victims not deleted since they were replaced by a pod of the same name after their selection",
+	}, []string{"namespace"})
 	// ErrorsTotal is the total number of errors encountered while trying to terminate pods.
 	ErrorsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: "chaoskube",
@@ -36,4 +42,28 @@ var (
 		Name:      "current_interval_seconds",
 		Help:      "Current interval in seconds between pod terminations",
 	})
+	// DynamicIntervalPodCount is a gauge for the, possibly smoothed, pod count the dynamic interval is based on.
+	DynamicIntervalPodCount = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "chaoskube",
+		Name:      "dynamic_interval_pod_count",
+		Help:      "Pod count the dynamic interval is based on, smoothed if enabled",
+	})
+	// IntervalStretchFactor is a gauge for the factor the interval is stretched by due to API server pressure.
+	IntervalStretchFactor = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "chaoskube",
+		Name:      "interval_stretch_factor",
+		Help:      "Factor the interval is stretched by while the API server is under pressure",
+	})
+	// Stalled is a gauge that is 1 while the watchdog considers chaoskube stalled for the given reason.
+	Stalled = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "chaoskube",
+		Name:      "stalled",
+		Help:      "Whether no interval completed (reason tick) or no pod was terminated despite candidates (reason kill) within the watchdog threshold",
+	}, []string{"reason"})
+	// JanitorPodsDeletedTotal is the total number of pods in bad terminal states deleted by the janitor.
+	JanitorPodsDeletedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chaoskube",
+		Name:      "janitor_pods_deleted_total",
+		Help:      "The total number of pods stuck terminating (reason terminating) or lingering in a terminal phase (reason failed, succeeded or unknown) deleted by the janitor",
+	}, []string{"namespace", "reason"})
 )
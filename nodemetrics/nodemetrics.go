@@ -0,0 +1,98 @@
+package nodemetrics
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultTimeout is the timeout for fetching node metrics.
+var DefaultTimeout = 10 * time.Second
+
+// nodeMetricsResource is the resource of node metrics served by metrics-server
+var nodeMetricsResource = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+
+// Client computes the resource pressure of nodes from their usage as reported by metrics-server.
+type Client struct {
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	logger        log.FieldLogger
+}
+
+// NewClient creates and returns a Client reading nodes with the given client and their usage
+// with the given dynamic client.
+func NewClient(client kubernetes.Interface, dynamicClient dynamic.Interface, logger log.FieldLogger) *Client {
+	return &Client{
+		client:        client,
+		dynamicClient: dynamicClient,
+		logger:        logger,
+	}
+}
+
+// Pressure returns the pressure of each node keyed by its name, i.e. the higher of its CPU and
+// memory usage relative to its allocatable resources. Failures are logged and result in no
+// pressure at all, so that selection falls back to picking victims uniformly at random.
+func (c *Client) Pressure() map[string]float64 {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	pressure, err := c.pressure(ctx)
+	if err != nil {
+		c.logger.WithField("err", err).Warn("failed to fetch node metrics")
+		return nil
+	}
+	return pressure
+}
+
+func (c *Client) pressure(ctx context.Context) (map[string]float64, error) {
+	nodes, err := c.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	allocatable := make(map[string]v1.ResourceList, len(nodes.Items))
+	for _, node := range nodes.Items {
+		allocatable[node.Name] = node.Status.Allocatable
+	}
+
+	metrics, err := c.dynamicClient.Resource(nodeMetricsResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pressure := make(map[string]float64, len(metrics.Items))
+	for _, item := range metrics.Items {
+		resources, ok := allocatable[item.GetName()]
+		if !ok {
+			continue
+		}
+
+		usage, _, err := unstructured.NestedStringMap(item.Object, "usage")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			used, err := resource.ParseQuantity(usage[string(name)])
+			if err != nil {
+				continue
+			}
+			available := resources[name]
+			if available.IsZero() {
+				continue
+			}
+			pressure[item.GetName()] = max(pressure[item.GetName()], used.AsApproximateFloat64()/available.AsApproximateFloat64())
+		}
+	}
+
+	return pressure, nil
+}
@@ -0,0 +1,105 @@
+package nodemetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientSuite struct {
+	testutil.TestSuite
+}
+
+var logger, logOutput = test.NewNullLogger()
+
+func (suite *ClientSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func newNode(name, cpu, memory string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func newNodeMetrics(name, cpu, memory string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "metrics.k8s.io/v1beta1",
+		"kind":       "NodeMetrics",
+		"metadata":   map[string]interface{}{"name": name},
+		"usage":      map[string]interface{}{"cpu": cpu, "memory": memory},
+	}}
+}
+
+// newDynamicClient returns a fake dynamic client serving the given node metrics. They are created
+// explicitly, since their kind doesn't match the nodes resource they are served as.
+func (suite *ClientSuite) newDynamicClient(metrics ...*unstructured.Unstructured) *dynamicfake.FakeDynamicClient {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		nodeMetricsResource: "NodeMetricsList",
+	})
+	for _, m := range metrics {
+		_, err := client.Resource(nodeMetricsResource).Create(context.Background(), m, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+	}
+	return client
+}
+
+func (suite *ClientSuite) TestPressure() {
+	client := fake.NewSimpleClientset(
+		newNode("cpu-bound", "4", "16Gi"),
+		newNode("memory-bound", "4", "16Gi"),
+		newNode("idle", "4", "16Gi"),
+		newNode("unmeasured", "4", "16Gi"),
+	)
+	dynamicClient := suite.newDynamicClient(
+		newNodeMetrics("cpu-bound", "3", "4Gi"),
+		newNodeMetrics("memory-bound", "1", "12Gi"),
+		newNodeMetrics("idle", "0", "0"),
+		newNodeMetrics("gone", "4", "16Gi"),
+	)
+
+	pressure := NewClient(client, dynamicClient, logger).Pressure()
+
+	suite.Len(pressure, 3)
+	suite.InDelta(0.75, pressure["cpu-bound"], 0.001)
+	suite.InDelta(0.75, pressure["memory-bound"], 0.001)
+	suite.Zero(pressure["idle"])
+}
+
+func (suite *ClientSuite) TestPressureWithoutMetricsServer() {
+	dynamicClient := suite.newDynamicClient()
+	dynamicClient.PrependReactor("list", "nodes", func(ktesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("the server could not find the requested resource")
+	})
+
+	suite.Nil(NewClient(fake.NewSimpleClientset(), dynamicClient, logger).Pressure())
+	suite.Equal("failed to fetch node metrics", logOutput.LastEntry().Message)
+}
+
+func TestClientSuite(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
@@ -1,6 +1,8 @@
 package notifier
 
 import (
+	"sync"
+
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -8,9 +10,14 @@ const NotifierNoop = "noop"
 
 type Noop struct {
 	Calls int
+	// guards Calls, as victims may be terminated concurrently
+	mutex sync.Mutex
 }
 
 func (t *Noop) NotifyPodTermination(pod v1.Pod) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
 	t.Calls++
 	return nil
 }
@@ -5,6 +5,10 @@ import (
 	v1 "k8s.io/api/core/v1"
 )
 
+// LogTailAnnotation is the annotation key under which the last lines of a victim's container
+// logs are passed to notifiers, if they were fetched before its termination.
+const LogTailAnnotation = "chaos.alpha.kubernetes.io/log-tail"
+
 type Notifier interface {
 	NotifyPodTermination(pod v1.Pod) error
 }
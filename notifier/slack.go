@@ -71,6 +71,13 @@ func (s Slack) NotifyPodTermination(pod v1.Pod) error {
 		},
 	}
 
+	if logTail, ok := pod.Annotations[LogTailAnnotation]; ok {
+		fields = append(fields, slackField{
+			Title: "logs",
+			Value: logTail,
+		})
+	}
+
 	message := createSlackRequest(title, text, fields)
 	return s.sendSlackMessage(message)
 }
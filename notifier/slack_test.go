@@ -1,6 +1,7 @@
 package notifier
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -55,6 +56,25 @@ func (suite *SlackSuite) TestSlackNotificationForTerminationStatus500() {
 	suite.Error(err)
 }
 
+func (suite *SlackSuite) TestSlackNotificationWithLogTail() {
+	var message slackMessage
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		suite.Require().NoError(json.NewDecoder(req.Body).Decode(&message))
+		res.WriteHeader(200)
+	}))
+	defer testServer.Close()
+
+	testPod := util.NewPod("chaos", "chaos-57df4db6b-h9ktj", v1.PodRunning)
+	testPod.Annotations[LogTailAnnotation] = "last words"
+
+	slack := NewSlackNotifier(testServer.URL)
+	suite.Require().NoError(slack.NotifyPodTermination(testPod))
+
+	fields := message.Attachments[0].Fields
+	suite.Equal("logs", fields[len(fields)-1].Title)
+	suite.Equal("last words", fields[len(fields)-1].Value)
+}
+
 func TestSlackSuite(t *testing.T) {
 	suite.Run(t, new(SlackSuite))
 }
@@ -0,0 +1,83 @@
+package opencost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultTimeout is the timeout for requests against the OpenCost API.
+var DefaultTimeout = 10 * time.Second
+
+// Client fetches pod costs from an OpenCost (or Kubecost) allocation API.
+type Client struct {
+	Address string
+	Window  string
+	Client  *http.Client
+}
+
+type allocationResponse struct {
+	Code    int                     `json:"code"`
+	Message string                  `json:"message"`
+	Data    []map[string]allocation `json:"data"`
+}
+
+type allocation struct {
+	Properties struct {
+		Namespace string `json:"namespace"`
+		Pod       string `json:"pod"`
+	} `json:"properties"`
+	TotalCost float64 `json:"totalCost"`
+}
+
+// NewClient creates and returns a Client that aggregates pod costs over the given window, e.g. 1d.
+func NewClient(address, window string) *Client {
+	return &Client{
+		Address: address,
+		Window:  window,
+		Client:  &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// PodCosts returns the total cost of each pod within the configured window, keyed by namespace/name.
+func (c *Client) PodCosts(ctx context.Context) (map[string]float64, error) {
+	query := url.Values{}
+	query.Set("window", c.Window)
+	query.Set("aggregate", "namespace,pod")
+	query.Set("accumulate", "true")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Address+"/allocation/compute?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from opencost %s", res.StatusCode, c.Address)
+	}
+
+	var body allocationResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	costs := map[string]float64{}
+	for _, set := range body.Data {
+		for _, alloc := range set {
+			if alloc.Properties.Namespace == "" || alloc.Properties.Pod == "" {
+				continue
+			}
+			costs[alloc.Properties.Namespace+"/"+alloc.Properties.Pod] += alloc.TotalCost
+		}
+	}
+
+	return costs, nil
+}
@@ -0,0 +1,51 @@
+package opencost
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *ClientSuite) TestPodCosts() {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		suite.Equal("/allocation/compute", req.URL.Path)
+		suite.Equal("1d", req.URL.Query().Get("window"))
+		suite.Equal("namespace,pod", req.URL.Query().Get("aggregate"))
+
+		_, err := res.Write([]byte(`{"code":200,"data":[{
+			"default/foo":{"properties":{"namespace":"default","pod":"foo"},"totalCost":1.5},
+			"testing/bar":{"properties":{"namespace":"testing","pod":"bar"},"totalCost":0.25},
+			"__idle__":{"properties":{},"totalCost":10}
+		}]}`))
+		suite.Require().NoError(err)
+	}))
+	defer server.Close()
+
+	costs, err := NewClient(server.URL, "1d").PodCosts(context.Background())
+	suite.Require().NoError(err)
+
+	suite.Equal(map[string]float64{"default/foo": 1.5, "testing/bar": 0.25}, costs)
+}
+
+func (suite *ClientSuite) TestPodCostsStatus500() {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(500)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, "1d").PodCosts(context.Background())
+	suite.Error(err)
+}
+
+func TestClientSuite(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
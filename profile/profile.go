@@ -0,0 +1,71 @@
+package profile
+
+import (
+	"fmt"
+	"sort"
+)
+
+// profiles bundle the defaults of a number of flags, keyed by flag name. None of them turns off
+// dry-run mode, which stays an explicit decision.
+var profiles = map[string]map[string]string{
+	"conservative": {
+		"interval":              "30m",
+		"max-kill":              "1",
+		"grace-period":          "-1s",
+		"namespaces":            "!kube-system,!kube-public,!kube-node-lease",
+		"minimum-age":           "1h",
+		"excluded-weekdays":     "Sat,Sun",
+		"excluded-times-of-day": "17:00-09:00",
+		"cooldown":              "24h",
+	},
+	"standard": {
+		"interval":              "10m",
+		"max-kill":              "1",
+		"grace-period":          "-1s",
+		"namespaces":            "!kube-system,!kube-public,!kube-node-lease",
+		"minimum-age":           "10m",
+		"excluded-weekdays":     "Sat,Sun",
+		"excluded-times-of-day": "",
+		"cooldown":              "1h",
+	},
+	"aggressive": {
+		"interval":              "1m",
+		"max-kill":              "3",
+		"grace-period":          "0s",
+		"namespaces":            "!kube-system",
+		"minimum-age":           "0s",
+		"excluded-weekdays":     "",
+		"excluded-times-of-day": "",
+		"cooldown":              "0s",
+	},
+}
+
+// Names returns the names of all profiles in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Defaults returns the flag defaults of the profile with the given name, keyed by flag name.
+// It returns no defaults for an empty name.
+func Defaults(name string) (map[string]string, error) {
+	if name == "" {
+		return map[string]string{}, nil
+	}
+
+	defaults, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile: %s", name)
+	}
+
+	// return a copy, so callers can't modify the profile
+	res := make(map[string]string, len(defaults))
+	for flag, value := range defaults {
+		res[flag] = value
+	}
+	return res, nil
+}
@@ -0,0 +1,56 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfileSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *ProfileSuite) TestNames() {
+	suite.Equal([]string{"aggressive", "conservative", "standard"}, Names())
+}
+
+func (suite *ProfileSuite) TestDefaults() {
+	defaults, err := Defaults("")
+	suite.Require().NoError(err)
+	suite.Empty(defaults)
+
+	defaults, err = Defaults("conservative")
+	suite.Require().NoError(err)
+	suite.Equal("30m", defaults["interval"])
+
+	// profiles can't be modified through their defaults
+	defaults["interval"] = "1s"
+	defaults, err = Defaults("conservative")
+	suite.Require().NoError(err)
+	suite.Equal("30m", defaults["interval"])
+
+	_, err = Defaults("reckless")
+	suite.EqualError(err, "unknown profile: reckless")
+}
+
+func (suite *ProfileSuite) TestSameFlags() {
+	// every profile sets the same flags, so switching profiles leaves nothing behind
+	expected, err := Defaults("standard")
+	suite.Require().NoError(err)
+
+	for _, name := range Names() {
+		defaults, err := Defaults(name)
+		suite.Require().NoError(err)
+
+		suite.Len(defaults, len(expected), name)
+		for flag := range expected {
+			suite.Contains(defaults, flag, name)
+		}
+	}
+}
+
+func TestProfileSuite(t *testing.T) {
+	suite.Run(t, new(ProfileSuite))
+}
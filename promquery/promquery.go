@@ -0,0 +1,125 @@
+package promquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// NamespaceLabel is the label of a query result that holds the pod's namespace.
+	NamespaceLabel = "namespace"
+	// PodLabel is the label of a query result that holds the pod's name.
+	PodLabel = "pod"
+)
+
+// PodQuery evaluates a PromQL expression against a Prometheus server and returns
+// the pods that appear in its result, identified by their namespace and pod labels.
+type PodQuery struct {
+	api   promv1.API
+	query string
+	now   func() time.Time
+}
+
+// NewPodQuery creates and returns a PodQuery object for the Prometheus server at the given address.
+func NewPodQuery(address, query string) (*PodQuery, error) {
+	client, err := api.NewClient(api.Config{Address: address})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PodQuery{
+		api:   promv1.NewAPI(client),
+		query: query,
+		now:   time.Now,
+	}, nil
+}
+
+// Pods evaluates the query and returns the set of pods in its result, keyed by namespace/name.
+func (q *PodQuery) Pods(ctx context.Context) (map[string]struct{}, error) {
+	result, _, err := q.api.Query(ctx, q.query, q.now())
+	if err != nil {
+		return nil, err
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unsupported query result type: %s", result.Type())
+	}
+
+	pods := make(map[string]struct{}, len(vector))
+	for _, sample := range vector {
+		namespace := sample.Metric[NamespaceLabel]
+		name := sample.Metric[PodLabel]
+		if namespace == "" || name == "" {
+			continue
+		}
+		pods[fmt.Sprintf("%s/%s", namespace, name)] = struct{}{}
+	}
+
+	return pods, nil
+}
+
+// TemplateQuery evaluates a PromQL expression per pod, templated with the pod, e.g. with
+// {{.Namespace}} and {{.Name}}, against a Prometheus server.
+type TemplateQuery struct {
+	api      promv1.API
+	template *template.Template
+	now      func() time.Time
+}
+
+// NewTemplateQuery creates and returns a TemplateQuery object for the Prometheus server at the
+// given address. It fails if the query isn't a valid template.
+func NewTemplateQuery(address, query string) (*TemplateQuery, error) {
+	tmpl, err := template.New("query").Option("missingkey=error").Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := api.NewClient(api.Config{Address: address})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateQuery{
+		api:      promv1.NewAPI(client),
+		template: tmpl,
+		now:      time.Now,
+	}, nil
+}
+
+// Matches evaluates the query for the given pod. A query matches if its result holds a non-zero
+// value, so both filtering comparisons, e.g. rate(...) < 100, and ones with the bool modifier work.
+func (q *TemplateQuery) Matches(ctx context.Context, pod v1.Pod) (bool, error) {
+	var query strings.Builder
+	if err := q.template.Execute(&query, pod); err != nil {
+		return false, err
+	}
+
+	result, _, err := q.api.Query(ctx, query.String(), q.now())
+	if err != nil {
+		return false, err
+	}
+
+	switch result := result.(type) {
+	case model.Vector:
+		for _, sample := range result {
+			if sample.Value != 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	case *model.Scalar:
+		return result.Value != 0, nil
+	default:
+		return false, fmt.Errorf("unsupported query result type: %s", result.Type())
+	}
+}
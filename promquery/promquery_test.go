@@ -0,0 +1,124 @@
+package promquery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PodQuerySuite struct {
+	testutil.TestSuite
+}
+
+func (suite *PodQuerySuite) TestPods() {
+	for _, tt := range []struct {
+		name     string
+		response string
+		expected map[string]struct{}
+		err      bool
+	}{
+		{
+			name:     "vector with pods",
+			response: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"namespace":"default","pod":"foo"},"value":[1,"1"]},{"metric":{"namespace":"testing","pod":"bar"},"value":[1,"1"]}]}}`,
+			expected: map[string]struct{}{"default/foo": {}, "testing/bar": {}},
+		},
+		{
+			name:     "samples without pod labels are ignored",
+			response: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"namespace":"default"},"value":[1,"1"]}]}}`,
+			expected: map[string]struct{}{},
+		},
+		{
+			name:     "scalar results are rejected",
+			response: `{"status":"success","data":{"resultType":"scalar","result":[1,"1"]}}`,
+			err:      true,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			suite.Equal("/api/v1/query", req.URL.Path)
+			res.Header().Set("Content-Type", "application/json")
+			_, err := res.Write([]byte(tt.response))
+			suite.Require().NoError(err)
+		}))
+
+		query, err := NewPodQuery(server.URL, `up`)
+		suite.Require().NoError(err)
+
+		pods, err := query.Pods(context.Background())
+		if tt.err {
+			suite.Error(err, tt.name)
+		} else {
+			suite.Require().NoError(err, tt.name)
+			suite.Equal(tt.expected, pods, tt.name)
+		}
+
+		server.Close()
+	}
+}
+
+func (suite *PodQuerySuite) TestTemplateQuery() {
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+
+	for _, tt := range []struct {
+		name     string
+		response string
+		expected bool
+		err      bool
+	}{
+		{
+			name:     "non-empty vector",
+			response: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1,"42"]}]}}`,
+			expected: true,
+		},
+		{
+			name:     "empty vector",
+			response: `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			expected: false,
+		},
+		{
+			name:     "false comparison with bool modifier",
+			response: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1,"0"]}]}}`,
+			expected: false,
+		},
+		{
+			name:     "true scalar",
+			response: `{"status":"success","data":{"resultType":"scalar","result":[1,"1"]}}`,
+			expected: true,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			suite.Require().NoError(req.ParseForm())
+			suite.Equal(`rate(requests{namespace="default",pod="foo"}[5m]) < 100`, req.Form.Get("query"))
+			res.Header().Set("Content-Type", "application/json")
+			_, err := res.Write([]byte(tt.response))
+			suite.Require().NoError(err)
+		}))
+
+		query, err := NewTemplateQuery(server.URL, `rate(requests{namespace="{{.Namespace}}",pod="{{.Name}}"}[5m]) < 100`)
+		suite.Require().NoError(err)
+
+		matches, err := query.Matches(context.Background(), pod)
+		if tt.err {
+			suite.Error(err, tt.name)
+		} else {
+			suite.Require().NoError(err, tt.name)
+			suite.Equal(tt.expected, matches, tt.name)
+		}
+		server.Close()
+	}
+
+	// invalid templates are rejected upfront
+	_, err := NewTemplateQuery("http://prometheus:9090", `up{pod="{{.Name"}`)
+	suite.Error(err)
+}
+
+func TestPodQuerySuite(t *testing.T) {
+	suite.Run(t, new(PodQuerySuite))
+}
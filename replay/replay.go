@@ -0,0 +1,86 @@
+package replay
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Tick is the candidate set and selection decision of a single interval.
+type Tick struct {
+	Time       time.Time `json:"time"`
+	Candidates []v1.Pod  `json:"candidates"`
+	// the key of each selected victim, see Key
+	Victims []string `json:"victims"`
+}
+
+// NewTick creates a Tick from the given candidates and victims. Managed fields of the
+// candidates are dropped, since they are of no use for selection and make up most of a pod.
+func NewTick(now time.Time, candidates, victims []v1.Pod) Tick {
+	tick := Tick{
+		Time:       now,
+		Candidates: make([]v1.Pod, 0, len(candidates)),
+		Victims:    Keys(victims),
+	}
+
+	for _, pod := range candidates {
+		pod.ManagedFields = nil
+		tick.Candidates = append(tick.Candidates, pod)
+	}
+
+	return tick
+}
+
+// Keys returns the namespace/name of each of the given pods.
+func Keys(pods []v1.Pod) []string {
+	keys := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		keys = append(keys, pod.Namespace+"/"+pod.Name)
+	}
+	return keys
+}
+
+// Recorder writes ticks as JSON lines, one tick per line.
+type Recorder struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewRecorder creates and returns a Recorder writing to the given writer.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{encoder: json.NewEncoder(w)}
+}
+
+// Record writes the given tick.
+func (r *Recorder) Record(tick Tick) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.encoder.Encode(tick)
+}
+
+// Player reads ticks written by a Recorder in the order they were recorded.
+type Player struct {
+	mutex   sync.Mutex
+	decoder *json.Decoder
+}
+
+// NewPlayer creates and returns a Player reading from the given reader.
+func NewPlayer(r io.Reader) *Player {
+	return &Player{decoder: json.NewDecoder(r)}
+}
+
+// Next returns the next recorded tick, or io.EOF if all ticks have been played.
+func (p *Player) Next() (Tick, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var tick Tick
+	if err := p.decoder.Decode(&tick); err != nil {
+		return Tick{}, err
+	}
+	return tick, nil
+}
@@ -0,0 +1,80 @@
+package replay
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplaySuite struct {
+	testutil.TestSuite
+}
+
+func (suite *ReplaySuite) TestNewTick() {
+	foo := util.NewPod("default", "foo", v1.PodRunning)
+	foo.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+	bar := util.NewPod("testing", "bar", v1.PodRunning)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tick := NewTick(now, []v1.Pod{foo, bar}, []v1.Pod{bar})
+
+	suite.Equal(now, tick.Time)
+	suite.Equal([]string{"testing/bar"}, tick.Victims)
+	suite.AssertPods(tick.Candidates, []map[string]string{
+		{"namespace": "default", "name": "foo"},
+		{"namespace": "testing", "name": "bar"},
+	})
+	suite.Nil(tick.Candidates[0].ManagedFields)
+
+	// the given candidates are left untouched
+	suite.Len(foo.ManagedFields, 1)
+}
+
+func (suite *ReplaySuite) TestRecordAndPlay() {
+	foo := util.NewPod("default", "foo", v1.PodRunning)
+	bar := util.NewPod("testing", "bar", v1.PodRunning)
+
+	ticks := []Tick{
+		NewTick(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), []v1.Pod{foo, bar}, []v1.Pod{foo}),
+		NewTick(time.Date(2024, 1, 1, 12, 10, 0, 0, time.UTC), []v1.Pod{bar}, []v1.Pod{bar}),
+	}
+
+	var buf bytes.Buffer
+	recorder := NewRecorder(&buf)
+	for _, tick := range ticks {
+		suite.Require().NoError(recorder.Record(tick))
+	}
+	suite.Equal(2, strings.Count(buf.String(), "\n"))
+
+	player := NewPlayer(&buf)
+	for _, expected := range ticks {
+		tick, err := player.Next()
+		suite.Require().NoError(err)
+
+		suite.True(expected.Time.Equal(tick.Time))
+		suite.Equal(expected.Victims, tick.Victims)
+		suite.Equal(Keys(expected.Candidates), Keys(tick.Candidates))
+	}
+
+	_, err := player.Next()
+	suite.Equal(io.EOF, err)
+}
+
+func (suite *ReplaySuite) TestPlayInvalid() {
+	_, err := NewPlayer(strings.NewReader("not json")).Next()
+	suite.Error(err)
+}
+
+func TestReplaySuite(t *testing.T) {
+	suite.Run(t, new(ReplaySuite))
+}
@@ -0,0 +1,54 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Snapshot is the state of a pod right before its termination.
+type Snapshot struct {
+	Time time.Time `json:"time"`
+	// the full manifest of the pod, including its status
+	Pod v1.Pod `json:"pod"`
+	// the events involving the pod
+	Events []v1.Event `json:"events"`
+}
+
+// Store is the interface for places to keep snapshots of terminated pods.
+type Store interface {
+	// Save stores the given snapshot.
+	Save(snapshot Snapshot) error
+}
+
+// Directory stores each snapshot as a JSON file in a directory, e.g. a volume backed by
+// object storage.
+type Directory struct {
+	path string
+}
+
+// NewDirectory creates and returns a Directory storing snapshots in the given path. The
+// directory is created if it doesn't exist yet.
+func NewDirectory(path string) (*Directory, error) {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+	return &Directory{path: path}, nil
+}
+
+// Save writes the given snapshot to a file named after its time, namespace and pod, so that
+// a directory listing is sorted chronologically.
+func (d *Directory) Save(snapshot Snapshot) error {
+	name := fmt.Sprintf("%s_%s_%s.json", snapshot.Time.UTC().Format("20060102T150405.000Z"), snapshot.Pod.Namespace, snapshot.Pod.Name)
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(d.path, name), data, 0644)
+}
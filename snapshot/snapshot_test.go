@@ -0,0 +1,47 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SnapshotSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *SnapshotSuite) TestDirectory() {
+	path := filepath.Join(suite.T().TempDir(), "snapshots")
+
+	directory, err := NewDirectory(path)
+	suite.Require().NoError(err)
+
+	snapshot := Snapshot{
+		Time:   time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Pod:    util.NewPod("default", "foo", v1.PodRunning),
+		Events: []v1.Event{{Reason: "Started"}},
+	}
+	suite.Require().NoError(directory.Save(snapshot))
+
+	data, err := os.ReadFile(filepath.Join(path, "20240101T120000.000Z_default_foo.json"))
+	suite.Require().NoError(err)
+
+	var saved Snapshot
+	suite.Require().NoError(json.Unmarshal(data, &saved))
+	suite.True(snapshot.Time.Equal(saved.Time))
+	suite.Equal("foo", saved.Pod.Name)
+	suite.Equal("Started", saved.Events[0].Reason)
+}
+
+func TestSnapshotSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotSuite))
+}
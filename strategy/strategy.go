@@ -0,0 +1,339 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/history"
+	"github.com/linki/chaoskube/util"
+)
+
+const (
+	// WeightAnnotation is the annotation key holding the weight of a pod for the weighted strategy.
+	WeightAnnotation = "chaoskube.io/weight"
+)
+
+// Strategy is the interface for implementations of victim selection strategies.
+type Strategy interface {
+	// Select returns up to count victims from the given candidates using the given random source.
+	// It may reorder the candidates.
+	Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod
+}
+
+// OwnerSampler is implemented by strategies that take care of owners themselves, e.g. by
+// selecting at most one pod per owner. They receive all candidates instead of one random
+// candidate per owner.
+type OwnerSampler interface {
+	Strategy
+	// SamplesOwners marks the strategy as taking care of owners itself.
+	SamplesOwners()
+}
+
+// Options configures the strategies returned by New. Each option is only used by some strategies.
+type Options struct {
+	// the history of terminations, used by least-recently-killed
+	History *history.History
+	// the exponent applied to the age of pods, used by age-weighted
+	AgeExponent float64
+	// a function to retrieve the current time, used by age-weighted
+	Now func() time.Time
+	// a function to retrieve the pressure of nodes by name, used by node-pressure
+	NodePressure func() map[string]float64
+}
+
+// New returns the strategy with the given name.
+func New(name string, options Options) (Strategy, error) {
+	switch name {
+	case "uniform":
+		return Uniform{}, nil
+	case "weighted":
+		return NewWeighted(AnnotationWeight), nil
+	case "spread":
+		return Spread{}, nil
+	case "oldest-first":
+		return OldestFirst{}, nil
+	case "round-robin":
+		return NewRoundRobin(), nil
+	case "least-recently-killed":
+		return NewLeastRecentlyKilled(options.History), nil
+	case "stratified":
+		return Stratified{}, nil
+	case "node":
+		return Node{}, nil
+	case "age-weighted":
+		return NewAgeWeighted(options.AgeExponent, options.Now), nil
+	case "node-pressure":
+		return NewNodePressure(options.NodePressure), nil
+	default:
+		return nil, fmt.Errorf("unknown selection strategy: %s", name)
+	}
+}
+
+// Uniform selects victims uniformly at random.
+type Uniform struct{}
+
+// Select returns a random subset of the given pods.
+func (Uniform) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	return util.RandomPodSubSlice(pods, count, rnd)
+}
+
+// Weighted selects victims at random with a probability proportional to their weight.
+type Weighted struct {
+	weight func(v1.Pod) float64
+}
+
+// NewWeighted creates and returns a Weighted strategy using the given weight function.
+func NewWeighted(weight func(v1.Pod) float64) Weighted {
+	return Weighted{weight: weight}
+}
+
+// Select returns a random subset of the given pods, preferring pods with a higher weight.
+func (s Weighted) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	return util.WeightedPodSubSlice(pods, count, s.weight, rnd)
+}
+
+// AnnotationWeight returns the weight of a pod as given by its WeightAnnotation, defaulting to one.
+func AnnotationWeight(pod v1.Pod) float64 {
+	weight, err := strconv.ParseFloat(pod.Annotations[WeightAnnotation], 64)
+	if err != nil {
+		return 1
+	}
+	return weight
+}
+
+// Spread selects victims at random but spreads them across as many nodes as possible.
+type Spread struct{}
+
+// Select returns a random subset of the given pods with at most one pod per node, unless there
+// are fewer nodes than victims to select.
+func (Spread) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	if count > len(pods) {
+		count = len(pods)
+	}
+
+	rnd.Shuffle(len(pods), func(i, j int) { pods[i], pods[j] = pods[j], pods[i] })
+
+	nodes := []string{}
+	podsByNode := make(map[string][]v1.Pod)
+	for _, pod := range pods {
+		if _, ok := podsByNode[pod.Spec.NodeName]; !ok {
+			nodes = append(nodes, pod.Spec.NodeName)
+		}
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+	}
+
+	res := make([]v1.Pod, 0, count)
+	for round := 0; len(res) < count; round++ {
+		for _, node := range nodes {
+			if round < len(podsByNode[node]) && len(res) < count {
+				res = append(res, podsByNode[node][round])
+			}
+		}
+	}
+	return res
+}
+
+// OldestFirst selects the oldest victims, so that long-lived pods are recycled first.
+type OldestFirst struct{}
+
+// Select returns the oldest of the given pods, ordered by their creation time.
+func (OldestFirst) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	if count > len(pods) {
+		count = len(pods)
+	}
+
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+	})
+
+	return pods[:count]
+}
+
+// RoundRobin selects victims at random, but cycles through the namespaces over successive
+// selections, so that every namespace gets its turn regardless of how many pods it has.
+type RoundRobin struct {
+	mutex sync.Mutex
+	// the namespace the last victim was selected from
+	last string
+}
+
+// NewRoundRobin creates and returns a RoundRobin strategy starting at the first namespace.
+func NewRoundRobin() *RoundRobin {
+	return &RoundRobin{}
+}
+
+// Select returns one random pod per namespace, starting with the namespace after the one
+// selected from last, and continues with further rounds if there are fewer namespaces than victims.
+func (s *RoundRobin) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	if count > len(pods) {
+		count = len(pods)
+	}
+
+	rnd.Shuffle(len(pods), func(i, j int) { pods[i], pods[j] = pods[j], pods[i] })
+
+	podsByNamespace := make(map[string][]v1.Pod)
+	for _, pod := range pods {
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+	}
+
+	namespaces := make([]string, 0, len(podsByNamespace))
+	for namespace := range podsByNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// continue with the first namespace after the last one, wrapping around
+	start := sort.SearchStrings(namespaces, s.last)
+	if start < len(namespaces) && namespaces[start] == s.last {
+		start++
+	}
+
+	res := make([]v1.Pod, 0, count)
+	for round := 0; len(res) < count; round++ {
+		for i := range namespaces {
+			namespace := namespaces[(start+i)%len(namespaces)]
+			if round < len(podsByNamespace[namespace]) && len(res) < count {
+				res = append(res, podsByNamespace[namespace][round])
+				s.last = namespace
+			}
+		}
+	}
+	return res
+}
+
+// LeastRecentlyKilled selects victims whose owners haven't been disrupted for the longest time,
+// preferring owners that were never disrupted, or not within the recorded history.
+type LeastRecentlyKilled struct {
+	history *history.History
+}
+
+// NewLeastRecentlyKilled creates and returns a LeastRecentlyKilled strategy using the given history.
+func NewLeastRecentlyKilled(history *history.History) LeastRecentlyKilled {
+	return LeastRecentlyKilled{history: history}
+}
+
+// Select returns the pods whose owners were terminated least recently, in random order among
+// owners terminated at the same time.
+func (s LeastRecentlyKilled) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	if count > len(pods) {
+		count = len(pods)
+	}
+
+	last := s.history.LastTerminations()
+
+	rnd.Shuffle(len(pods), func(i, j int) { pods[i], pods[j] = pods[j], pods[i] })
+	sort.SliceStable(pods, func(i, j int) bool {
+		return last[history.OwnerKey(pods[i])].Before(last[history.OwnerKey(pods[j])])
+	})
+
+	return pods[:count]
+}
+
+// Stratified selects at most one pod per owner, choosing owners at random with a probability
+// proportional to their number of candidates, and a random pod of each chosen owner.
+type Stratified struct{}
+
+// SamplesOwners implements OwnerSampler.
+func (Stratified) SamplesOwners() {}
+
+// Select returns random pods of distinct owners, preferring owners with more candidates.
+func (Stratified) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	rnd.Shuffle(len(pods), func(i, j int) { pods[i], pods[j] = pods[j], pods[i] })
+
+	// the first pod of each owner represents it, which is random due to the shuffle
+	owners := []v1.Pod{}
+	sizes := make(map[string]int)
+	for _, pod := range pods {
+		owner := history.OwnerKey(pod)
+		if sizes[owner] == 0 {
+			owners = append(owners, pod)
+		}
+		sizes[owner]++
+	}
+
+	return util.WeightedPodSubSlice(owners, count, func(pod v1.Pod) float64 {
+		return float64(sizes[history.OwnerKey(pod)])
+	}, rnd)
+}
+
+// Node selects a random node and victims among the pods on that node, simulating a node failure.
+type Node struct{}
+
+// SamplesOwners implements OwnerSampler, since a failing node takes down all of its pods,
+// including several pods of the same owner.
+func (Node) SamplesOwners() {}
+
+// Select returns random pods running on a single random node.
+func (Node) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	nodes := []string{}
+	podsByNode := make(map[string][]v1.Pod)
+	for _, pod := range pods {
+		if _, ok := podsByNode[pod.Spec.NodeName]; !ok {
+			nodes = append(nodes, pod.Spec.NodeName)
+		}
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+	}
+
+	if len(nodes) == 0 {
+		return []v1.Pod{}
+	}
+
+	return util.RandomPodSubSlice(podsByNode[nodes[rnd.Intn(len(nodes))]], count, rnd)
+}
+
+// AgeWeighted selects victims at random with a probability growing with their age, so that
+// fresh pods are rarely chosen and long-lived pods are prime targets.
+type AgeWeighted struct {
+	exponent float64
+	now      func() time.Time
+}
+
+// NewAgeWeighted creates and returns an AgeWeighted strategy. The weight of a pod is its age in
+// hours raised to the given exponent, e.g. 1 for a linear and 2 for a quadratic curve.
+func NewAgeWeighted(exponent float64, now func() time.Time) AgeWeighted {
+	return AgeWeighted{exponent: exponent, now: now}
+}
+
+// Select returns a random subset of the given pods, preferring older pods.
+func (s AgeWeighted) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	now := s.now()
+
+	return util.WeightedPodSubSlice(pods, count, func(pod v1.Pod) float64 {
+		return math.Pow(now.Sub(pod.CreationTimestamp.Time).Hours(), s.exponent)
+	}, rnd)
+}
+
+// NodePressure selects victims at random with a probability proportional to the resource pressure
+// of their nodes, so that pods contributing to hot nodes are preferred, e.g. to test rebalancing.
+type NodePressure struct {
+	pressure func() map[string]float64
+}
+
+// NewNodePressure creates and returns a NodePressure strategy retrieving the current pressure of
+// nodes by name with the given function before each selection, e.g. their highest relative
+// resource usage. Pods on nodes without pressure are only chosen if there are no others.
+func NewNodePressure(pressure func() map[string]float64) NodePressure {
+	return NodePressure{pressure: pressure}
+}
+
+// Select returns a random subset of the given pods, preferring pods on nodes under pressure.
+func (s NodePressure) Select(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
+	var pressure map[string]float64
+	if s.pressure != nil {
+		pressure = s.pressure()
+	}
+
+	return util.WeightedPodSubSlice(pods, count, func(pod v1.Pod) float64 {
+		return pressure[pod.Spec.NodeName]
+	}, rnd)
+}
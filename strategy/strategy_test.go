@@ -0,0 +1,389 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/linki/chaoskube/history"
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StrategySuite struct {
+	testutil.TestSuite
+}
+
+var (
+	rnd = util.NewRand(0)
+)
+
+func (suite *StrategySuite) TestInterface() {
+	suite.Implements((*Strategy)(nil), new(Uniform))
+	suite.Implements((*Strategy)(nil), new(Weighted))
+	suite.Implements((*Strategy)(nil), new(Spread))
+	suite.Implements((*Strategy)(nil), new(OldestFirst))
+	suite.Implements((*Strategy)(nil), new(RoundRobin))
+	suite.Implements((*Strategy)(nil), new(LeastRecentlyKilled))
+	suite.Implements((*Strategy)(nil), new(AgeWeighted))
+	suite.Implements((*Strategy)(nil), new(NodePressure))
+	suite.Implements((*OwnerSampler)(nil), new(Stratified))
+	suite.Implements((*OwnerSampler)(nil), new(Node))
+}
+
+func (suite *StrategySuite) TestNew() {
+	for _, tt := range []struct {
+		name     string
+		expected Strategy
+	}{
+		{"uniform", Uniform{}},
+		{"spread", Spread{}},
+		{"oldest-first", OldestFirst{}},
+		{"stratified", Stratified{}},
+		{"node", Node{}},
+	} {
+		strategy, err := New(tt.name, Options{History: history.New(10)})
+		suite.Require().NoError(err)
+		suite.Equal(tt.expected, strategy)
+	}
+
+	strategy, err := New("weighted", Options{History: history.New(10)})
+	suite.Require().NoError(err)
+	suite.IsType(Weighted{}, strategy)
+
+	strategy, err = New("round-robin", Options{History: history.New(10)})
+	suite.Require().NoError(err)
+	suite.IsType(&RoundRobin{}, strategy)
+
+	strategy, err = New("least-recently-killed", Options{History: history.New(10)})
+	suite.Require().NoError(err)
+	suite.IsType(LeastRecentlyKilled{}, strategy)
+
+	strategy, err = New("age-weighted", Options{AgeExponent: 2, Now: time.Now})
+	suite.Require().NoError(err)
+	suite.IsType(AgeWeighted{}, strategy)
+
+	strategy, err = New("node-pressure", Options{NodePressure: func() map[string]float64 { return nil }})
+	suite.Require().NoError(err)
+	suite.IsType(NodePressure{}, strategy)
+
+	_, err = New("unknown", Options{History: history.New(10)})
+	suite.EqualError(err, "unknown selection strategy: unknown")
+}
+
+func (suite *StrategySuite) TestUniform() {
+	pods := []v1.Pod{
+		util.NewPod("default", "foo", v1.PodRunning),
+		util.NewPod("default", "bar", v1.PodRunning),
+		util.NewPod("default", "baz", v1.PodRunning),
+	}
+
+	suite.Len(Uniform{}.Select(pods, 2, rnd), 2)
+	suite.Len(Uniform{}.Select(pods, 5, rnd), 3)
+}
+
+func (suite *StrategySuite) TestWeighted() {
+	foo := util.NewPod("default", "foo", v1.PodRunning)
+	bar := util.NewPod("default", "bar", v1.PodRunning)
+	bar.Annotations = map[string]string{WeightAnnotation: "0"}
+
+	for i := 0; i < 10; i++ {
+		victims := NewWeighted(AnnotationWeight).Select([]v1.Pod{bar, foo}, 1, rnd)
+		suite.AssertPods(victims, []map[string]string{{"namespace": "default", "name": "foo"}})
+	}
+}
+
+func (suite *StrategySuite) TestAnnotationWeight() {
+	for _, tt := range []struct {
+		annotations map[string]string
+		expected    float64
+	}{
+		{nil, 1},
+		{map[string]string{WeightAnnotation: "2.5"}, 2.5},
+		{map[string]string{WeightAnnotation: "0"}, 0},
+		{map[string]string{WeightAnnotation: "invalid"}, 1},
+	} {
+		pod := util.NewPod("default", "foo", v1.PodRunning)
+		pod.Annotations = tt.annotations
+		suite.Equal(tt.expected, AnnotationWeight(pod))
+	}
+}
+
+func (suite *StrategySuite) TestSpread() {
+	newPod := func(name, node string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.NodeName = node
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("foo", "node-1"),
+		newPod("foo-1", "node-1"),
+		newPod("foo-2", "node-1"),
+		newPod("bar", "node-2"),
+		newPod("baz", "node-3"),
+	}
+
+	for _, tt := range []struct {
+		count    int
+		expected map[string]int
+	}{
+		{2, nil},
+		{3, map[string]int{"node-1": 1, "node-2": 1, "node-3": 1}},
+		{4, map[string]int{"node-1": 2, "node-2": 1, "node-3": 1}},
+		{10, map[string]int{"node-1": 3, "node-2": 1, "node-3": 1}},
+	} {
+		nodes := map[string]int{}
+		for _, pod := range (Spread{}).Select(pods, tt.count, rnd) {
+			nodes[pod.Spec.NodeName]++
+		}
+
+		// with two victims any two of the three nodes may be chosen
+		if tt.expected == nil {
+			suite.Len(nodes, 2)
+			continue
+		}
+		suite.Equal(tt.expected, nodes)
+	}
+}
+
+func (suite *StrategySuite) TestOldestFirst() {
+	now := time.Now()
+
+	newPod := func(name string, age time.Duration) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("foo", time.Hour),
+		newPod("bar", 24*time.Hour),
+		newPod("baz", time.Minute),
+		newPod("qux", 24*time.Hour),
+	}
+
+	for _, tt := range []struct {
+		count    int
+		expected []map[string]string
+	}{
+		{1, []map[string]string{{"namespace": "default", "name": "bar"}}},
+		{2, []map[string]string{{"namespace": "default", "name": "bar"}, {"namespace": "default", "name": "qux"}}},
+		{3, []map[string]string{{"namespace": "default", "name": "bar"}, {"namespace": "default", "name": "qux"}, {"namespace": "default", "name": "foo"}}},
+	} {
+		victims := OldestFirst{}.Select(append([]v1.Pod{}, pods...), tt.count, rnd)
+		suite.AssertPods(victims, tt.expected)
+	}
+}
+
+func (suite *StrategySuite) TestRoundRobin() {
+	pods := []v1.Pod{
+		util.NewPod("default", "foo", v1.PodRunning),
+		util.NewPod("default", "foo-1", v1.PodRunning),
+		util.NewPod("default", "foo-2", v1.PodRunning),
+		util.NewPod("testing", "bar", v1.PodRunning),
+		util.NewPod("test", "baz", v1.PodRunning),
+	}
+
+	namespaces := func(victims []v1.Pod) []string {
+		res := []string{}
+		for _, pod := range victims {
+			res = append(res, pod.Namespace)
+		}
+		return res
+	}
+
+	strategy := NewRoundRobin()
+
+	for _, tt := range []struct {
+		count    int
+		expected []string
+	}{
+		{1, []string{"default"}},
+		{1, []string{"test"}},
+		{1, []string{"testing"}},
+		{1, []string{"default"}},
+		{2, []string{"test", "testing"}},
+		{4, []string{"default", "test", "testing", "default"}},
+		{10, []string{"test", "testing", "default", "default", "default"}},
+	} {
+		victims := strategy.Select(append([]v1.Pod{}, pods...), tt.count, rnd)
+		suite.Equal(tt.expected, namespaces(victims))
+	}
+}
+
+func (suite *StrategySuite) TestLeastRecentlyKilled() {
+	now := time.Now()
+
+	foo := util.NewPodWithOwner("default", "foo", v1.PodRunning, "foo-owner")
+	bar := util.NewPodWithOwner("testing", "bar", v1.PodRunning, "bar-owner")
+	baz := util.NewPod("test", "baz", v1.PodRunning)
+
+	terminations := history.New(10)
+	terminations.Add(history.Entry{Time: now.Add(-time.Hour), Owner: history.OwnerKey(foo)})
+	terminations.Add(history.Entry{Time: now.Add(-2 * time.Hour), Owner: history.OwnerKey(bar)})
+	terminations.Add(history.Entry{Time: now.Add(-3 * time.Hour), Owner: history.OwnerKey(bar)})
+
+	strategy := NewLeastRecentlyKilled(terminations)
+
+	for _, tt := range []struct {
+		count    int
+		expected []map[string]string
+	}{
+		{1, []map[string]string{{"namespace": "test", "name": "baz"}}},
+		{2, []map[string]string{{"namespace": "test", "name": "baz"}, {"namespace": "testing", "name": "bar"}}},
+		{3, []map[string]string{{"namespace": "test", "name": "baz"}, {"namespace": "testing", "name": "bar"}, {"namespace": "default", "name": "foo"}}},
+	} {
+		victims := strategy.Select([]v1.Pod{foo, bar, baz}, tt.count, rnd)
+		suite.AssertPods(victims, tt.expected)
+	}
+}
+
+func (suite *StrategySuite) TestStratified() {
+	pods := []v1.Pod{
+		util.NewPodWithOwner("default", "foo", v1.PodRunning, "foo-owner"),
+		util.NewPodWithOwner("default", "foo-1", v1.PodRunning, "foo-owner"),
+		util.NewPodWithOwner("default", "foo-2", v1.PodRunning, "foo-owner"),
+		util.NewPodWithOwner("testing", "bar", v1.PodRunning, "bar-owner"),
+		util.NewPod("test", "baz", v1.PodRunning),
+	}
+
+	for _, tt := range []struct {
+		count    int
+		expected int
+	}{
+		{1, 1},
+		{2, 2},
+		{3, 3},
+		{5, 3},
+	} {
+		victims := Stratified{}.Select(append([]v1.Pod{}, pods...), tt.count, rnd)
+		suite.Len(victims, tt.expected)
+
+		owners := map[string]bool{}
+		for _, pod := range victims {
+			suite.False(owners[history.OwnerKey(pod)], "owner selected twice")
+			owners[history.OwnerKey(pod)] = true
+		}
+	}
+
+	// the owner with three candidates is picked about three times as often as the others
+	picks := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		victims := Stratified{}.Select(append([]v1.Pod{}, pods...), 1, rnd)
+		picks[victims[0].Namespace]++
+	}
+	suite.Greater(picks["default"], picks["testing"]+picks["test"])
+}
+
+func (suite *StrategySuite) TestNode() {
+	newPod := func(name, node string) v1.Pod {
+		pod := util.NewPodWithOwner("default", name, v1.PodRunning, "parent")
+		pod.Spec.NodeName = node
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("foo", "node-1"),
+		newPod("foo-1", "node-1"),
+		newPod("foo-2", "node-1"),
+		newPod("bar", "node-2"),
+		newPod("bar-1", "node-2"),
+	}
+
+	for _, tt := range []struct {
+		count    int
+		expected map[string]int
+	}{
+		{1, map[string]int{"node-1": 1, "node-2": 1}},
+		{2, map[string]int{"node-1": 2, "node-2": 2}},
+		{10, map[string]int{"node-1": 3, "node-2": 2}},
+	} {
+		for i := 0; i < 10; i++ {
+			victims := Node{}.Select(append([]v1.Pod{}, pods...), tt.count, rnd)
+			suite.Require().NotEmpty(victims)
+
+			node := victims[0].Spec.NodeName
+			for _, pod := range victims {
+				suite.Equal(node, pod.Spec.NodeName)
+			}
+			suite.Len(victims, tt.expected[node])
+		}
+	}
+
+	suite.Empty(Node{}.Select([]v1.Pod{}, 1, rnd))
+}
+
+func (suite *StrategySuite) TestAgeWeighted() {
+	now := time.Now()
+
+	newPod := func(name string, age time.Duration) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("fresh", time.Hour),
+		newPod("crusty", 30*24*time.Hour),
+	}
+
+	for _, tt := range []struct {
+		exponent float64
+		min, max int
+	}{
+		{0, 400, 600},
+		{1, 980, 1000},
+		{2, 1000, 1000},
+	} {
+		strategy := NewAgeWeighted(tt.exponent, func() time.Time { return now })
+
+		picks := 0
+		for i := 0; i < 1000; i++ {
+			if strategy.Select(append([]v1.Pod{}, pods...), 1, rnd)[0].Name == "crusty" {
+				picks++
+			}
+		}
+		suite.GreaterOrEqual(picks, tt.min)
+		suite.LessOrEqual(picks, tt.max)
+	}
+}
+
+func (suite *StrategySuite) TestNodePressure() {
+	newPod := func(name, node string) v1.Pod {
+		pod := util.NewPod("default", name, v1.PodRunning)
+		pod.Spec.NodeName = node
+		return pod
+	}
+
+	pods := []v1.Pod{
+		newPod("hot", "node-1"),
+		newPod("warm", "node-2"),
+		newPod("cold", "node-3"),
+		newPod("unknown", "node-4"),
+	}
+
+	strategy := NewNodePressure(func() map[string]float64 {
+		return map[string]float64{"node-1": 0.9, "node-2": 0.3, "node-3": 0}
+	})
+
+	picks := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		picks[strategy.Select(append([]v1.Pod{}, pods...), 1, rnd)[0].Name]++
+	}
+	suite.InDelta(750, picks["hot"], 50)
+	suite.InDelta(250, picks["warm"], 50)
+	suite.Zero(picks["cold"])
+	suite.Zero(picks["unknown"])
+
+	// without any pressure, victims are selected uniformly at random
+	suite.Len(NewNodePressure(func() map[string]float64 { return nil }).Select(append([]v1.Pod{}, pods...), 2, rnd), 2)
+}
+
+func TestStrategySuite(t *testing.T) {
+	suite.Run(t, new(StrategySuite))
+}
@@ -0,0 +1,70 @@
+package terminator
+
+import (
+	"context"
+	"math/rand"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Sequence applies several terminators to each victim in order, e.g. to kill a container
+// before deleting the pod. It stops at the first terminator that fails.
+type Sequence struct {
+	terminators []Terminator
+}
+
+// NewSequence creates and returns a Sequence of the given terminators.
+func NewSequence(terminators ...Terminator) *Sequence {
+	return &Sequence{terminators: terminators}
+}
+
+// Terminate applies all terminators to the victim in order.
+func (s *Sequence) Terminate(ctx context.Context, victim v1.Pod) error {
+	for _, t := range s.terminators {
+		if err := t.Terminate(ctx, victim); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Weighted applies one of several terminators to each victim, chosen at random in proportion
+// to their weights, e.g. deleting 80% of the victims and draining the nodes of the others.
+type Weighted struct {
+	terminators []Terminator
+	weights     []int
+	total       int
+	rnd         *rand.Rand
+}
+
+// NewWeighted creates and returns an empty Weighted terminator drawing from the given source.
+func NewWeighted(rnd *rand.Rand) *Weighted {
+	return &Weighted{rnd: rnd}
+}
+
+// Add adds a terminator with the given weight. Terminators without a positive weight are
+// never chosen.
+func (w *Weighted) Add(terminator Terminator, weight int) {
+	if weight <= 0 {
+		return
+	}
+	w.terminators = append(w.terminators, terminator)
+	w.weights = append(w.weights, weight)
+	w.total += weight
+}
+
+// Terminate applies a randomly chosen terminator to the victim.
+func (w *Weighted) Terminate(ctx context.Context, victim v1.Pod) error {
+	if w.total == 0 {
+		return nil
+	}
+
+	n := w.rnd.Intn(w.total)
+	for i, weight := range w.weights {
+		if n < weight {
+			return w.terminators[i].Terminate(ctx, victim)
+		}
+		n -= weight
+	}
+	return nil
+}
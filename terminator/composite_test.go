@@ -0,0 +1,72 @@
+package terminator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CompositeSuite struct {
+	testutil.TestSuite
+}
+
+// countingTerminator counts its terminations and fails with the given error.
+type countingTerminator struct {
+	count int
+	err   error
+}
+
+func (t *countingTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	t.count++
+	return t.err
+}
+
+func (suite *CompositeSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(Sequence))
+	suite.Implements((*Terminator)(nil), new(Weighted))
+}
+
+func (suite *CompositeSuite) TestSequence() {
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+
+	first, second := &countingTerminator{}, &countingTerminator{}
+	suite.NoError(NewSequence(first, second).Terminate(context.Background(), victim))
+	suite.Equal(1, first.count)
+	suite.Equal(1, second.count)
+
+	// the sequence stops at the first failure
+	failing, skipped := &countingTerminator{err: errors.New("boom")}, &countingTerminator{}
+	suite.EqualError(NewSequence(failing, skipped).Terminate(context.Background(), victim), "boom")
+	suite.Equal(1, failing.count)
+	suite.Equal(0, skipped.count)
+}
+
+func (suite *CompositeSuite) TestWeighted() {
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+
+	frequent, rare, never := &countingTerminator{}, &countingTerminator{}, &countingTerminator{}
+	weighted := NewWeighted(util.NewRand(0))
+	weighted.Add(frequent, 80)
+	weighted.Add(rare, 20)
+	weighted.Add(never, 0)
+
+	for i := 0; i < 1000; i++ {
+		suite.Require().NoError(weighted.Terminate(context.Background(), victim))
+	}
+
+	suite.Equal(1000, frequent.count+rare.count)
+	suite.InDelta(800, frequent.count, 50)
+	suite.InDelta(200, rare.count, 50)
+	suite.Equal(0, never.count)
+}
+
+func TestCompositeSuite(t *testing.T) {
+	suite.Run(t, new(CompositeSuite))
+}
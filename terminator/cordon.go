@@ -0,0 +1,157 @@
+package terminator
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CordonedUntilAnnotation is the annotation a node cordoned by chaoskube carries the time it is
+// due to be uncordoned in, so that it can be uncordoned even if chaoskube exits before then.
+const CordonedUntilAnnotation = "chaos.alpha.kubernetes.io/cordoned-until"
+
+// CordonTerminator wraps another terminator and cordons the victim's node before terminating
+// the victim, so that its replacement must be scheduled elsewhere. This simulates the loss of
+// a node without touching the node's other pods. The node is uncordoned again after a while.
+type CordonTerminator struct {
+	terminator    Terminator
+	client        kubernetes.Interface
+	logger        log.FieldLogger
+	uncordonAfter time.Duration
+	// schedules the uncordoning, replaceable for testing
+	afterFunc func(d time.Duration, f func())
+}
+
+// NewCordonTerminator creates and returns a CordonTerminator object. The node is uncordoned
+// after uncordonAfter, unless it is zero.
+func NewCordonTerminator(terminator Terminator, client kubernetes.Interface, logger log.FieldLogger, uncordonAfter time.Duration) *CordonTerminator {
+	return &CordonTerminator{
+		terminator:    terminator,
+		client:        client,
+		logger:        logger.WithField("terminator", "Cordon"),
+		uncordonAfter: uncordonAfter,
+		afterFunc: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+	}
+}
+
+// Terminate cordons the victim's node and terminates the victim with the wrapped terminator.
+func (t *CordonTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	node, err := nodeOf(ctx, t.client, victim)
+	if err != nil {
+		return err
+	}
+
+	if err := cordon(ctx, t.client, t.logger, node, t.uncordonAfter, t.afterFunc); err != nil {
+		return err
+	}
+
+	return t.terminator.Terminate(ctx, victim)
+}
+
+// nodeOf returns the name of the node the victim runs on.
+func nodeOf(ctx context.Context, client kubernetes.Interface, victim v1.Pod) (string, error) {
+	if victim.Spec.NodeName != "" {
+		return victim.Spec.NodeName, nil
+	}
+
+	// the victim may only consist of metadata
+	pod, err := client.CoreV1().Pods(victim.Namespace).Get(ctx, victim.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return pod.Spec.NodeName, nil
+}
+
+// cordon marks the given node unschedulable and schedules its uncordoning after the given
+// duration, unless it is zero. Nodes that are cordoned already are left alone, so that nodes
+// cordoned by someone else aren't uncordoned. Nodes to be uncordoned carry the
+// CordonedUntilAnnotation, see RestoreCordoned.
+func cordon(ctx context.Context, client kubernetes.Interface, logger log.FieldLogger, node string, uncordonAfter time.Duration, afterFunc func(time.Duration, func())) error {
+	logger = logger.WithField("node", node)
+
+	current, err := client.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if current.Spec.Unschedulable {
+		logger.Debug("node is cordoned already")
+		return nil
+	}
+
+	logger.Info("cordoning node")
+	var cordonedUntil *string
+	if uncordonAfter > 0 {
+		until := time.Now().Add(uncordonAfter).UTC().Format(time.RFC3339)
+		cordonedUntil = &until
+	}
+	if err := setUnschedulable(ctx, client, node, true, cordonedUntil); err != nil {
+		return err
+	}
+
+	if uncordonAfter > 0 {
+		afterFunc(uncordonAfter, func() {
+			logger.Info("uncordoning node")
+			// the context of the termination is long gone by now
+			if err := setUnschedulable(context.Background(), client, node, false, nil); err != nil {
+				logger.WithField("err", err).Error("failed to uncordon node")
+			}
+		})
+	}
+
+	return nil
+}
+
+// RestoreCordoned uncordons all nodes that carry the CordonedUntilAnnotation, e.g. when chaoskube
+// starts or exits while nodes are cordoned.
+func RestoreCordoned(ctx context.Context, client kubernetes.Interface, logger log.FieldLogger) error {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var result error
+	for _, node := range nodes.Items {
+		cordonedUntil, ok := node.Annotations[CordonedUntilAnnotation]
+		if !ok {
+			continue
+		}
+
+		logger.WithFields(log.Fields{
+			"node":          node.Name,
+			"cordonedUntil": cordonedUntil,
+		}).Info("uncordoning node cordoned by chaos")
+
+		if err := setUnschedulable(ctx, client, node.Name, false, nil); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result
+}
+
+// setUnschedulable cordons or uncordons the given node. The CordonedUntilAnnotation is set to
+// the given time, or removed if it is nil.
+func setUnschedulable(ctx context.Context, client kubernetes.Interface, node string, unschedulable bool, cordonedUntil *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{CordonedUntilAnnotation: cordonedUntil},
+		},
+		"spec": map[string]interface{}{"unschedulable": unschedulable},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.CoreV1().Nodes().Patch(ctx, node, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
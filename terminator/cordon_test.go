@@ -0,0 +1,102 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CordonTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *CordonTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *CordonTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(CordonTerminator))
+}
+
+func (suite *CordonTerminatorSuite) TestTerminate() {
+	for _, tt := range []struct {
+		name               string
+		cordoned           bool
+		expectedUncordoned bool
+	}{
+		{"schedulable nodes are cordoned and uncordoned", false, true},
+		{"cordoned nodes stay cordoned", true, false},
+	} {
+		node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Spec: v1.NodeSpec{Unschedulable: tt.cordoned}}
+		victim := util.NewPod("default", "foo", v1.PodRunning)
+		victim.Spec.NodeName = "node-1"
+		client := fake.NewSimpleClientset(node)
+
+		inner := &countingTerminator{}
+		var uncordon func()
+		terminator := NewCordonTerminator(inner, client, logger, 2*time.Minute)
+		terminator.afterFunc = func(d time.Duration, f func()) {
+			suite.Equal(2*time.Minute, d, tt.name)
+			uncordon = f
+		}
+
+		suite.Require().NoError(terminator.Terminate(context.Background(), victim), tt.name)
+		suite.Equal(1, inner.count, tt.name)
+
+		current, err := client.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+		suite.Require().NoError(err)
+		suite.True(current.Spec.Unschedulable, tt.name)
+		if tt.expectedUncordoned {
+			suite.Contains(current.Annotations, CordonedUntilAnnotation, tt.name)
+		}
+
+		if uncordon != nil {
+			uncordon()
+		}
+
+		current, err = client.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+		suite.Require().NoError(err)
+		suite.Equal(!tt.expectedUncordoned, current.Spec.Unschedulable, tt.name)
+		suite.NotContains(current.Annotations, CordonedUntilAnnotation, tt.name)
+	}
+}
+
+func (suite *CordonTerminatorSuite) TestRestoreCordoned() {
+	client := fake.NewSimpleClientset(
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "chaos", Annotations: map[string]string{CordonedUntilAnnotation: "2024-01-01T12:00:00Z"}},
+			Spec:       v1.NodeSpec{Unschedulable: true},
+		},
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "maintenance"},
+			Spec:       v1.NodeSpec{Unschedulable: true},
+		},
+	)
+
+	suite.Require().NoError(RestoreCordoned(context.Background(), client, logger))
+
+	restored, err := client.CoreV1().Nodes().Get(context.Background(), "chaos", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.False(restored.Spec.Unschedulable)
+	suite.NotContains(restored.Annotations, CordonedUntilAnnotation)
+
+	untouched, err := client.CoreV1().Nodes().Get(context.Background(), "maintenance", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.True(untouched.Spec.Unschedulable)
+}
+
+func TestCordonTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(CordonTerminatorSuite))
+}
@@ -2,6 +2,7 @@ package terminator
 
 import (
 	"context"
+	"math/rand"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -16,6 +17,13 @@ type DeletePodTerminator struct {
 	client      kubernetes.Interface
 	logger      log.FieldLogger
 	gracePeriod time.Duration
+	// the upper bound of a random grace period per termination, see NewDeletePodTerminatorWithGracePeriodRange
+	gracePeriodMax time.Duration
+	rnd            *rand.Rand
+
+	// issue deletions with the server-side dry-run option, so that they pass admission and
+	// authorization like a real deletion but aren't persisted
+	ServerDryRun bool
 }
 
 // NewDeletePodTerminator creates and returns a DeletePodTerminator object.
@@ -27,14 +35,41 @@ func NewDeletePodTerminator(client kubernetes.Interface, logger log.FieldLogger,
 	}
 }
 
-// Terminate sends a request to Kubernetes to delete the pod.
+// NewDeletePodTerminatorWithGracePeriodRange creates and returns a DeletePodTerminator object
+// choosing a random grace period between min and max for each termination, in whole seconds,
+// so that applications are exercised against both abrupt and slow shutdowns.
+func NewDeletePodTerminatorWithGracePeriodRange(client kubernetes.Interface, logger log.FieldLogger, min, max time.Duration, rnd *rand.Rand) *DeletePodTerminator {
+	t := NewDeletePodTerminator(client, logger, min)
+	t.gracePeriodMax = max
+	t.rnd = rnd
+	return t
+}
+
+// Terminate sends a request to Kubernetes to delete the pod. The deletion is preconditioned on
+// the victim's UID, if known, so that a replacement pod of the same name is never deleted
+// instead, which fails with a conflict error.
 func (t *DeletePodTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	gracePeriod := t.gracePeriod
+	if t.rnd != nil && t.gracePeriodMax > t.gracePeriod {
+		seconds := int64((t.gracePeriodMax - t.gracePeriod) / time.Second)
+		gracePeriod += time.Duration(t.rnd.Int63n(seconds+1)) * time.Second
+	}
+
 	t.logger.WithFields(log.Fields{
-		"namespace": victim.Namespace,
-		"name":      victim.Name,
+		"namespace":   victim.Namespace,
+		"name":        victim.Name,
+		"gracePeriod": gracePeriod,
 	}).Debug("calling deletePod endpoint")
 
-	return t.client.CoreV1().Pods(victim.Namespace).Delete(ctx, victim.Name, deleteOptions(t.gracePeriod))
+	options := deleteOptions(gracePeriod)
+	if victim.UID != "" {
+		options.Preconditions = metav1.NewUIDPreconditions(string(victim.UID))
+	}
+	if t.ServerDryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+
+	return t.client.CoreV1().Pods(victim.Namespace).Delete(ctx, victim.Name, options)
 }
 
 func deleteOptions(gracePeriod time.Duration) metav1.DeleteOptions {
@@ -11,6 +11,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
 
 	"github.com/linki/chaoskube/internal/testutil"
 	"github.com/linki/chaoskube/util"
@@ -65,6 +66,62 @@ func (suite *DeletePodTerminatorSuite) TestTerminate() {
 	})
 }
 
+func (suite *DeletePodTerminatorSuite) TestTerminateServerDryRun() {
+	client := fake.NewSimpleClientset()
+	terminator := NewDeletePodTerminator(client, logger, 10*time.Second)
+	terminator.ServerDryRun = true
+
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	_, err := client.CoreV1().Pods(victim.Namespace).Create(context.Background(), &victim, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+
+	actions := client.Actions()
+	suite.Require().Len(actions, 2)
+	deleteAction, ok := actions[1].(ktesting.DeleteAction)
+	suite.Require().True(ok)
+	suite.Equal([]string{metav1.DryRunAll}, deleteAction.GetDeleteOptions().DryRun)
+}
+
+func (suite *DeletePodTerminatorSuite) TestTerminateUIDPrecondition() {
+	client := fake.NewSimpleClientset()
+	terminator := NewDeletePodTerminator(client, logger, 10*time.Second)
+
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	victim.UID = "foo-uid"
+	_, err := client.CoreV1().Pods(victim.Namespace).Create(context.Background(), &victim, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+
+	deleteAction, ok := client.Actions()[1].(ktesting.DeleteAction)
+	suite.Require().True(ok)
+	suite.Equal(metav1.NewUIDPreconditions("foo-uid"), deleteAction.GetDeleteOptions().Preconditions)
+}
+
+func (suite *DeletePodTerminatorSuite) TestTerminateGracePeriodRange() {
+	client := fake.NewSimpleClientset()
+	terminator := NewDeletePodTerminatorWithGracePeriodRange(client, logger, 0, 2*time.Second, util.NewRand(0))
+
+	seen := map[int64]bool{}
+	for i := 0; i < 50; i++ {
+		victim := util.NewPod("default", "foo", v1.PodRunning)
+		_, err := client.CoreV1().Pods(victim.Namespace).Create(context.Background(), &victim, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+
+		client.ClearActions()
+		suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+
+		deleteAction, ok := client.Actions()[0].(ktesting.DeleteAction)
+		suite.Require().True(ok)
+		seen[*deleteAction.GetDeleteOptions().GracePeriodSeconds] = true
+	}
+
+	// each termination gets a random grace period within the range
+	suite.Equal(map[int64]bool{0: true, 1: true, 2: true}, seen)
+}
+
 func (suite *DeletePodTerminatorSuite) TestDeleteOptions() {
 	for _, tt := range []struct {
 		gracePeriod time.Duration
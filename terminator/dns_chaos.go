@@ -0,0 +1,65 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// dnsChaosScript drops the victim's outgoing DNS traffic for the given number of seconds.
+const dnsChaosScript = `iptables -I OUTPUT -p udp --dport 53 -j DROP && iptables -I OUTPUT -p tcp --dport 53 -j DROP && sleep %d; ` +
+	`iptables -D OUTPUT -p udp --dport 53 -j DROP; iptables -D OUTPUT -p tcp --dport 53 -j DROP`
+
+// DNSChaosTerminator breaks name resolution of the victim for a while instead of deleting it,
+// since DNS failures are among the most common incidents. It adds an ephemeral container to
+// the victim that drops all outgoing DNS traffic with iptables and restores it afterwards.
+// Like with NetworkChaosTerminator, the container needs the NET_ADMIN capability.
+type DNSChaosTerminator struct {
+	client   kubernetes.Interface
+	logger   log.FieldLogger
+	image    string
+	duration time.Duration
+}
+
+// NewDNSChaosTerminator creates and returns a DNSChaosTerminator object. The image must provide
+// sh, sleep and iptables.
+func NewDNSChaosTerminator(client kubernetes.Interface, logger log.FieldLogger, image string, duration time.Duration) *DNSChaosTerminator {
+	return &DNSChaosTerminator{
+		client:   client,
+		logger:   logger.WithField("terminator", "DNSChaos"),
+		image:    image,
+		duration: duration,
+	}
+}
+
+// Terminate breaks DNS of the victim for the configured duration. It returns once the ephemeral
+// container is added, without waiting for DNS to be restored.
+func (t *DNSChaosTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	name, err := addEphemeralContainer(ctx, t.client, victim, v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:    "chaoskube-dns",
+			Image:   t.image,
+			Command: []string{"sh", "-c", fmt.Sprintf(dnsChaosScript, int64(t.duration.Seconds()))},
+			SecurityContext: &v1.SecurityContext{
+				Capabilities: &v1.Capabilities{Add: []v1.Capability{"NET_ADMIN"}},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	t.logger.WithFields(log.Fields{
+		"namespace": victim.Namespace,
+		"name":      victim.Name,
+		"container": name,
+		"duration":  t.duration,
+	}).Debug("breaking dns")
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DNSChaosTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *DNSChaosTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *DNSChaosTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(DNSChaosTerminator))
+}
+
+func (suite *DNSChaosTerminatorSuite) TestTerminate() {
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	client := fake.NewSimpleClientset(&victim)
+	terminator := NewDNSChaosTerminator(client, logger, "netshoot", 2*time.Minute)
+
+	suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+
+	pod, err := client.CoreV1().Pods("default").Get(context.Background(), "foo", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.Require().Len(pod.Spec.EphemeralContainers, 1)
+
+	container := pod.Spec.EphemeralContainers[0]
+	suite.Regexp("^chaoskube-dns-", container.Name)
+	suite.Equal("netshoot", container.Image)
+	suite.Equal([]string{"sh", "-c", "iptables -I OUTPUT -p udp --dport 53 -j DROP && iptables -I OUTPUT -p tcp --dport 53 -j DROP && sleep 120; " +
+		"iptables -D OUTPUT -p udp --dport 53 -j DROP; iptables -D OUTPUT -p tcp --dport 53 -j DROP"}, container.Command)
+	suite.Equal([]v1.Capability{"NET_ADMIN"}, container.SecurityContext.Capabilities.Add)
+
+	suite.AssertLog(logOutput, log.DebugLevel, "breaking dns", log.Fields{"namespace": "default", "name": "foo"})
+}
+
+func TestDNSChaosTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(DNSChaosTerminatorSuite))
+}
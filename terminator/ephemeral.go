@@ -0,0 +1,30 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+)
+
+// addEphemeralContainer adds the given container to the victim, where it shares the victim's
+// network namespace and pod-level resources. The container's name is suffixed at random, since
+// ephemeral containers can never be removed and their names must be unique within the pod.
+func addEphemeralContainer(ctx context.Context, client kubernetes.Interface, victim v1.Pod, container v1.EphemeralContainer) (string, error) {
+	pod, err := client.CoreV1().Pods(victim.Namespace).Get(ctx, victim.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if pod.UID != victim.UID && victim.UID != "" {
+		return "", fmt.Errorf("pod %s/%s was replaced", victim.Namespace, victim.Name)
+	}
+
+	container.Name = fmt.Sprintf("%s-%s", container.Name, utilrand.String(5))
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, container)
+
+	_, err = client.CoreV1().Pods(pod.Namespace).UpdateEphemeralContainers(ctx, pod.Name, pod, metav1.UpdateOptions{})
+	return container.Name, err
+}
@@ -0,0 +1,91 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// evictionRetryInterval is how often evictions blocked by a PodDisruptionBudget are retried.
+const evictionRetryInterval = 5 * time.Second
+
+// EvictPodTerminator evicts the victim through the Eviction API, which respects
+// PodDisruptionBudgets. Evictions blocked by a budget are retried until a timeout, after
+// which the victim is handed to a fallback terminator or skipped.
+type EvictPodTerminator struct {
+	client      kubernetes.Interface
+	logger      log.FieldLogger
+	gracePeriod time.Duration
+	timeout     time.Duration
+	fallback    Terminator
+	// how often blocked evictions are retried, replaceable for testing
+	retryInterval time.Duration
+}
+
+// NewEvictPodTerminator creates and returns an EvictPodTerminator object. Victims whose
+// eviction is blocked for longer than the timeout are terminated by the fallback, e.g. a
+// DeletePodTerminator, or skipped with an error if it's nil.
+func NewEvictPodTerminator(client kubernetes.Interface, logger log.FieldLogger, gracePeriod, timeout time.Duration, fallback Terminator) *EvictPodTerminator {
+	return &EvictPodTerminator{
+		client:        client,
+		logger:        logger.WithField("terminator", "EvictPod"),
+		gracePeriod:   gracePeriod,
+		timeout:       timeout,
+		fallback:      fallback,
+		retryInterval: evictionRetryInterval,
+	}
+}
+
+// Terminate evicts the victim, retrying while a PodDisruptionBudget blocks the eviction.
+func (t *EvictPodTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	logger := t.logger.WithFields(log.Fields{
+		"namespace": victim.Namespace,
+		"name":      victim.Name,
+	})
+
+	options := evictionDeleteOptions(t.gracePeriod)
+	if victim.UID != "" {
+		options.Preconditions = metav1.NewUIDPreconditions(string(victim.UID))
+	}
+	eviction := &policyv1.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Namespace: victim.Namespace, Name: victim.Name},
+		DeleteOptions: options,
+	}
+
+	deadline := time.Now().Add(t.timeout)
+	for {
+		logger.Debug("calling eviction endpoint")
+
+		err := t.client.PolicyV1().Evictions(victim.Namespace).Evict(ctx, eviction)
+		if !apierrors.IsTooManyRequests(err) {
+			return err
+		}
+
+		if time.Now().Add(t.retryInterval).After(deadline) {
+			break
+		}
+
+		logger.Debug("eviction blocked by disruption budget, retrying")
+
+		select {
+		case <-time.After(t.retryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if t.fallback == nil {
+		return fmt.Errorf("eviction of pod %s/%s blocked by disruption budget for %s", victim.Namespace, victim.Name, t.timeout)
+	}
+
+	logger.Info("eviction blocked by disruption budget, falling back")
+	return t.fallback.Terminate(ctx, victim)
+}
@@ -0,0 +1,94 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EvictPodTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *EvictPodTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+// blockEvictions makes the first n evictions fail as if blocked by a PodDisruptionBudget and
+// returns a pointer to the number of eviction attempts.
+func blockEvictions(client *fake.Clientset, n int) *int {
+	attempts := 0
+	client.PrependReactor("create", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		attempts++
+		if attempts <= n {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		return true, nil, nil
+	})
+	return &attempts
+}
+
+func (suite *EvictPodTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(EvictPodTerminator))
+}
+
+func (suite *EvictPodTerminatorSuite) TestTerminate() {
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+
+	for _, tt := range []struct {
+		name      string
+		blocked   int
+		fallback  *countingTerminator
+		attempts  int
+		fallbacks int
+		err       string
+	}{
+		{"unblocked evictions succeed", 0, nil, 1, 0, ""},
+		{"blocked evictions are retried", 2, nil, 3, 0, ""},
+		{"blocked evictions are skipped without fallback", 100, nil, 5, 0, "eviction of pod default/foo blocked by disruption budget for 50ms"},
+		{"blocked evictions fall back", 100, &countingTerminator{}, 5, 1, ""},
+	} {
+		client := fake.NewSimpleClientset()
+		attempts := blockEvictions(client, tt.blocked)
+
+		var fallback Terminator
+		if tt.fallback != nil {
+			fallback = tt.fallback
+		}
+		terminator := NewEvictPodTerminator(client, logger, 10*time.Second, 50*time.Millisecond, fallback)
+		terminator.retryInterval = 10 * time.Millisecond
+
+		err := terminator.Terminate(context.Background(), victim)
+		if tt.err != "" {
+			suite.EqualError(err, tt.err, tt.name)
+		} else {
+			suite.NoError(err, tt.name)
+		}
+
+		suite.InDelta(tt.attempts, *attempts, 1, tt.name)
+		if tt.fallback != nil {
+			suite.Equal(tt.fallbacks, tt.fallback.count, tt.name)
+		}
+	}
+}
+
+func TestEvictPodTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(EvictPodTerminatorSuite))
+}
@@ -0,0 +1,82 @@
+package terminator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ExecVictim is the metadata of a victim an ExecTerminator passes to its command as JSON.
+type ExecVictim struct {
+	Namespace       string                  `json:"namespace"`
+	Name            string                  `json:"name"`
+	UID             types.UID               `json:"uid"`
+	NodeName        string                  `json:"nodeName,omitempty"`
+	Labels          map[string]string       `json:"labels,omitempty"`
+	Annotations     map[string]string       `json:"annotations,omitempty"`
+	OwnerReferences []metav1.OwnerReference `json:"ownerReferences,omitempty"`
+}
+
+// ExecTerminator runs an external command for each victim, which lets users plug in custom
+// chaos actions. The command receives the victim's metadata as JSON on stdin, see ExecVictim,
+// and terminates successfully if it exits with zero.
+type ExecTerminator struct {
+	command []string
+	logger  log.FieldLogger
+}
+
+// NewExecTerminator creates and returns an ExecTerminator object running the given command,
+// whose arguments are separated by whitespace.
+func NewExecTerminator(command string, logger log.FieldLogger) *ExecTerminator {
+	return &ExecTerminator{
+		command: strings.Fields(command),
+		logger:  logger.WithField("terminator", "Exec"),
+	}
+}
+
+// Terminate runs the command with the victim's metadata on stdin. It fails with the command's
+// output if the command exits with non-zero.
+func (t *ExecTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	if len(t.command) == 0 {
+		return fmt.Errorf("no command to run")
+	}
+
+	input, err := json.Marshal(ExecVictim{
+		Namespace:       victim.Namespace,
+		Name:            victim.Name,
+		UID:             victim.UID,
+		NodeName:        victim.Spec.NodeName,
+		Labels:          victim.Labels,
+		Annotations:     victim.Annotations,
+		OwnerReferences: victim.OwnerReferences,
+	})
+	if err != nil {
+		return err
+	}
+
+	t.logger.WithFields(log.Fields{
+		"namespace": victim.Namespace,
+		"name":      victim.Name,
+		"command":   t.command[0],
+	}).Debug("running command")
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.command[0], t.command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("command %s failed: %w: %s", t.command[0], err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package terminator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExecTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *ExecTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *ExecTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(ExecTerminator))
+}
+
+func (suite *ExecTerminatorSuite) TestTerminate() {
+	output := filepath.Join(suite.T().TempDir(), "victim.json")
+	terminator := NewExecTerminator("tee "+output, logger)
+
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	victim.UID = "foo-uid"
+	victim.Spec.NodeName = "node-1"
+
+	suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+
+	input, err := os.ReadFile(output)
+	suite.Require().NoError(err)
+
+	var received ExecVictim
+	suite.Require().NoError(json.Unmarshal(input, &received))
+	suite.Equal("default", received.Namespace)
+	suite.Equal("foo", received.Name)
+	suite.Equal("foo-uid", string(received.UID))
+	suite.Equal("node-1", received.NodeName)
+	suite.Equal(victim.Labels, received.Labels)
+
+	suite.AssertLog(logOutput, log.DebugLevel, "running command", log.Fields{"namespace": "default", "name": "foo", "command": "tee"})
+}
+
+func (suite *ExecTerminatorSuite) TestTerminateFailure() {
+	script := filepath.Join(suite.T().TempDir(), "chaos.sh")
+	suite.Require().NoError(os.WriteFile(script, []byte("#!/bin/sh\necho nope\nexit 3\n"), 0o755))
+
+	terminator := NewExecTerminator(script, logger)
+	suite.EqualError(terminator.Terminate(context.Background(), util.NewPod("default", "foo", v1.PodRunning)), "command "+script+" failed: exit status 3: nope")
+}
+
+func TestExecTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(ExecTerminatorSuite))
+}
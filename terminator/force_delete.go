@@ -0,0 +1,84 @@
+package terminator
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ForceDeleteTerminator wraps another terminator and force-deletes victims that are still
+// terminating a while after their termination, e.g. due to stuck finalizers or unreachable
+// nodes, so that chaos doesn't leave zombie pods behind.
+type ForceDeleteTerminator struct {
+	terminator Terminator
+	client     kubernetes.Interface
+	logger     log.FieldLogger
+	after      time.Duration
+	// schedules the check for stuck victims, replaceable for testing
+	afterFunc func(d time.Duration, f func())
+}
+
+// NewForceDeleteTerminator creates and returns a ForceDeleteTerminator object force-deleting
+// victims that are still terminating after the given duration.
+func NewForceDeleteTerminator(terminator Terminator, client kubernetes.Interface, logger log.FieldLogger, after time.Duration) *ForceDeleteTerminator {
+	return &ForceDeleteTerminator{
+		terminator: terminator,
+		client:     client,
+		logger:     logger.WithField("terminator", "ForceDelete"),
+		after:      after,
+		afterFunc: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+	}
+}
+
+// Terminate terminates the victim with the wrapped terminator and schedules the check.
+func (t *ForceDeleteTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	if err := t.terminator.Terminate(ctx, victim); err != nil {
+		return err
+	}
+
+	t.afterFunc(t.after, func() {
+		// the context of the termination is long gone by now
+		t.forceDeleteIfStuck(context.Background(), victim)
+	})
+
+	return nil
+}
+
+// forceDeleteIfStuck deletes the victim without grace period if it's still terminating.
+func (t *ForceDeleteTerminator) forceDeleteIfStuck(ctx context.Context, victim v1.Pod) {
+	logger := t.logger.WithFields(log.Fields{
+		"namespace": victim.Namespace,
+		"name":      victim.Name,
+	})
+
+	pod, err := t.client.CoreV1().Pods(victim.Namespace).Get(ctx, victim.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		logger.WithField("err", err).Error("failed to check for stuck pod")
+		return
+	}
+
+	// a pod with the same name may have replaced the victim in the meantime
+	if pod.UID != victim.UID || pod.DeletionTimestamp == nil {
+		return
+	}
+
+	logger.WithField("terminating", time.Since(pod.DeletionTimestamp.Time).Round(time.Second)).Warn("force-deleting stuck pod")
+
+	options := deleteOptions(0)
+	options.Preconditions = metav1.NewUIDPreconditions(string(pod.UID))
+	err = t.client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, options)
+	if err != nil && !apierrors.IsNotFound(err) {
+		logger.WithField("err", err).Error("failed to force-delete stuck pod")
+	}
+}
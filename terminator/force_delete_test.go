@@ -0,0 +1,102 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ForceDeleteTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+// stuckTerminator marks victims as terminating without ever deleting them.
+type stuckTerminator struct {
+	client *fake.Clientset
+}
+
+func (t *stuckTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	pod, err := t.client.CoreV1().Pods(victim.Namespace).Get(ctx, victim.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	pod.DeletionTimestamp = &now
+	_, err = t.client.CoreV1().Pods(victim.Namespace).Update(ctx, pod, metav1.UpdateOptions{})
+	return err
+}
+
+func (suite *ForceDeleteTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *ForceDeleteTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(ForceDeleteTerminator))
+}
+
+func (suite *ForceDeleteTerminatorSuite) TestTerminate() {
+	for _, tt := range []struct {
+		name       string
+		terminator func(client *fake.Clientset) Terminator
+		uid        types.UID
+		remaining  int
+	}{
+		{
+			"stuck victims are force-deleted",
+			func(client *fake.Clientset) Terminator { return &stuckTerminator{client} },
+			"foo-uid",
+			0,
+		},
+		{
+			"replacements of stuck victims are kept",
+			func(client *fake.Clientset) Terminator { return &stuckTerminator{client} },
+			"other-uid",
+			1,
+		},
+		{
+			"running victims are kept",
+			func(client *fake.Clientset) Terminator { return &countingTerminator{} },
+			"foo-uid",
+			1,
+		},
+	} {
+		pod := util.NewPod("default", "foo", v1.PodRunning)
+		pod.UID = "foo-uid"
+		client := fake.NewSimpleClientset(&pod)
+
+		var check func()
+		terminator := NewForceDeleteTerminator(tt.terminator(client), client, logger, 5*time.Minute)
+		terminator.afterFunc = func(d time.Duration, f func()) {
+			suite.Equal(5*time.Minute, d)
+			check = f
+		}
+
+		victim := util.NewPod("default", "foo", v1.PodRunning)
+		victim.UID = tt.uid
+
+		suite.Require().NoError(terminator.Terminate(context.Background(), victim), tt.name)
+		suite.Require().NotNil(check, tt.name)
+		check()
+
+		pods, err := client.CoreV1().Pods(v1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		suite.Require().NoError(err)
+		suite.Len(pods.Items, tt.remaining, tt.name)
+	}
+}
+
+func TestForceDeleteTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(ForceDeleteTerminatorSuite))
+}
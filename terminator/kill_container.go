@@ -0,0 +1,113 @@
+package terminator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// KillContainerTerminator kills the main process of a random container of the victim instead
+// of deleting the whole pod, which exercises restart policies and liveness probes. It sends
+// the signal from within the container, so the container image must provide a kill command.
+type KillContainerTerminator struct {
+	client kubernetes.Interface
+	config *rest.Config
+	logger log.FieldLogger
+	signal string
+	rnd    *rand.Rand
+	// runs a command in a container of a pod, replaceable for testing
+	exec func(ctx context.Context, pod v1.Pod, container string, command []string) error
+
+	// signal all containers of the victim instead of a random one, which exercises the
+	// graceful shutdown of the whole pod without Kubernetes rescheduling it
+	AllContainers bool
+}
+
+// NewKillContainerTerminator creates and returns a KillContainerTerminator object sending the
+// given signal, e.g. TERM or KILL.
+func NewKillContainerTerminator(client kubernetes.Interface, config *rest.Config, logger log.FieldLogger, signal string, rnd *rand.Rand) *KillContainerTerminator {
+	t := &KillContainerTerminator{
+		client: client,
+		config: config,
+		logger: logger.WithField("terminator", "KillContainer"),
+		signal: signal,
+		rnd:    rnd,
+	}
+	t.exec = t.remoteExec
+	return t
+}
+
+// Terminate sends the signal to the process with PID 1 of a random container of the victim, or
+// of all of its containers.
+func (t *KillContainerTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	// the victim may only consist of metadata
+	if len(victim.Spec.Containers) == 0 {
+		pod, err := t.client.CoreV1().Pods(victim.Namespace).Get(ctx, victim.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		victim = *pod
+	}
+
+	if len(victim.Spec.Containers) == 0 {
+		return fmt.Errorf("pod %s/%s has no containers", victim.Namespace, victim.Name)
+	}
+
+	containers := victim.Spec.Containers
+	if !t.AllContainers {
+		containers = []v1.Container{containers[t.rnd.Intn(len(containers))]}
+	}
+
+	var result error
+	for _, container := range containers {
+		t.logger.WithFields(log.Fields{
+			"namespace": victim.Namespace,
+			"name":      victim.Name,
+			"container": container.Name,
+			"signal":    t.signal,
+		}).Debug("killing container")
+
+		if err := t.exec(ctx, victim, container.Name, []string{"kill", "-s", t.signal, "1"}); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result
+}
+
+// remoteExec runs the given command in the given container through the exec subresource.
+func (t *KillContainerTerminator) remoteExec(ctx context.Context, pod v1.Pod, container string, command []string) error {
+	req := t.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(t.config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &output, Stderr: &output}); err != nil {
+		return fmt.Errorf("failed to run %q in container %s: %w: %s", strings.Join(command, " "), container, err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type KillContainerTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+// execution is a command run by a KillContainerTerminator.
+type execution struct {
+	pod       string
+	container string
+	command   []string
+}
+
+func (suite *KillContainerTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *KillContainerTerminatorSuite) newTerminator(client *fake.Clientset) (*KillContainerTerminator, *[]execution) {
+	executions := []execution{}
+	terminator := NewKillContainerTerminator(client, &rest.Config{}, logger, "TERM", util.NewRand(0))
+	terminator.exec = func(ctx context.Context, pod v1.Pod, container string, command []string) error {
+		executions = append(executions, execution{pod.Namespace + "/" + pod.Name, container, command})
+		return nil
+	}
+	return terminator, &executions
+}
+
+func (suite *KillContainerTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(KillContainerTerminator))
+}
+
+func (suite *KillContainerTerminatorSuite) TestTerminate() {
+	terminator, executions := suite.newTerminator(fake.NewSimpleClientset())
+
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	victim.Spec.Containers = []v1.Container{{Name: "app"}, {Name: "sidecar"}}
+
+	seen := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+	}
+	for _, execution := range *executions {
+		suite.Equal("default/foo", execution.pod)
+		suite.Equal([]string{"kill", "-s", "TERM", "1"}, execution.command)
+		seen[execution.container] = true
+	}
+
+	// a random container is chosen each time
+	suite.Equal(map[string]bool{"app": true, "sidecar": true}, seen)
+
+	suite.AssertLog(logOutput, log.DebugLevel, "killing container", log.Fields{"namespace": "default", "name": "foo", "signal": "TERM"})
+}
+
+func (suite *KillContainerTerminatorSuite) TestTerminateAllContainers() {
+	terminator, executions := suite.newTerminator(fake.NewSimpleClientset())
+	terminator.AllContainers = true
+
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	victim.Spec.Containers = []v1.Container{{Name: "app"}, {Name: "sidecar"}}
+
+	suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+	suite.Equal([]execution{
+		{"default/foo", "app", []string{"kill", "-s", "TERM", "1"}},
+		{"default/foo", "sidecar", []string{"kill", "-s", "TERM", "1"}},
+	}, *executions)
+}
+
+func (suite *KillContainerTerminatorSuite) TestTerminateMetadataOnly() {
+	pod := util.NewPod("default", "foo", v1.PodRunning)
+	pod.Spec.Containers = []v1.Container{{Name: "app"}}
+	client := fake.NewSimpleClientset()
+	_, err := client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	terminator, executions := suite.newTerminator(client)
+
+	// the containers of the victim are looked up
+	suite.Require().NoError(terminator.Terminate(context.Background(), util.NewPod("default", "foo", v1.PodRunning)))
+	suite.Require().Len(*executions, 1)
+	suite.Equal("app", (*executions)[0].container)
+
+	// missing pods fail
+	suite.Error(terminator.Terminate(context.Background(), util.NewPod("default", "bar", v1.PodRunning)))
+}
+
+func TestKillContainerTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(KillContainerTerminatorSuite))
+}
@@ -0,0 +1,97 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NetworkFault describes the degradation a NetworkChaosTerminator injects into the network
+// of a victim.
+type NetworkFault struct {
+	// the delay added to each outgoing packet
+	Latency time.Duration
+	// the random variation of the delay
+	Jitter time.Duration
+	// the percentage of outgoing packets to drop
+	Loss float64
+}
+
+// netem returns the netem parameters of the fault, e.g. "delay 100ms 10ms loss 1%".
+func (f NetworkFault) netem() string {
+	params := []string{}
+	if f.Latency > 0 || f.Jitter > 0 {
+		params = append(params, fmt.Sprintf("delay %dms", f.Latency.Milliseconds()))
+		if f.Jitter > 0 {
+			params = append(params, fmt.Sprintf("%dms", f.Jitter.Milliseconds()))
+		}
+	}
+	if f.Loss > 0 {
+		params = append(params, fmt.Sprintf("loss %g%%", f.Loss))
+	}
+	return strings.Join(params, " ")
+}
+
+// NetworkChaosTerminator degrades the network of the victim instead of deleting it, since
+// slow and lossy networks are the more common failure in practice. It adds an ephemeral
+// container to the victim that applies the fault with tc and removes it again after a while.
+// The container needs the NET_ADMIN capability, which the pod security admission level of
+// the victim's namespace must allow.
+type NetworkChaosTerminator struct {
+	client   kubernetes.Interface
+	logger   log.FieldLogger
+	image    string
+	fault    NetworkFault
+	duration time.Duration
+}
+
+// NewNetworkChaosTerminator creates and returns a NetworkChaosTerminator object. The image must
+// provide sh, sleep and tc.
+func NewNetworkChaosTerminator(client kubernetes.Interface, logger log.FieldLogger, image string, fault NetworkFault, duration time.Duration) *NetworkChaosTerminator {
+	return &NetworkChaosTerminator{
+		client:   client,
+		logger:   logger.WithField("terminator", "NetworkChaos"),
+		image:    image,
+		fault:    fault,
+		duration: duration,
+	}
+}
+
+// Terminate injects the fault into the victim's network for the configured duration. It returns
+// once the ephemeral container is added, without waiting for the fault to be removed.
+func (t *NetworkChaosTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	netem := t.fault.netem()
+
+	name, err := addEphemeralContainer(ctx, t.client, victim, v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:  "chaoskube-netem",
+			Image: t.image,
+			Command: []string{"sh", "-c", fmt.Sprintf(
+				"tc qdisc add dev eth0 root netem %s && sleep %d; tc qdisc del dev eth0 root",
+				netem, int64(t.duration.Seconds()),
+			)},
+			SecurityContext: &v1.SecurityContext{
+				Capabilities: &v1.Capabilities{Add: []v1.Capability{"NET_ADMIN"}},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	t.logger.WithFields(log.Fields{
+		"namespace": victim.Namespace,
+		"name":      victim.Name,
+		"container": name,
+		"netem":     netem,
+		"duration":  t.duration,
+	}).Debug("injecting network fault")
+
+	return nil
+}
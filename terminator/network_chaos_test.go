@@ -0,0 +1,74 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NetworkChaosTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *NetworkChaosTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *NetworkChaosTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(NetworkChaosTerminator))
+}
+
+func (suite *NetworkChaosTerminatorSuite) TestTerminate() {
+	victim := util.NewPod("default", "foo", v1.PodRunning)
+	client := fake.NewSimpleClientset(&victim)
+
+	fault := NetworkFault{Latency: 100 * time.Millisecond, Jitter: 10 * time.Millisecond, Loss: 0.5}
+	terminator := NewNetworkChaosTerminator(client, logger, "netshoot", fault, time.Minute)
+
+	suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+
+	pod, err := client.CoreV1().Pods("default").Get(context.Background(), "foo", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.Require().Len(pod.Spec.EphemeralContainers, 1)
+
+	container := pod.Spec.EphemeralContainers[0]
+	suite.Regexp("^chaoskube-netem-", container.Name)
+	suite.Equal("netshoot", container.Image)
+	suite.Equal([]string{"sh", "-c", "tc qdisc add dev eth0 root netem delay 100ms 10ms loss 0.5% && sleep 60; tc qdisc del dev eth0 root"}, container.Command)
+	suite.Equal([]v1.Capability{"NET_ADMIN"}, container.SecurityContext.Capabilities.Add)
+
+	suite.AssertLog(logOutput, log.DebugLevel, "injecting network fault", log.Fields{"namespace": "default", "name": "foo", "netem": "delay 100ms 10ms loss 0.5%"})
+
+	// missing pods fail
+	suite.Error(terminator.Terminate(context.Background(), util.NewPod("default", "bar", v1.PodRunning)))
+}
+
+func (suite *NetworkChaosTerminatorSuite) TestNetem() {
+	for _, tt := range []struct {
+		fault    NetworkFault
+		expected string
+	}{
+		{NetworkFault{Latency: 100 * time.Millisecond}, "delay 100ms"},
+		{NetworkFault{Latency: time.Second, Jitter: 50 * time.Millisecond}, "delay 1000ms 50ms"},
+		{NetworkFault{Loss: 5}, "loss 5%"},
+		{NetworkFault{Latency: 20 * time.Millisecond, Loss: 1.5}, "delay 20ms loss 1.5%"},
+	} {
+		suite.Equal(tt.expected, tt.fault.netem())
+	}
+}
+
+func TestNetworkChaosTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(NetworkChaosTerminatorSuite))
+}
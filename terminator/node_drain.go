@@ -0,0 +1,115 @@
+package terminator
+
+import (
+	"context"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NodeDrainTerminator cordons and drains the node hosting the victim, like kubectl drain,
+// to simulate node-level failures. DaemonSet and static pods are left alone. The node is
+// uncordoned again after a while.
+type NodeDrainTerminator struct {
+	client        kubernetes.Interface
+	logger        log.FieldLogger
+	gracePeriod   time.Duration
+	uncordonAfter time.Duration
+	// schedules the uncordoning, replaceable for testing
+	afterFunc func(d time.Duration, f func())
+}
+
+// NewNodeDrainTerminator creates and returns a NodeDrainTerminator object. The grace period is
+// passed to each eviction, a negative value uses the pods' own. The node is uncordoned after
+// uncordonAfter, unless it is zero or the node was cordoned already.
+func NewNodeDrainTerminator(client kubernetes.Interface, logger log.FieldLogger, gracePeriod, uncordonAfter time.Duration) *NodeDrainTerminator {
+	return &NodeDrainTerminator{
+		client:        client,
+		logger:        logger.WithField("terminator", "NodeDrain"),
+		gracePeriod:   gracePeriod,
+		uncordonAfter: uncordonAfter,
+		afterFunc: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+	}
+}
+
+// Terminate cordons the victim's node and evicts all of its pods. Evictions refused due to
+// PodDisruptionBudgets are only logged, since that's the budgets doing their job.
+func (t *NodeDrainTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	node, err := nodeOf(ctx, t.client, victim)
+	if err != nil {
+		return err
+	}
+
+	if err := cordon(ctx, t.client, t.logger, node, t.uncordonAfter, t.afterFunc); err != nil {
+		return err
+	}
+
+	logger := t.logger.WithField("node", node)
+
+	pods, err := t.client.CoreV1().Pods(v1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	var result error
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != node || !evictable(pod) {
+			continue
+		}
+
+		logger.WithFields(log.Fields{
+			"namespace": pod.Namespace,
+			"name":      pod.Name,
+		}).Debug("evicting pod")
+
+		err := t.client.PolicyV1().Evictions(pod.Namespace).Evict(ctx, &policyv1.Eviction{
+			ObjectMeta:    metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name},
+			DeleteOptions: evictionDeleteOptions(t.gracePeriod),
+		})
+		switch {
+		case apierrors.IsTooManyRequests(err):
+			logger.WithFields(log.Fields{
+				"namespace": pod.Namespace,
+				"name":      pod.Name,
+			}).Info("eviction refused by disruption budget")
+		case apierrors.IsNotFound(err):
+			// the pod is gone already
+		case err != nil:
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result
+}
+
+// evictable returns false for pods that a drain leaves alone: finished pods, static pods and
+// pods of DaemonSets, which would be recreated on the same node right away.
+func evictable(pod v1.Pod) bool {
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[v1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	if ref := metav1.GetControllerOf(&pod); ref != nil && ref.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
+
+func evictionDeleteOptions(gracePeriod time.Duration) *metav1.DeleteOptions {
+	options := deleteOptions(gracePeriod)
+	return &options
+}
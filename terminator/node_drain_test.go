@@ -0,0 +1,94 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NodeDrainTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *NodeDrainTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *NodeDrainTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(NodeDrainTerminator))
+}
+
+func (suite *NodeDrainTerminatorSuite) TestTerminate() {
+	newPod := func(namespace, name, node string) v1.Pod {
+		pod := util.NewPod(namespace, name, v1.PodRunning)
+		pod.Spec.NodeName = node
+		return pod
+	}
+
+	controller := true
+	foo := newPod("default", "foo", "node-1")
+	bar := newPod("testing", "bar", "node-1")
+	daemon := newPod("kube-system", "daemon", "node-1")
+	daemon.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "daemon", Controller: &controller}}
+	static := newPod("kube-system", "static", "node-1")
+	static.Annotations[v1.MirrorPodAnnotationKey] = "mirror"
+	done := newPod("default", "done", "node-1")
+	done.Status.Phase = v1.PodSucceeded
+	other := newPod("default", "other", "node-2")
+
+	client := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+	for _, pod := range []v1.Pod{foo, bar, daemon, static, done, other} {
+		_, err := client.CoreV1().Pods(pod.Namespace).Create(context.Background(), &pod, metav1.CreateOptions{})
+		suite.Require().NoError(err)
+	}
+	client.ClearActions()
+
+	var uncordon func()
+	terminator := NewNodeDrainTerminator(client, logger, 30*time.Second, 10*time.Minute)
+	terminator.afterFunc = func(d time.Duration, f func()) {
+		suite.Equal(10*time.Minute, d)
+		uncordon = f
+	}
+
+	suite.Require().NoError(terminator.Terminate(context.Background(), foo))
+
+	node, err := client.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.True(node.Spec.Unschedulable)
+
+	evicted := []string{}
+	for _, action := range client.Actions() {
+		if action.GetSubresource() != "eviction" {
+			continue
+		}
+		eviction := action.(ktesting.CreateAction).GetObject().(*policyv1.Eviction)
+		suite.Equal(int64(30), *eviction.DeleteOptions.GracePeriodSeconds)
+		evicted = append(evicted, eviction.Namespace+"/"+eviction.Name)
+	}
+	suite.ElementsMatch([]string{"default/foo", "testing/bar"}, evicted)
+
+	suite.Require().NotNil(uncordon)
+	uncordon()
+
+	node, err = client.CoreV1().Nodes().Get(context.Background(), "node-1", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.False(node.Spec.Unschedulable)
+}
+
+func TestNodeDrainTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(NodeDrainTerminatorSuite))
+}
@@ -0,0 +1,51 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/linki/chaoskube/workload"
+)
+
+// RolloutRestartTerminator restarts the Deployment, StatefulSet or DaemonSet owning the victim
+// like kubectl rollout restart instead of deleting a single pod, which exercises rolling
+// updates and their surge and unavailability settings.
+type RolloutRestartTerminator struct {
+	client kubernetes.Interface
+	logger log.FieldLogger
+}
+
+// NewRolloutRestartTerminator creates and returns a RolloutRestartTerminator object.
+func NewRolloutRestartTerminator(client kubernetes.Interface, logger log.FieldLogger) *RolloutRestartTerminator {
+	return &RolloutRestartTerminator{
+		client: client,
+		logger: logger.WithField("terminator", "RolloutRestart"),
+	}
+}
+
+// Terminate restarts the victim's top-level workload. It fails for victims that aren't owned
+// by a workload that can be restarted.
+func (t *RolloutRestartTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	w, err := workload.NewResolver(t.client).Resolve(ctx, victim)
+	if err != nil {
+		return err
+	}
+	if w == nil {
+		return fmt.Errorf("pod %s/%s isn't owned by a workload", victim.Namespace, victim.Name)
+	}
+
+	t.logger.WithFields(log.Fields{
+		"namespace": victim.Namespace,
+		"name":      victim.Name,
+		"kind":      w.Kind,
+		"workload":  w.Name,
+	}).Debug("restarting workload")
+
+	return workload.Restart(ctx, t.client, w, time.Now())
+}
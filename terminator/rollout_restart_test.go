@@ -0,0 +1,67 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+	"github.com/linki/chaoskube/workload"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RolloutRestartTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *RolloutRestartTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *RolloutRestartTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(RolloutRestartTerminator))
+}
+
+func (suite *RolloutRestartTerminatorSuite) TestTerminate() {
+	controller := true
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", UID: "deployment-uid"}}
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default",
+		Name:      "foo-abc",
+		UID:       "replicaset-uid",
+		OwnerReferences: []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: "Deployment", Name: "foo", UID: "deployment-uid", Controller: &controller},
+		},
+	}}
+	client := fake.NewSimpleClientset(deployment, replicaSet)
+	terminator := NewRolloutRestartTerminator(client, logger)
+
+	victim := util.NewPod("default", "foo-abc-123", v1.PodRunning)
+	victim.OwnerReferences = []metav1.OwnerReference{
+		{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "foo-abc", UID: "replicaset-uid", Controller: &controller},
+	}
+
+	suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+
+	restarted, err := client.AppsV1().Deployments("default").Get(context.Background(), "foo", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.Contains(restarted.Spec.Template.Annotations, workload.RestartedAtAnnotation)
+
+	suite.AssertLog(logOutput, log.DebugLevel, "restarting workload", log.Fields{"namespace": "default", "name": "foo-abc-123", "kind": "Deployment", "workload": "foo"})
+
+	// pods without owner can't be restarted
+	suite.EqualError(terminator.Terminate(context.Background(), util.NewPod("default", "bar", v1.PodRunning)), "pod default/bar isn't owned by a workload")
+}
+
+func TestRolloutRestartTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(RolloutRestartTerminatorSuite))
+}
@@ -0,0 +1,154 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/linki/chaoskube/workload"
+)
+
+// ScaledFromAnnotation is the annotation a workload scaled to zero carries its original number
+// of replicas in, so that it can be scaled back even if chaoskube exits during the pause.
+const ScaledFromAnnotation = "chaos.alpha.kubernetes.io/scaled-from"
+
+// ScaleToZeroTerminator scales the Deployment or StatefulSet owning the victim to zero and
+// back to its original number of replicas after a pause, which simulates the outage of a
+// whole service rather than the loss of a single pod. The original number of replicas is kept
+// in the ScaledFromAnnotation of the workload, see RestoreScaledToZero.
+type ScaleToZeroTerminator struct {
+	client kubernetes.Interface
+	logger log.FieldLogger
+	pause  time.Duration
+	// schedules the scaling back, replaceable for testing
+	afterFunc func(d time.Duration, f func())
+}
+
+// NewScaleToZeroTerminator creates and returns a ScaleToZeroTerminator object.
+func NewScaleToZeroTerminator(client kubernetes.Interface, logger log.FieldLogger, pause time.Duration) *ScaleToZeroTerminator {
+	return &ScaleToZeroTerminator{
+		client: client,
+		logger: logger.WithField("terminator", "ScaleToZero"),
+		pause:  pause,
+		afterFunc: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+	}
+}
+
+// Terminate scales the victim's top-level workload to zero and schedules scaling it back.
+// It fails for victims that aren't owned by a workload that can be scaled, or whose workload
+// is scaled to zero already, e.g. by an earlier termination.
+func (t *ScaleToZeroTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	w, err := workload.NewResolver(t.client).Resolve(ctx, victim)
+	if err != nil {
+		return err
+	}
+	if w == nil {
+		return fmt.Errorf("pod %s/%s isn't owned by a workload", victim.Namespace, victim.Name)
+	}
+
+	replicas, err := workload.Replicas(w)
+	if err != nil {
+		return err
+	}
+	if _, ok := w.Object.GetAnnotations()[ScaledFromAnnotation]; ok || replicas == 0 {
+		return fmt.Errorf("%s %s/%s is scaled to zero already", w.Kind, w.Namespace, w.Name)
+	}
+
+	logger := t.logger.WithFields(log.Fields{
+		"namespace": w.Namespace,
+		"kind":      w.Kind,
+		"workload":  w.Name,
+		"replicas":  replicas,
+	})
+
+	logger.Info("scaling workload to zero")
+	scaledFrom := strconv.Itoa(int(replicas))
+	if err := workload.ScaleAnnotated(ctx, t.client, w, 0, map[string]*string{ScaledFromAnnotation: &scaledFrom}); err != nil {
+		return err
+	}
+
+	t.afterFunc(t.pause, func() {
+		logger.Info("scaling workload back")
+		// the context of the termination is long gone by now
+		if err := scaleBack(context.Background(), t.client, w, replicas); err != nil {
+			logger.WithField("err", err).Error("failed to scale workload back")
+		}
+	})
+
+	return nil
+}
+
+// RestoreScaledToZero scales all Deployments and StatefulSets in the given namespaces that carry
+// the ScaledFromAnnotation back to their original number of replicas, e.g. when chaoskube starts
+// or exits while workloads are scaled to zero.
+func RestoreScaledToZero(ctx context.Context, client kubernetes.Interface, logger log.FieldLogger, namespaces []string) error {
+	logger = logger.WithField("terminator", "ScaleToZero")
+
+	var result error
+	for _, namespace := range namespaces {
+		deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+		for i := range deployments.Items {
+			d := &deployments.Items[i]
+			w := &workload.Workload{Kind: "Deployment", APIVersion: "apps/v1", Namespace: d.Namespace, Name: d.Name, UID: d.UID, Object: d}
+			if err := restore(ctx, client, logger, w); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+
+		statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+		for i := range statefulSets.Items {
+			s := &statefulSets.Items[i]
+			w := &workload.Workload{Kind: "StatefulSet", APIVersion: "apps/v1", Namespace: s.Namespace, Name: s.Name, UID: s.UID, Object: s}
+			if err := restore(ctx, client, logger, w); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+	}
+
+	return result
+}
+
+// restore scales the given workload back to the replicas in its ScaledFromAnnotation, if any.
+func restore(ctx context.Context, client kubernetes.Interface, logger log.FieldLogger, w *workload.Workload) error {
+	scaledFrom, ok := w.Object.GetAnnotations()[ScaledFromAnnotation]
+	if !ok {
+		return nil
+	}
+
+	replicas, err := strconv.ParseInt(scaledFrom, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation of %s %s/%s: %v", ScaledFromAnnotation, w.Kind, w.Namespace, w.Name, err)
+	}
+
+	logger.WithFields(log.Fields{
+		"namespace": w.Namespace,
+		"kind":      w.Kind,
+		"workload":  w.Name,
+		"replicas":  replicas,
+	}).Info("restoring workload scaled to zero")
+
+	return scaleBack(ctx, client, w, int32(replicas))
+}
+
+// scaleBack scales the given workload to the given replicas and removes its ScaledFromAnnotation.
+func scaleBack(ctx context.Context, client kubernetes.Interface, w *workload.Workload, replicas int32) error {
+	return workload.ScaleAnnotated(ctx, client, w, replicas, map[string]*string{ScaledFromAnnotation: nil})
+}
@@ -0,0 +1,114 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ScaleToZeroTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *ScaleToZeroTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *ScaleToZeroTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(ScaleToZeroTerminator))
+}
+
+func (suite *ScaleToZeroTerminatorSuite) TestTerminate() {
+	controller := true
+	replicas := int32(3)
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", UID: "statefulset-uid"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+	}
+	client := fake.NewSimpleClientset(statefulSet)
+
+	var scaleBack func()
+	terminator := NewScaleToZeroTerminator(client, logger, time.Minute)
+	terminator.afterFunc = func(d time.Duration, f func()) {
+		suite.Equal(time.Minute, d)
+		scaleBack = f
+	}
+
+	victim := util.NewPod("default", "foo-0", v1.PodRunning)
+	victim.OwnerReferences = []metav1.OwnerReference{
+		{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "foo", UID: "statefulset-uid", Controller: &controller},
+	}
+
+	current := func() *appsv1.StatefulSet {
+		current, err := client.AppsV1().StatefulSets("default").Get(context.Background(), "foo", metav1.GetOptions{})
+		suite.Require().NoError(err)
+		return current
+	}
+	replicasOf := func() int32 {
+		return *current().Spec.Replicas
+	}
+
+	suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+	suite.Equal(int32(0), replicasOf())
+	suite.Equal("3", current().Annotations[ScaledFromAnnotation])
+
+	// workloads scaled to zero already are left alone
+	suite.EqualError(terminator.Terminate(context.Background(), victim), "StatefulSet default/foo is scaled to zero already")
+
+	suite.Require().NotNil(scaleBack)
+	scaleBack()
+	suite.Equal(int32(3), replicasOf())
+	suite.NotContains(current().Annotations, ScaledFromAnnotation)
+
+	suite.AssertLog(logOutput, log.InfoLevel, "scaling workload back", log.Fields{"namespace": "default", "kind": "StatefulSet", "workload": "foo", "replicas": int32(3)})
+}
+
+func (suite *ScaleToZeroTerminatorSuite) TestRestoreScaledToZero() {
+	zero := int32(0)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", Annotations: map[string]string{ScaledFromAnnotation: "2"}},
+		Spec:       appsv1.DeploymentSpec{Replicas: &zero},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "bar", Annotations: map[string]string{ScaledFromAnnotation: "3"}},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &zero},
+	}
+	untouched := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "baz"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &zero},
+	}
+	client := fake.NewSimpleClientset(deployment, statefulSet, untouched)
+
+	suite.Require().NoError(RestoreScaledToZero(context.Background(), client, logger, []string{v1.NamespaceAll}))
+
+	restored, err := client.AppsV1().Deployments("default").Get(context.Background(), "foo", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.Equal(int32(2), *restored.Spec.Replicas)
+	suite.NotContains(restored.Annotations, ScaledFromAnnotation)
+
+	restoredSet, err := client.AppsV1().StatefulSets("other").Get(context.Background(), "bar", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.Equal(int32(3), *restoredSet.Spec.Replicas)
+	suite.NotContains(restoredSet.Annotations, ScaledFromAnnotation)
+
+	unchanged, err := client.AppsV1().Deployments("default").Get(context.Background(), "baz", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.Equal(int32(0), *unchanged.Spec.Replicas)
+}
+
+func TestScaleToZeroTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(ScaleToZeroTerminatorSuite))
+}
@@ -0,0 +1,84 @@
+package terminator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Stress describes the load a StressTerminator puts on a victim.
+type Stress struct {
+	// the number of workers spinning on the CPU
+	CPUWorkers int
+	// the amount of memory to allocate and keep touching
+	MemoryBytes int64
+}
+
+// args returns the stress-ng arguments of the load, without timeout.
+func (s Stress) args() []string {
+	args := []string{}
+	if s.CPUWorkers > 0 {
+		args = append(args, "--cpu", fmt.Sprint(s.CPUWorkers))
+	}
+	if s.MemoryBytes > 0 {
+		args = append(args, "--vm", "1", "--vm-bytes", fmt.Sprintf("%db", s.MemoryBytes))
+	}
+	return args
+}
+
+// StressTerminator puts CPU and memory load on the victim instead of deleting it, which
+// exercises autoscaling and resource limits. It adds an ephemeral container running stress-ng
+// to the victim, which counts against the victim's pod-level resources.
+type StressTerminator struct {
+	client   kubernetes.Interface
+	logger   log.FieldLogger
+	image    string
+	stress   Stress
+	duration time.Duration
+}
+
+// NewStressTerminator creates and returns a StressTerminator object. The image must provide
+// stress-ng.
+func NewStressTerminator(client kubernetes.Interface, logger log.FieldLogger, image string, stress Stress, duration time.Duration) *StressTerminator {
+	return &StressTerminator{
+		client:   client,
+		logger:   logger.WithField("terminator", "Stress"),
+		image:    image,
+		stress:   stress,
+		duration: duration,
+	}
+}
+
+// Terminate stresses the victim for the configured duration. It returns once the ephemeral
+// container is added, without waiting for the load to end.
+func (t *StressTerminator) Terminate(ctx context.Context, victim v1.Pod) error {
+	command := append([]string{"stress-ng"}, t.stress.args()...)
+	command = append(command, "--timeout", fmt.Sprintf("%ds", int64(t.duration.Seconds())))
+
+	name, err := addEphemeralContainer(ctx, t.client, victim, v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:    "chaoskube-stress",
+			Image:   t.image,
+			Command: command,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	t.logger.WithFields(log.Fields{
+		"namespace":   victim.Namespace,
+		"name":        victim.Name,
+		"container":   name,
+		"cpuWorkers":  t.stress.CPUWorkers,
+		"memoryBytes": t.stress.MemoryBytes,
+		"duration":    t.duration,
+	}).Debug("stressing pod")
+
+	return nil
+}
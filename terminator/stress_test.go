@@ -0,0 +1,72 @@
+package terminator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StressTerminatorSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *StressTerminatorSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *StressTerminatorSuite) TestInterface() {
+	suite.Implements((*Terminator)(nil), new(StressTerminator))
+}
+
+func (suite *StressTerminatorSuite) TestTerminate() {
+	for _, tt := range []struct {
+		stress   Stress
+		expected []string
+	}{
+		{
+			Stress{CPUWorkers: 2},
+			[]string{"stress-ng", "--cpu", "2", "--timeout", "300s"},
+		},
+		{
+			Stress{MemoryBytes: 256 << 20},
+			[]string{"stress-ng", "--vm", "1", "--vm-bytes", "268435456b", "--timeout", "300s"},
+		},
+		{
+			Stress{CPUWorkers: 1, MemoryBytes: 1024},
+			[]string{"stress-ng", "--cpu", "1", "--vm", "1", "--vm-bytes", "1024b", "--timeout", "300s"},
+		},
+	} {
+		victim := util.NewPod("default", "foo", v1.PodRunning)
+		client := fake.NewSimpleClientset(&victim)
+		terminator := NewStressTerminator(client, logger, "stress-ng", tt.stress, 5*time.Minute)
+
+		suite.Require().NoError(terminator.Terminate(context.Background(), victim))
+
+		pod, err := client.CoreV1().Pods("default").Get(context.Background(), "foo", metav1.GetOptions{})
+		suite.Require().NoError(err)
+		suite.Require().Len(pod.Spec.EphemeralContainers, 1)
+
+		container := pod.Spec.EphemeralContainers[0]
+		suite.Regexp("^chaoskube-stress-", container.Name)
+		suite.Equal("stress-ng", container.Image)
+		suite.Equal(tt.expected, container.Command)
+	}
+
+	suite.AssertLog(logOutput, log.DebugLevel, "stressing pod", log.Fields{"namespace": "default", "name": "foo"})
+}
+
+func TestStressTerminatorSuite(t *testing.T) {
+	suite.Run(t, new(StressTerminatorSuite))
+}
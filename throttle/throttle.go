@@ -0,0 +1,114 @@
+package throttle
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// InitialDelay is the delay applied after the first throttled request.
+var InitialDelay = 100 * time.Millisecond
+
+// RoundTripper is an http.RoundTripper that slows down when the API server responds with
+// 429 Too Many Requests. Each throttled response doubles the delay between requests, while
+// each successful one halves it again, so chaoskube backs off on busy shared clusters.
+type RoundTripper struct {
+	next     http.RoundTripper
+	maxDelay time.Duration
+
+	mutex sync.Mutex
+	delay time.Duration
+
+	// a function to wait for the given duration, replaceable in tests
+	wait func(ctx context.Context, d time.Duration) error
+}
+
+// NewRoundTripper creates and returns a RoundTripper that delays requests to next by at most maxDelay.
+func NewRoundTripper(next http.RoundTripper, maxDelay time.Duration) *RoundTripper {
+	return &RoundTripper{
+		next:     next,
+		maxDelay: maxDelay,
+		wait:     wait,
+	}
+}
+
+// RoundTrip waits for the current delay, if any, and sends the request.
+func (t *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if delay := t.Delay(); delay > 0 {
+		if err := t.wait(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return res, err
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		t.backoff(retryAfter(res))
+	} else {
+		t.recover()
+	}
+
+	return res, nil
+}
+
+// Delay returns the current delay between requests.
+func (t *RoundTripper) Delay() time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.delay
+}
+
+// backoff doubles the delay, but waits at least as long as the server asked for.
+func (t *RoundTripper) backoff(minimum time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.delay *= 2
+	if t.delay < InitialDelay {
+		t.delay = InitialDelay
+	}
+	if t.delay < minimum {
+		t.delay = minimum
+	}
+	if t.delay > t.maxDelay {
+		t.delay = t.maxDelay
+	}
+}
+
+// recover halves the delay until it drops below the initial delay.
+func (t *RoundTripper) recover() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.delay /= 2
+	if t.delay < InitialDelay {
+		t.delay = 0
+	}
+}
+
+// retryAfter returns the duration from a response's Retry-After header in seconds, if any.
+func retryAfter(res *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// wait blocks for the given duration or until the context is canceled.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,86 @@
+package throttle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RoundTripperSuite struct {
+	testutil.TestSuite
+}
+
+// statusRoundTripper responds with the given status codes in order.
+type statusRoundTripper struct {
+	codes      []int
+	retryAfter string
+}
+
+func (s *statusRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	res := httptest.NewRecorder()
+	if s.retryAfter != "" {
+		res.Header().Set("Retry-After", s.retryAfter)
+	}
+	res.WriteHeader(s.codes[0])
+	s.codes = s.codes[1:]
+	return res.Result(), nil
+}
+
+func (suite *RoundTripperSuite) TestRoundTrip() {
+	for _, tt := range []struct {
+		name       string
+		codes      []int
+		retryAfter string
+		waits      []time.Duration
+		expected   time.Duration
+	}{
+		{"no throttling", []int{200, 200}, "", nil, 0},
+		{"throttled once", []int{429}, "", nil, 100 * time.Millisecond},
+		{"throttled repeatedly", []int{429, 429, 429}, "", []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, 400 * time.Millisecond},
+		{"throttled up to the maximum", []int{429, 429, 429, 429, 429}, "", []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 500 * time.Millisecond}, 500 * time.Millisecond},
+		{"recovering", []int{429, 429, 200}, "", []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, 100 * time.Millisecond},
+		{"recovered", []int{429, 200, 200}, "", []time.Duration{100 * time.Millisecond}, 0},
+		{"retry after", []int{429, 429}, "1", []time.Duration{500 * time.Millisecond}, 500 * time.Millisecond},
+	} {
+		rt := NewRoundTripper(&statusRoundTripper{codes: tt.codes, retryAfter: tt.retryAfter}, 500*time.Millisecond)
+
+		var waits []time.Duration
+		rt.wait = func(ctx context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		}
+
+		for range tt.codes {
+			res, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+			suite.Require().NoError(err)
+			res.Body.Close()
+		}
+
+		suite.Equal(tt.waits, waits, tt.name)
+		suite.Equal(tt.expected, rt.Delay(), tt.name)
+	}
+}
+
+func (suite *RoundTripperSuite) TestRoundTripContextCanceled() {
+	rt := NewRoundTripper(&statusRoundTripper{codes: []int{429, 200}}, time.Minute)
+
+	res, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil))
+	suite.Require().NoError(err)
+	res.Body.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/api/v1/pods", nil).WithContext(ctx))
+	suite.Equal(context.Canceled, err)
+}
+
+func TestRoundTripperSuite(t *testing.T) {
+	suite.Run(t, new(RoundTripperSuite))
+}
@@ -0,0 +1,78 @@
+package trigger
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/linki/chaoskube/auth"
+)
+
+// Pauser suspends or resumes terminations, e.g. a Chaoskube object.
+type Pauser interface {
+	SetPaused(paused bool)
+}
+
+// Pause is an http.Handler that suspends or resumes terminations, e.g. to stop chaos during an
+// incident without touching the deployment. Pauses always apply to all namespaces, so requests
+// must carry a bearer token authorized for all namespaces.
+type Pause struct {
+	logger     log.FieldLogger
+	authorizer auth.Authorizer
+	pauser     Pauser
+	paused     bool
+}
+
+// NewPause creates and returns a Pause object that pauses or resumes the given Pauser when
+// called, depending on paused. It fails without an authorizer, as anyone could stop chaos then.
+func NewPause(logger log.FieldLogger, authorizer auth.Authorizer, pauser Pauser, paused bool) (*Pause, error) {
+	if authorizer == nil {
+		return nil, errors.New("pausing requires an authorizer")
+	}
+	return &Pause{
+		logger:     logger,
+		authorizer: authorizer,
+		pauser:     pauser,
+		paused:     paused,
+	}, nil
+}
+
+// ServeHTTP pauses or resumes terminations.
+func (p *Pause) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		http.Error(res, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := p.authorizer.Authorize(req.Context(), token, "")
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		http.Error(res, "unauthorized", http.StatusUnauthorized)
+		return
+	case errors.Is(err, auth.ErrForbidden):
+		p.logger.WithField("user", user).Warn("rejected pause request")
+		http.Error(res, "forbidden", http.StatusForbidden)
+		return
+	case err != nil:
+		p.logger.WithField("err", err).Error("failed to authorize pause request")
+		http.Error(res, "failed to authorize request", http.StatusInternalServerError)
+		return
+	}
+
+	p.logger.WithFields(log.Fields{
+		"user":   user,
+		"paused": p.paused,
+	}).Info("accepted pause request")
+
+	p.pauser.SetPaused(p.paused)
+
+	res.WriteHeader(http.StatusNoContent)
+}
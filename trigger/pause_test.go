@@ -0,0 +1,72 @@
+package trigger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/linki/chaoskube/auth"
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PauseSuite struct {
+	testutil.TestSuite
+}
+
+// recordingPauser remembers the last pause state it was set to.
+type recordingPauser struct {
+	paused *bool
+}
+
+func (p *recordingPauser) SetPaused(paused bool) {
+	p.paused = &paused
+}
+
+func (suite *PauseSuite) TestServeHTTP() {
+	for _, tt := range []struct {
+		name       string
+		authorizer auth.Authorizer
+		method     string
+		token      string
+		paused     bool
+		expected   int
+	}{
+		{"pause with token", auth.NewStaticToken("secret"), http.MethodPost, "secret", true, http.StatusNoContent},
+		{"resume with token", auth.NewStaticToken("secret"), http.MethodPost, "secret", false, http.StatusNoContent},
+		{"wrong method", auth.NewStaticToken("secret"), http.MethodGet, "secret", true, http.StatusMethodNotAllowed},
+		{"missing token", auth.NewStaticToken("secret"), http.MethodPost, "", true, http.StatusUnauthorized},
+		{"wrong token", auth.NewStaticToken("secret"), http.MethodPost, "wrong", true, http.StatusUnauthorized},
+		{"namespaced token", namespaceAuthorizer{token: "secret", namespace: "team"}, http.MethodPost, "secret", true, http.StatusForbidden},
+	} {
+		pauser := &recordingPauser{}
+		handler, err := NewPause(logger, tt.authorizer, pauser, tt.paused)
+		suite.Require().NoError(err, tt.name)
+
+		req := httptest.NewRequest(tt.method, "/pause", nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		res := httptest.NewRecorder()
+
+		handler.ServeHTTP(res, req)
+
+		suite.Equal(tt.expected, res.Code, tt.name)
+		if tt.expected == http.StatusNoContent {
+			suite.Require().NotNil(pauser.paused, tt.name)
+			suite.Equal(tt.paused, *pauser.paused, tt.name)
+		} else {
+			suite.Nil(pauser.paused, tt.name)
+		}
+	}
+}
+
+func (suite *PauseSuite) TestNewPauseRequiresAuthorizer() {
+	_, err := NewPause(logger, nil, &recordingPauser{}, true)
+	suite.Error(err)
+}
+
+func TestPauseSuite(t *testing.T) {
+	suite.Run(t, new(PauseSuite))
+}
@@ -0,0 +1,208 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// deploymentRevisionAnnotation is the annotation the deployment controller stores the revision
+// of a Deployment's latest rollout in.
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// RolloutTrigger watches Deployments and StatefulSets and requests a kill for
+// a workload shortly after one of its rollouts completed.
+type RolloutTrigger struct {
+	client    kubernetes.Interface
+	logger    log.FieldLogger
+	namespace string
+	delay     time.Duration
+	requests  chan Request
+
+	// guards the revisions below
+	mutex sync.Mutex
+	// the last completed revision per workload, so that each rollout triggers once only
+	revisions map[string]string
+}
+
+// NewRolloutTrigger creates and returns a RolloutTrigger object.
+func NewRolloutTrigger(client kubernetes.Interface, logger log.FieldLogger, namespace string, delay time.Duration) *RolloutTrigger {
+	return &RolloutTrigger{
+		client:    client,
+		logger:    logger.WithField("trigger", "Rollout"),
+		namespace: namespace,
+		delay:     delay,
+		requests:  make(chan Request),
+		revisions: map[string]string{},
+	}
+}
+
+// Requests returns the channel the trigger sends its requests to.
+func (t *RolloutTrigger) Requests() <-chan Request {
+	return t.requests
+}
+
+// Run watches workloads until the given context is canceled.
+func (t *RolloutTrigger) Run(ctx context.Context) {
+	factory := informers.NewSharedInformerFactoryWithOptions(t.client, 0, informers.WithNamespace(t.namespace))
+
+	factory.Apps().V1().Deployments().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if d, ok := obj.(*appsv1.Deployment); ok {
+				t.observe(ctx, d.ObjectMeta, d.Spec.Selector, "Deployment", deploymentRevision(d), deploymentRolloutComplete(d), true)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if d, ok := obj.(*appsv1.Deployment); ok {
+				t.observe(ctx, d.ObjectMeta, d.Spec.Selector, "Deployment", deploymentRevision(d), deploymentRolloutComplete(d), false)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if d, ok := obj.(*appsv1.Deployment); ok {
+				t.forget(d.ObjectMeta, "Deployment")
+			}
+		},
+	})
+
+	factory.Apps().V1().StatefulSets().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if s, ok := obj.(*appsv1.StatefulSet); ok {
+				t.observe(ctx, s.ObjectMeta, s.Spec.Selector, "StatefulSet", s.Status.UpdateRevision, statefulSetRolloutComplete(s), true)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if s, ok := obj.(*appsv1.StatefulSet); ok {
+				t.observe(ctx, s.ObjectMeta, s.Spec.Selector, "StatefulSet", s.Status.UpdateRevision, statefulSetRolloutComplete(s), false)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if s, ok := obj.(*appsv1.StatefulSet); ok {
+				t.forget(s.ObjectMeta, "StatefulSet")
+			}
+		},
+	})
+
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	t.logger.Info("watching workload rollouts")
+
+	<-ctx.Done()
+	factory.Shutdown()
+}
+
+// observe schedules a request for the given workload once a rollout of a revision it hasn't
+// triggered for yet completed. Availability dipping and recovering, e.g. due to a lost pod or a
+// scale event, doesn't change the revision and therefore doesn't trigger. Workloads that are
+// complete when they are added, e.g. when chaoskube starts, are only remembered.
+func (t *RolloutTrigger) observe(ctx context.Context, meta metav1.ObjectMeta, selector *metav1.LabelSelector, kind, revision string, complete, added bool) {
+	if !complete {
+		return
+	}
+
+	key := kind + "/" + meta.Namespace + "/" + meta.Name
+
+	t.mutex.Lock()
+	previous, known := t.revisions[key]
+	t.revisions[key] = revision
+	t.mutex.Unlock()
+
+	if added || (known && previous == revision) {
+		return
+	}
+	t.schedule(ctx, meta, selector, kind)
+}
+
+// forget drops the revision remembered for the given workload.
+func (t *RolloutTrigger) forget(meta metav1.ObjectMeta, kind string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.revisions, kind+"/"+meta.Namespace+"/"+meta.Name)
+}
+
+// schedule sends a request for the given workload after the configured delay.
+func (t *RolloutTrigger) schedule(ctx context.Context, meta metav1.ObjectMeta, labelSelector *metav1.LabelSelector, kind string) {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		t.logger.WithFields(log.Fields{
+			"namespace": meta.Namespace,
+			"name":      meta.Name,
+			"err":       err,
+		}).Warn("failed to parse workload selector")
+		return
+	}
+
+	request := Request{
+		Namespace: meta.Namespace,
+		Selector:  selector,
+		Reason:    fmt.Sprintf("rollout of %s %s/%s completed", kind, meta.Namespace, meta.Name),
+	}
+
+	t.logger.WithFields(log.Fields{
+		"namespace": meta.Namespace,
+		"name":      meta.Name,
+		"kind":      kind,
+		"delay":     t.delay,
+	}).Info("scheduling chaos after rollout")
+
+	go func() {
+		select {
+		case <-time.After(t.delay):
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case t.requests <- request:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// deploymentRevision returns the revision of the latest rollout of the given deployment, falling
+// back to its generation if the deployment controller didn't annotate it yet.
+func deploymentRevision(d *appsv1.Deployment) string {
+	if revision, ok := d.Annotations[deploymentRevisionAnnotation]; ok {
+		return revision
+	}
+	return strconv.FormatInt(d.Generation, 10)
+}
+
+// deploymentRolloutComplete returns true iff the deployment controller has observed the latest
+// spec and all replicas are updated and available.
+func deploymentRolloutComplete(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.Replicas == replicas &&
+		d.Status.AvailableReplicas == replicas
+}
+
+// statefulSetRolloutComplete returns true iff the statefulset controller has observed the latest
+// spec and all replicas run the current revision and are ready.
+func statefulSetRolloutComplete(s *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+
+	return s.Status.ObservedGeneration >= s.Generation &&
+		s.Status.UpdateRevision == s.Status.CurrentRevision &&
+		s.Status.UpdatedReplicas == replicas &&
+		s.Status.ReadyReplicas == replicas
+}
@@ -0,0 +1,208 @@
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RolloutTriggerSuite struct {
+	testutil.TestSuite
+}
+
+var (
+	logger, logOutput = test.NewNullLogger()
+)
+
+func (suite *RolloutTriggerSuite) SetupTest() {
+	logger.SetLevel(log.DebugLevel)
+	logOutput.Reset()
+}
+
+func (suite *RolloutTriggerSuite) TestDeploymentRolloutComplete() {
+	for _, tt := range []struct {
+		name     string
+		status   appsv1.DeploymentStatus
+		expected bool
+	}{
+		{
+			name:     "all replicas updated and available",
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+			expected: true,
+		},
+		{
+			name:     "latest generation not observed yet",
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+			expected: false,
+		},
+		{
+			name:     "old replicas still around",
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3},
+			expected: false,
+		},
+		{
+			name:     "updated replicas not available yet",
+			status:   appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2},
+			expected: false,
+		},
+	} {
+		deployment := newDeployment("default", "foo", 3)
+		deployment.Generation = 2
+		deployment.Status = tt.status
+
+		suite.Equal(tt.expected, deploymentRolloutComplete(deployment), tt.name)
+	}
+}
+
+func (suite *RolloutTriggerSuite) TestStatefulSetRolloutComplete() {
+	for _, tt := range []struct {
+		name     string
+		status   appsv1.StatefulSetStatus
+		expected bool
+	}{
+		{
+			name:     "all replicas on current revision and ready",
+			status:   appsv1.StatefulSetStatus{ObservedGeneration: 1, UpdatedReplicas: 2, ReadyReplicas: 2, CurrentRevision: "b", UpdateRevision: "b"},
+			expected: true,
+		},
+		{
+			name:     "revisions differ",
+			status:   appsv1.StatefulSetStatus{ObservedGeneration: 1, UpdatedReplicas: 2, ReadyReplicas: 2, CurrentRevision: "a", UpdateRevision: "b"},
+			expected: false,
+		},
+		{
+			name:     "replicas not ready yet",
+			status:   appsv1.StatefulSetStatus{ObservedGeneration: 1, UpdatedReplicas: 2, ReadyReplicas: 1, CurrentRevision: "b", UpdateRevision: "b"},
+			expected: false,
+		},
+	} {
+		replicas := int32(2)
+		statefulSet := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", Generation: 1},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status:     tt.status,
+		}
+
+		suite.Equal(tt.expected, statefulSetRolloutComplete(statefulSet), tt.name)
+	}
+}
+
+func (suite *RolloutTriggerSuite) TestRun() {
+	client := fake.NewSimpleClientset()
+	trigger := NewRolloutTrigger(client, logger, "", 0)
+
+	deployment := newDeployment("default", "foo", 1)
+	deployment.Generation = 2
+	deployment.Status.ObservedGeneration = 1
+
+	_, err := client.AppsV1().Deployments("default").Create(context.Background(), deployment, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	go trigger.Run(ctx)
+
+	// give the informer a chance to sync before completing the rollout
+	suite.Eventually(func() bool {
+		return logOutput.LastEntry() != nil && logOutput.LastEntry().Message == "watching workload rollouts"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	deployment.Status = appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}
+	_, err = client.AppsV1().Deployments("default").UpdateStatus(context.Background(), deployment, metav1.UpdateOptions{})
+	suite.Require().NoError(err)
+
+	select {
+	case request := <-trigger.Requests():
+		suite.Equal("default", request.Namespace)
+		suite.True(request.Selector.Matches(labels.Set{"app": "foo"}))
+		suite.False(request.Selector.Matches(labels.Set{"app": "bar"}))
+		suite.Equal("rollout of Deployment default/foo completed", request.Reason)
+	case <-ctx.Done():
+		suite.Fail("timed out waiting for trigger request")
+	}
+}
+
+func (suite *RolloutTriggerSuite) TestRunIgnoresRecovery() {
+	client := fake.NewSimpleClientset()
+	trigger := NewRolloutTrigger(client, logger, "", 0)
+
+	available := appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+
+	deployment := newDeployment("default", "foo", 2)
+	deployment.Generation = 1
+	deployment.Annotations = map[string]string{deploymentRevisionAnnotation: "1"}
+	deployment.Status = available
+
+	_, err := client.AppsV1().Deployments("default").Create(context.Background(), deployment, metav1.CreateOptions{})
+	suite.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	go trigger.Run(ctx)
+
+	suite.Eventually(func() bool {
+		return logOutput.LastEntry() != nil && logOutput.LastEntry().Message == "watching workload rollouts"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	update := func(status appsv1.DeploymentStatus) {
+		deployment.Status = status
+		_, err := client.AppsV1().Deployments("default").Update(context.Background(), deployment, metav1.UpdateOptions{})
+		suite.Require().NoError(err)
+	}
+
+	// a pod is deleted and replaced, which doesn't make for a new rollout
+	update(appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1})
+	update(available)
+
+	select {
+	case request := <-trigger.Requests():
+		suite.Failf("unexpected trigger request", "%s", request.Reason)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// a new revision is rolled out
+	deployment.Generation = 2
+	deployment.Annotations[deploymentRevisionAnnotation] = "2"
+	update(appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2})
+	update(appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2})
+
+	select {
+	case request := <-trigger.Requests():
+		suite.Equal("rollout of Deployment default/foo completed", request.Reason)
+	case <-ctx.Done():
+		suite.Fail("timed out waiting for trigger request")
+	}
+}
+
+func TestRolloutTriggerSuite(t *testing.T) {
+	suite.Run(t, new(RolloutTriggerSuite))
+}
+
+func newDeployment(namespace, name string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+		},
+	}
+}
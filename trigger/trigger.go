@@ -0,0 +1,16 @@
+package trigger
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Request asks chaoskube to terminate a victim among the candidate pods that
+// live in Namespace and match Selector.
+type Request struct {
+	// the namespace of the affected workload
+	Namespace string
+	// a label selector matching the pods of the affected workload
+	Selector labels.Selector
+	// a human readable description of why the request was issued
+	Reason string
+}
@@ -0,0 +1,191 @@
+package trigger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/linki/chaoskube/auth"
+)
+
+// webhookRequest is the payload accepted by the Webhook.
+type webhookRequest struct {
+	// the namespace to restrict the victims to, empty means all namespaces
+	Namespace string `json:"namespace"`
+	// a label selector to restrict the victims to
+	Selector string `json:"selector"`
+	// the time window in which the termination is going to happen, e.g. 10m
+	Within string `json:"within"`
+}
+
+// scheduledRequest is a Request that should be sent at a given point in time.
+type scheduledRequest struct {
+	request Request
+	at      time.Time
+}
+
+// Webhook is an http.Handler that accepts chaos requests from external systems, e.g. CI/CD
+// pipelines. Requests must carry a bearer token that authorizes them for the requested
+// namespace and are rate limited.
+type Webhook struct {
+	logger     log.FieldLogger
+	authorizer auth.Authorizer
+	maxWithin  time.Duration
+	limiter    flowcontrol.RateLimiter
+	pending    chan scheduledRequest
+	requests   chan Request
+	now        func() time.Time
+}
+
+// NewWebhook creates and returns a Webhook object. It accepts up to requestsPerMinute requests
+// and allows time windows up to maxWithin.
+func NewWebhook(logger log.FieldLogger, authorizer auth.Authorizer, requestsPerMinute int, maxWithin time.Duration) *Webhook {
+	return &Webhook{
+		logger:     logger.WithField("trigger", "Webhook"),
+		authorizer: authorizer,
+		maxWithin:  maxWithin,
+		limiter:    flowcontrol.NewTokenBucketRateLimiter(float32(requestsPerMinute)/60, requestsPerMinute),
+		pending:    make(chan scheduledRequest, requestsPerMinute),
+		requests:   make(chan Request),
+		now:        time.Now,
+	}
+}
+
+// Requests returns the channel the trigger sends its requests to.
+func (w *Webhook) Requests() <-chan Request {
+	return w.requests
+}
+
+// Run sends accepted requests once their scheduled time has come until the given context is canceled.
+func (w *Webhook) Run(ctx context.Context) {
+	for {
+		select {
+		case scheduled := <-w.pending:
+			go func() {
+				select {
+				case <-time.After(scheduled.at.Sub(w.now())):
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case w.requests <- scheduled.request:
+				case <-ctx.Done():
+				}
+			}()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ServeHTTP validates and schedules an incoming chaos request.
+func (w *Webhook) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(res, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		http.Error(res, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// authorizing may review the token against the API server, so rate-limit first
+	if !w.limiter.TryAccept() {
+		http.Error(res, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
+	scheduled, err := w.parse(req)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	user, err := w.authorizer.Authorize(req.Context(), token, scheduled.request.Namespace)
+	switch {
+	case errors.Is(err, auth.ErrUnauthenticated):
+		http.Error(res, "unauthorized", http.StatusUnauthorized)
+		return
+	case errors.Is(err, auth.ErrForbidden):
+		w.logger.WithFields(log.Fields{
+			"user":      user,
+			"namespace": scheduled.request.Namespace,
+		}).Warn("rejected chaos request")
+		http.Error(res, "forbidden", http.StatusForbidden)
+		return
+	case err != nil:
+		w.logger.WithField("err", err).Error("failed to authorize chaos request")
+		http.Error(res, "failed to authorize request", http.StatusInternalServerError)
+		return
+	}
+
+	scheduled.request.Reason += " by " + user
+
+	select {
+	case w.pending <- scheduled:
+	default:
+		http.Error(res, "too many pending requests", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.logger.WithFields(log.Fields{
+		"user":      user,
+		"namespace": scheduled.request.Namespace,
+		"selector":  scheduled.request.Selector.String(),
+		"at":        scheduled.at,
+	}).Info("accepted chaos request")
+
+	res.WriteHeader(http.StatusAccepted)
+	fmt.Fprintf(res, "chaos scheduled at %s\n", scheduled.at.Format(time.RFC3339))
+}
+
+// parse turns the request body into a Request scheduled at a random time within the requested window.
+func (w *Webhook) parse(req *http.Request) (scheduledRequest, error) {
+	var payload webhookRequest
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+		return scheduledRequest{}, fmt.Errorf("invalid payload: %v", err)
+	}
+
+	selector, err := labels.Parse(payload.Selector)
+	if err != nil {
+		return scheduledRequest{}, fmt.Errorf("invalid selector: %v", err)
+	}
+
+	within := time.Duration(0)
+	if payload.Within != "" {
+		within, err = time.ParseDuration(payload.Within)
+		if err != nil {
+			return scheduledRequest{}, fmt.Errorf("invalid time window: %v", err)
+		}
+	}
+	if within < 0 || within > w.maxWithin {
+		return scheduledRequest{}, fmt.Errorf("time window must be between 0s and %s", w.maxWithin)
+	}
+
+	at := w.now()
+	if within > 0 {
+		at = at.Add(time.Duration(rand.Int63n(int64(within))))
+	}
+
+	return scheduledRequest{
+		request: Request{
+			Namespace: payload.Namespace,
+			Selector:  selector,
+			Reason:    fmt.Sprintf("webhook request from %s", req.RemoteAddr),
+		},
+		at: at,
+	}, nil
+}
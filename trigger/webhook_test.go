@@ -0,0 +1,159 @@
+package trigger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/linki/chaoskube/auth"
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *WebhookSuite) TestServeHTTP() {
+	for _, tt := range []struct {
+		name     string
+		method   string
+		token    string
+		body     string
+		expected int
+	}{
+		{"valid request", http.MethodPost, "secret", `{"namespace":"default","selector":"app=foo"}`, http.StatusAccepted},
+		{"valid request with time window", http.MethodPost, "secret", `{"selector":"app=foo","within":"1m"}`, http.StatusAccepted},
+		{"wrong method", http.MethodGet, "secret", ``, http.StatusMethodNotAllowed},
+		{"missing token", http.MethodPost, "", `{"selector":"app=foo"}`, http.StatusUnauthorized},
+		{"wrong token", http.MethodPost, "wrong", `{"selector":"app=foo"}`, http.StatusUnauthorized},
+		{"invalid payload", http.MethodPost, "secret", `{`, http.StatusBadRequest},
+		{"invalid selector", http.MethodPost, "secret", `{"selector":"app in (foo"}`, http.StatusBadRequest},
+		{"invalid time window", http.MethodPost, "secret", `{"within":"forever"}`, http.StatusBadRequest},
+		{"time window too large", http.MethodPost, "secret", `{"within":"2h"}`, http.StatusBadRequest},
+	} {
+		webhook := NewWebhook(logger, auth.NewStaticToken("secret"), 10, time.Hour)
+
+		req := httptest.NewRequest(tt.method, "/trigger", strings.NewReader(tt.body))
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		res := httptest.NewRecorder()
+
+		webhook.ServeHTTP(res, req)
+
+		suite.Equal(tt.expected, res.Code, tt.name)
+	}
+}
+
+// namespaceAuthorizer allows its token to cause chaos in a single namespace only.
+type namespaceAuthorizer struct {
+	token     string
+	namespace string
+}
+
+func (a namespaceAuthorizer) Authorize(ctx context.Context, token, namespace string) (string, error) {
+	if token != a.token {
+		return "", auth.ErrUnauthenticated
+	}
+	if namespace != a.namespace {
+		return "team", auth.ErrForbidden
+	}
+	return "team", nil
+}
+
+func (suite *WebhookSuite) TestServeHTTPNamespaceScope() {
+	for _, tt := range []struct {
+		name     string
+		body     string
+		expected int
+	}{
+		{"own namespace", `{"namespace":"team"}`, http.StatusAccepted},
+		{"foreign namespace", `{"namespace":"other"}`, http.StatusForbidden},
+		{"all namespaces", `{}`, http.StatusForbidden},
+	} {
+		webhook := NewWebhook(logger, namespaceAuthorizer{token: "secret", namespace: "team"}, 10, time.Hour)
+
+		req := httptest.NewRequest(http.MethodPost, "/trigger", strings.NewReader(tt.body))
+		req.Header.Set("Authorization", "Bearer secret")
+		res := httptest.NewRecorder()
+
+		webhook.ServeHTTP(res, req)
+
+		suite.Equal(tt.expected, res.Code, tt.name)
+	}
+}
+
+func (suite *WebhookSuite) TestRateLimit() {
+	webhook := NewWebhook(logger, auth.NewStaticToken("secret"), 2, time.Hour)
+
+	codes := []int{}
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/trigger", strings.NewReader(`{}`))
+		req.Header.Set("Authorization", "Bearer secret")
+		res := httptest.NewRecorder()
+
+		webhook.ServeHTTP(res, req)
+		codes = append(codes, res.Code)
+	}
+
+	suite.Equal([]int{http.StatusAccepted, http.StatusAccepted, http.StatusTooManyRequests}, codes)
+}
+
+type countingAuthorizer struct {
+	calls int
+}
+
+func (a *countingAuthorizer) Authorize(ctx context.Context, token, namespace string) (string, error) {
+	a.calls++
+	return "", auth.ErrUnauthenticated
+}
+
+func (suite *WebhookSuite) TestRateLimitBeforeAuthorization() {
+	authorizer := &countingAuthorizer{}
+	webhook := NewWebhook(logger, authorizer, 2, time.Hour)
+
+	codes := []int{}
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/trigger", strings.NewReader(`{}`))
+		req.Header.Set("Authorization", "Bearer invalid")
+		res := httptest.NewRecorder()
+
+		webhook.ServeHTTP(res, req)
+		codes = append(codes, res.Code)
+	}
+
+	suite.Equal([]int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}, codes)
+	suite.Equal(2, authorizer.calls)
+}
+
+func (suite *WebhookSuite) TestRun() {
+	webhook := NewWebhook(logger, auth.NewStaticToken("secret"), 10, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go webhook.Run(ctx)
+
+	req := httptest.NewRequest(http.MethodPost, "/trigger", strings.NewReader(`{"namespace":"default","selector":"app=foo"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	webhook.ServeHTTP(httptest.NewRecorder(), req)
+
+	select {
+	case request := <-webhook.Requests():
+		suite.Equal("default", request.Namespace)
+		suite.True(request.Selector.Matches(labels.Set{"app": "foo"}))
+	case <-ctx.Done():
+		suite.Fail("timed out waiting for trigger request")
+	}
+}
+
+func TestWebhookSuite(t *testing.T) {
+	suite.Run(t, new(WebhookSuite))
+}
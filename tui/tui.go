@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/linki/chaoskube/chaoskube"
+)
+
+const (
+	// maxCandidates is the number of candidates listed before they are abbreviated
+	maxCandidates = 10
+	// maxVictims is the number of recent victims listed
+	maxVictims = 10
+	// clearScreen moves the cursor to the top left corner and clears the terminal
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+// Source is the Chaoskube instance shown by a Dashboard.
+type Source interface {
+	Status() chaoskube.Status
+	SetPaused(paused bool)
+}
+
+// Dashboard shows a live view of a Chaoskube instance in a terminal: its candidates, the
+// countdown to the next termination and the recent victims. Pressing p toggles pausing and
+// q quits.
+type Dashboard struct {
+	source  Source
+	in      io.Reader
+	out     io.Writer
+	refresh time.Duration
+	now     func() time.Time
+}
+
+// New creates and returns a Dashboard reading key presses from in and drawing to out, which
+// is expected to be a terminal in raw mode.
+func New(source Source, in io.Reader, out io.Writer) *Dashboard {
+	return &Dashboard{
+		source:  source,
+		in:      in,
+		out:     out,
+		refresh: time.Second,
+		now:     time.Now,
+	}
+}
+
+// Run redraws the dashboard every second and on every key press. It returns when q is
+// pressed, the input is closed or the given context is canceled.
+func (d *Dashboard) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	keys := make(chan byte)
+	go func() {
+		defer close(keys)
+
+		buf := make([]byte, 1)
+		for {
+			if _, err := d.in.Read(buf); err != nil {
+				return
+			}
+			select {
+			case keys <- buf[0]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(d.refresh)
+	defer ticker.Stop()
+
+	for {
+		if err := d.draw(); err != nil {
+			return err
+		}
+
+		select {
+		case key, ok := <-keys:
+			if !ok || !d.handle(key) {
+				return nil
+			}
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// handle acts on the given key press and returns false if the dashboard should quit.
+func (d *Dashboard) handle(key byte) bool {
+	switch key {
+	case 'p', 'P', ' ':
+		d.source.SetPaused(!d.source.Status().Paused)
+	case 'q', 'Q', 3: // ctrl-c doesn't raise a signal in raw mode
+		return false
+	}
+	return true
+}
+
+func (d *Dashboard) draw() error {
+	_, err := io.WriteString(d.out, clearScreen+d.Render())
+	return err
+}
+
+// Render returns a single frame of the dashboard. Lines end with \r\n, since a terminal in
+// raw mode doesn't return the cursor to the start of the line on \n.
+func (d *Dashboard) Render() string {
+	status := d.source.Status()
+
+	lines := []string{}
+
+	state := "running"
+	switch {
+	case status.Paused:
+		state = "paused"
+	case !status.NextTick.IsZero():
+		state += ", next termination in " + nonNegative(status.NextTick.Sub(d.now())).Truncate(time.Second).String()
+	}
+	lines = append(lines, "chaoskube: "+state, "")
+
+	lines = append(lines, fmt.Sprintf("Candidates (%d)", len(status.Candidates)))
+	for i, candidate := range status.Candidates {
+		if i == maxCandidates {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(status.Candidates)-maxCandidates))
+			break
+		}
+		lines = append(lines, "  "+candidate)
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, "Recent victims")
+	if len(status.Victims) == 0 {
+		lines = append(lines, "  none")
+	}
+	// newest first
+	for i := len(status.Victims) - 1; i >= 0 && i >= len(status.Victims)-maxVictims; i-- {
+		victim := status.Victims[i]
+		line := fmt.Sprintf("  %s  %s/%s", victim.Time.Format(time.TimeOnly), victim.Namespace, victim.Name)
+		if victim.DryRun {
+			line += " (dry run)"
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", "[p] pause/resume  [q] quit")
+
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
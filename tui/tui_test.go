@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linki/chaoskube/chaoskube"
+	"github.com/linki/chaoskube/history"
+	"github.com/linki/chaoskube/internal/testutil"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DashboardSuite struct {
+	testutil.TestSuite
+}
+
+type fakeSource struct {
+	status chaoskube.Status
+}
+
+func (s *fakeSource) Status() chaoskube.Status {
+	return s.status
+}
+
+func (s *fakeSource) SetPaused(paused bool) {
+	s.status.Paused = paused
+}
+
+var now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func (suite *DashboardSuite) TestRender() {
+	source := &fakeSource{status: chaoskube.Status{
+		Candidates: []string{"default/foo", "testing/bar"},
+		NextTick:   now.Add(90*time.Second + 500*time.Millisecond),
+		Victims: []history.Entry{
+			{Time: now.Add(-20 * time.Minute), Namespace: "default", Name: "baz"},
+			{Time: now.Add(-10 * time.Minute), Namespace: "testing", Name: "qux", DryRun: true},
+		},
+	}}
+
+	dashboard := New(source, strings.NewReader(""), &bytes.Buffer{})
+	dashboard.now = func() time.Time { return now }
+
+	suite.Equal(strings.Join([]string{
+		"chaoskube: running, next termination in 1m30s",
+		"",
+		"Candidates (2)",
+		"  default/foo",
+		"  testing/bar",
+		"",
+		"Recent victims",
+		"  11:50:00  testing/qux (dry run)",
+		"  11:40:00  default/baz",
+		"",
+		"[p] pause/resume  [q] quit",
+	}, "\r\n")+"\r\n", dashboard.Render())
+
+	source.status.Paused = true
+	suite.Contains(dashboard.Render(), "chaoskube: paused\r\n")
+}
+
+func (suite *DashboardSuite) TestRenderTruncatesCandidates() {
+	source := &fakeSource{}
+	for i := 0; i < maxCandidates+5; i++ {
+		source.status.Candidates = append(source.status.Candidates, fmt.Sprintf("default/pod-%d", i))
+	}
+
+	frame := New(source, strings.NewReader(""), &bytes.Buffer{}).Render()
+
+	suite.Contains(frame, "Candidates (15)")
+	suite.Contains(frame, "default/pod-9\r\n")
+	suite.NotContains(frame, "default/pod-10")
+	suite.Contains(frame, "... and 5 more")
+	suite.Contains(frame, "Recent victims\r\n  none")
+}
+
+func (suite *DashboardSuite) TestRun() {
+	source := &fakeSource{}
+	out := &bytes.Buffer{}
+
+	// pause, ignore an unknown key, resume, pause with space, then quit before the input ends
+	dashboard := New(source, strings.NewReader("pxp qp"), out)
+	suite.Require().NoError(dashboard.Run(context.Background()))
+
+	suite.True(source.status.Paused)
+	suite.Equal(5, strings.Count(out.String(), clearScreen))
+}
+
+func TestDashboardSuite(t *testing.T) {
+	suite.Run(t, new(DashboardSuite))
+}
@@ -0,0 +1,217 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a cron schedule of the usual five fields: minute, hour, day of month, month and
+// day of week. Each field is either * or a comma-separated list of values, ranges (e.g. 1-5)
+// and steps (e.g. */15 or 8-18/2). Months and weekdays can also be given by their first three
+// letters, e.g. JAN or MON. Like in cron, a time matches if either the day of month or the day
+// of week matches when both are restricted.
+type Schedule struct {
+	expression string
+	minutes    uint64
+	hours      uint64
+	days       uint64
+	months     uint64
+	weekdays   uint64
+	// whether all days of the month or week match
+	anyDay     bool
+	anyWeekday bool
+}
+
+// scheduleMacros are the shorthands for common schedules supported by most cron implementations.
+var scheduleMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// scheduleField describes the values one of the fields of a schedule may take.
+type scheduleField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField  = scheduleField{name: "minute", min: 0, max: 59}
+	hourField    = scheduleField{name: "hour", min: 0, max: 23}
+	dayField     = scheduleField{name: "day of month", min: 1, max: 31}
+	monthField   = scheduleField{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	weekdayField = scheduleField{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// scheduleHorizon is how far ahead to look for the next time of a schedule. It spans two leap
+// days, so that a schedule for the 29th of February always fires.
+const scheduleHorizon = 8 * 366
+
+// ParseSchedule parses a cron expression, e.g. "0 10,14 * * MON-FRI" for 10:00 and 14:00 on
+// weekdays, or one of the macros @hourly, @daily, @weekly, @monthly and @yearly.
+func ParseSchedule(expression string) (*Schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) == 1 {
+		macro, ok := scheduleMacros[strings.ToLower(fields[0])]
+		if !ok {
+			return nil, fmt.Errorf("unknown schedule: %s", expression)
+		}
+		fields = strings.Fields(macro)
+	}
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule must have five fields, got %d: %s", len(fields), expression)
+	}
+
+	s := &Schedule{
+		expression: expression,
+		anyDay:     strings.HasPrefix(fields[2], "*"),
+		anyWeekday: strings.HasPrefix(fields[4], "*"),
+	}
+
+	var err error
+	for i, target := range []struct {
+		field scheduleField
+		bits  *uint64
+	}{
+		{minuteField, &s.minutes},
+		{hourField, &s.hours},
+		{dayField, &s.days},
+		{monthField, &s.months},
+		{weekdayField, &s.weekdays},
+	} {
+		if *target.bits, err = target.field.parse(fields[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// both 0 and 7 are Sunday
+	if s.weekdays&(1<<7) != 0 {
+		s.weekdays |= 1
+	}
+
+	if s.Next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil, fmt.Errorf("schedule never fires: %s", expression)
+	}
+
+	return s, nil
+}
+
+// parse returns the values of the given field as a bit set.
+func (f scheduleField) parse(str string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(str, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %s: %s", f.name, part)
+			}
+			rng = part[:i]
+		}
+
+		from, to := f.min, f.max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if from, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = f.value(bounds[1]); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				// a single value with a step, e.g. 5/15, runs until the end of the range
+				to = f.max
+			}
+			if from > to {
+				return 0, fmt.Errorf("invalid range in %s: %s", f.name, part)
+			}
+		}
+
+		for v := from; v <= to; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of the given field, either a number or a name.
+func (f scheduleField) value(str string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(str, name) {
+			return i + f.min, nil
+		}
+	}
+
+	v, err := strconv.Atoi(str)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s: %s", f.name, str)
+	}
+	return v, nil
+}
+
+// Next returns the first time matching the schedule after the given time, in its location.
+// It returns the zero time if there is none.
+func (s *Schedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	year, month, day := after.Date()
+
+	for i := 0; i < scheduleHorizon; i++ {
+		date := time.Date(year, month, day+i, 0, 0, 0, 0, loc)
+		if !s.matchesDay(date) {
+			continue
+		}
+
+		for hour := 0; hour < 24; hour++ {
+			if s.hours&(1<<hour) == 0 {
+				continue
+			}
+			for minute := 0; minute < 60; minute++ {
+				if s.minutes&(1<<minute) == 0 {
+					continue
+				}
+				next := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, loc)
+				if next.After(after) {
+					return next
+				}
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchesDay returns true iff the schedule fires on the given date.
+func (s *Schedule) matchesDay(date time.Time) bool {
+	if s.months&(1<<date.Month()) == 0 {
+		return false
+	}
+
+	day := s.days&(1<<date.Day()) != 0
+	weekday := s.weekdays&(1<<date.Weekday()) != 0
+
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// String returns the cron expression of the schedule.
+func (s *Schedule) String() string {
+	return s.expression
+}
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"time"
+)
+
+func (suite *Suite) TestParseSchedule() {
+	for _, tt := range []struct {
+		given string
+		err   string
+	}{
+		{"0 10,14 * * MON-FRI", ""},
+		{"*/15 8-18/2 1,15 jan-jun *", ""},
+		{"@daily", ""},
+		{"@HOURLY", ""},
+		{"0 0 29 2 *", ""},
+		{"0 0 * * 7", ""},
+		{"@often", "unknown schedule: @often"},
+		{"0 10 * *", "schedule must have five fields, got 4: 0 10 * *"},
+		{"60 * * * *", "invalid minute: 60"},
+		{"0 24 * * *", "invalid hour: 24"},
+		{"0 0 0 * *", "invalid day of month: 0"},
+		{"0 0 * foo *", "invalid month: foo"},
+		{"0 0 * * 8", "invalid day of week: 8"},
+		{"0 18-8 * * *", "invalid range in hour: 18-8"},
+		{"*/0 * * * *", "invalid step in minute: */0"},
+		{"0 0 30 2 *", "schedule never fires: 0 0 30 2 *"},
+	} {
+		schedule, err := ParseSchedule(tt.given)
+		if tt.err != "" {
+			suite.EqualError(err, tt.err, tt.given)
+			continue
+		}
+		suite.Require().NoError(err, tt.given)
+		suite.Equal(tt.given, schedule.String())
+	}
+}
+
+func (suite *Suite) TestScheduleNext() {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	suite.Require().NoError(err)
+
+	// Wednesday
+	now := time.Date(2024, 6, 5, 11, 30, 15, 0, berlin)
+
+	for _, tt := range []struct {
+		schedule string
+		after    time.Time
+		expected time.Time
+	}{
+		{"0 10,14 * * MON-FRI", now, time.Date(2024, 6, 5, 14, 0, 0, 0, berlin)},
+		{"0 10,14 * * MON-FRI", time.Date(2024, 6, 5, 14, 0, 0, 0, berlin), time.Date(2024, 6, 6, 10, 0, 0, 0, berlin)},
+		{"0 10,14 * * MON-FRI", time.Date(2024, 6, 7, 15, 0, 0, 0, berlin), time.Date(2024, 6, 10, 10, 0, 0, 0, berlin)},
+		{"*/20 * * * *", now, time.Date(2024, 6, 5, 11, 40, 0, 0, berlin)},
+		{"@hourly", now, time.Date(2024, 6, 5, 12, 0, 0, 0, berlin)},
+		{"0 0 1 * *", now, time.Date(2024, 7, 1, 0, 0, 0, 0, berlin)},
+		{"0 0 29 2 *", now, time.Date(2028, 2, 29, 0, 0, 0, 0, berlin)},
+		{"0 0 * * 7", now, time.Date(2024, 6, 9, 0, 0, 0, 0, berlin)},
+		// either the day of month or the day of week must match if both are restricted
+		{"0 12 13 * FRI", now, time.Date(2024, 6, 7, 12, 0, 0, 0, berlin)},
+		{"0 12 6 * SUN", now, time.Date(2024, 6, 6, 12, 0, 0, 0, berlin)},
+		// the hour skipped when daylight saving time starts is shifted like by time.Date
+		{"30 2 * * *", time.Date(2024, 3, 30, 12, 0, 0, 0, berlin), time.Date(2024, 3, 31, 3, 30, 0, 0, berlin)},
+		// times are interpreted in the location of the given time
+		{"0 10 * * *", now.UTC(), time.Date(2024, 6, 5, 10, 0, 0, 0, time.UTC)},
+	} {
+		schedule, err := ParseSchedule(tt.schedule)
+		suite.Require().NoError(err)
+
+		next := schedule.Next(tt.after)
+		suite.True(tt.expected.Equal(next), "%s after %s: expected %s, got %s", tt.schedule, tt.after, tt.expected, next)
+	}
+}
@@ -2,12 +2,18 @@ package util
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -18,6 +24,17 @@ const (
 	YearDay = "Jan_2"
 )
 
+// weekdayNames maps the abbreviated, lower-cased names of the weekdays to them.
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
 // TimePeriod represents a time period with a single beginning and end.
 type TimePeriod struct {
 	From time.Time
@@ -51,25 +68,126 @@ func (tp TimePeriod) String() string {
 // ParseWeekdays takes a comma-separated list of abbreviated weekdays (e.g. sat,sun) and turns them
 // into a slice of time.Weekday. It ignores any whitespace and any invalid weekdays.
 func ParseWeekdays(weekdays string) []time.Weekday {
-	var days = map[string]time.Weekday{
-		"sun": time.Sunday,
-		"mon": time.Monday,
-		"tue": time.Tuesday,
-		"wed": time.Wednesday,
-		"thu": time.Thursday,
-		"fri": time.Friday,
-		"sat": time.Saturday,
-	}
-
 	parsedWeekdays := []time.Weekday{}
 	for _, wd := range strings.Split(weekdays, ",") {
-		if day, ok := days[strings.TrimSpace(strings.ToLower(wd))]; ok {
+		if day, ok := weekdayNames[strings.TrimSpace(strings.ToLower(wd))]; ok {
 			parsedWeekdays = append(parsedWeekdays, day)
 		}
 	}
 	return parsedWeekdays
 }
 
+// ParseWeekdayDurations takes a comma-separated list of abbreviated weekdays and durations (e.g.
+// mon=2h,fri=4h) and turns them into a map of time.Weekday to duration. It ignores any whitespace.
+func ParseWeekdayDurations(list string) (map[time.Weekday]time.Duration, error) {
+	durations := map[time.Weekday]time.Duration{}
+
+	for _, entry := range ParseList(list) {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday duration '%s': must be of the form weekday=duration", entry)
+		}
+
+		day, ok := weekdayNames[strings.TrimSpace(strings.ToLower(name))]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday: %s", name)
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+		if duration <= 0 {
+			return nil, fmt.Errorf("invalid duration for %s: must be positive", day)
+		}
+
+		durations[day] = duration
+	}
+
+	return durations, nil
+}
+
+// ParseList takes a comma-separated list of names (e.g. foo,bar) and turns them into a slice of
+// names. It ignores any whitespace and empty names.
+func ParseList(list string) []string {
+	names := []string{}
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// MatchAny combines the given regular expressions into one that matches whatever any of them
+// matches. It returns nil if none are given.
+func MatchAny(regexps []*regexp.Regexp) *regexp.Regexp {
+	switch len(regexps) {
+	case 0:
+		return nil
+	case 1:
+		return regexps[0]
+	}
+
+	alternatives := make([]string, 0, len(regexps))
+	for _, r := range regexps {
+		alternatives = append(alternatives, "(?:"+r.String()+")")
+	}
+	return regexp.MustCompile(strings.Join(alternatives, "|"))
+}
+
+// ParseMaxKill takes a maximum number of victims, either absolute (e.g. 3) or as a percentage
+// of the candidates (e.g. 10%), and returns either the number or the percentage.
+func ParseMaxKill(str string) (int, float64, error) {
+	str = strings.TrimSpace(str)
+
+	if number, ok := strings.CutSuffix(str, "%"); ok {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		if err != nil || percent <= 0 || percent > 100 {
+			return 0, 0, fmt.Errorf("invalid percentage: %s", str)
+		}
+		return 0, percent, nil
+	}
+
+	count, err := strconv.Atoi(str)
+	if err != nil || count < 0 {
+		return 0, 0, fmt.Errorf("invalid number: %s", str)
+	}
+	return count, 0, nil
+}
+
+// ParseLabelSelectors takes the values of a repeatable label selector flag, any of which must
+// match, and returns the selector to pass to the API server along with the selectors to OR on
+// the client. A single selector is left to the API server, which can't OR several of them.
+func ParseLabelSelectors(strs []string) (labels.Selector, []labels.Selector, error) {
+	selectors := []labels.Selector{}
+	for _, str := range strs {
+		selector, err := labels.Parse(str)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid selector %q: %v", str, err)
+		}
+		selectors = append(selectors, selector)
+	}
+
+	switch len(selectors) {
+	case 0:
+		return labels.Everything(), nil, nil
+	case 1:
+		return selectors[0], nil, nil
+	}
+	return labels.Everything(), selectors, nil
+}
+
+// ParseNamespaceScope takes a comma-separated list of namespaces (e.g. team-a,team-b) and turns
+// them into a slice of namespaces. It ignores any whitespace. An empty list stands for all namespaces.
+func ParseNamespaceScope(scope string) []string {
+	namespaces := ParseList(scope)
+	if len(namespaces) == 0 {
+		return []string{v1.NamespaceAll}
+	}
+	return namespaces
+}
+
 // ParseTimePeriods takes a comma-separated list of time periods in Kitchen24 format and turns them
 // into a slice of TimePeriods. It ignores any whitespace.
 func ParseTimePeriods(timePeriods string) ([]TimePeriod, error) {
@@ -184,14 +302,82 @@ func NewNamespace(name string) v1.Namespace {
 	}
 }
 
+// PodFromMetadata returns a pod that only carries the given metadata and phase, e.g. for pods
+// listed via the metadata API with a field selector on their phase.
+func PodFromMetadata(meta metav1.PartialObjectMetadata, phase v1.PodPhase) v1.Pod {
+	return v1.Pod{
+		TypeMeta:   metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: meta.ObjectMeta,
+		Status:     v1.PodStatus{Phase: phase},
+	}
+}
+
 // RandomPodSubSlice creates a shuffled subslice of the give pods slice
-func RandomPodSubSlice(pods []v1.Pod, count int) []v1.Pod {
+func RandomPodSubSlice(pods []v1.Pod, count int, rnd *rand.Rand) []v1.Pod {
 	maxCount := len(pods)
 	if count > maxCount {
 		count = maxCount
 	}
 
-	rand.Shuffle(len(pods), func(i, j int) { pods[i], pods[j] = pods[j], pods[i] })
+	rnd.Shuffle(len(pods), func(i, j int) { pods[i], pods[j] = pods[j], pods[i] })
 	res := pods[0:count]
 	return res
 }
+
+// WeightedPodSubSlice creates a subslice of the given pods where the probability of each pod
+// being chosen is proportional to its weight. Pods with a non-positive weight are only chosen
+// when there aren't enough pods with a positive weight.
+func WeightedPodSubSlice(pods []v1.Pod, count int, weight func(v1.Pod) float64, rnd *rand.Rand) []v1.Pod {
+	if count > len(pods) {
+		count = len(pods)
+	}
+
+	// Efraimidis-Spirakis: pick the pods with the largest keys u^(1/w)
+	keys := make(map[int]float64, len(pods))
+	order := make([]int, len(pods))
+	for i, pod := range pods {
+		order[i] = i
+		if w := weight(pod); w > 0 {
+			keys[i] = math.Pow(rnd.Float64(), 1/w)
+		} else {
+			keys[i] = -rnd.Float64()
+		}
+	}
+
+	sort.Slice(order, func(i, j int) bool { return keys[order[i]] > keys[order[j]] })
+
+	res := make([]v1.Pod, 0, count)
+	for _, i := range order[:count] {
+		res = append(res, pods[i])
+	}
+	return res
+}
+
+// NewRand returns a random source seeded with the given seed that is safe for concurrent use.
+func NewRand(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{src: rand.NewSource(seed).(rand.Source64)})
+}
+
+// lockedSource guards a rand.Source64 against concurrent use, like the global source of math/rand.
+type lockedSource struct {
+	mutex sync.Mutex
+	src   rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.src.Seed(seed)
+}
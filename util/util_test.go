@@ -1,6 +1,7 @@
 package util
 
 import (
+	"regexp"
 	"testing"
 	"time"
 
@@ -200,6 +201,112 @@ func (suite *Suite) TestTimeOfDay() {
 	}
 }
 
+func (suite *Suite) TestParseList() {
+	for _, tt := range []struct {
+		given    string
+		expected []string
+	}{
+		{"", []string{}},
+		{"foo", []string{"foo"}},
+		{" foo , bar ,", []string{"foo", "bar"}},
+	} {
+		suite.Equal(tt.expected, ParseList(tt.given), tt.given)
+	}
+}
+
+func (suite *Suite) TestMatchAny() {
+	suite.Nil(MatchAny(nil))
+
+	single := regexp.MustCompile("foo")
+	suite.Same(single, MatchAny([]*regexp.Regexp{single}))
+
+	matcher := MatchAny([]*regexp.Regexp{
+		regexp.MustCompile("^foo-"),
+		regexp.MustCompile("(?i)-BAR$"),
+	})
+	for _, tt := range []struct {
+		given    string
+		expected bool
+	}{
+		{"foo-1", true},
+		{"baz-bar", true},
+		{"FOO-1", false},
+		{"baz-qux", false},
+	} {
+		suite.Equal(tt.expected, matcher.MatchString(tt.given), tt.given)
+	}
+}
+
+func (suite *Suite) TestParseMaxKill() {
+	for _, tt := range []struct {
+		given   string
+		count   int
+		percent float64
+		err     bool
+	}{
+		{"1", 1, 0, false},
+		{" 3 ", 3, 0, false},
+		{"10%", 0, 10, false},
+		{"12.5%", 0, 12.5, false},
+		{"0%", 0, 0, true},
+		{"150%", 0, 0, true},
+		{"-1", 0, 0, true},
+		{"foo", 0, 0, true},
+	} {
+		count, percent, err := ParseMaxKill(tt.given)
+		if tt.err {
+			suite.Error(err, tt.given)
+			continue
+		}
+		suite.Require().NoError(err, tt.given)
+		suite.Equal(tt.count, count, tt.given)
+		suite.Equal(tt.percent, percent, tt.given)
+	}
+}
+
+func (suite *Suite) TestParseLabelSelectors() {
+	for _, tt := range []struct {
+		given  []string
+		server string
+		client []string
+		err    bool
+	}{
+		{[]string{}, "", nil, false},
+		{[]string{"app=foo,env!=prod"}, "app=foo,env!=prod", nil, false},
+		{[]string{"app=foo", "team=bar"}, "", []string{"app=foo", "team=bar"}, false},
+		{[]string{"app=foo", "app in foo"}, "", nil, true},
+	} {
+		server, client, err := ParseLabelSelectors(tt.given)
+		if tt.err {
+			suite.Error(err, "%v", tt.given)
+			continue
+		}
+		suite.Require().NoError(err, "%v", tt.given)
+		suite.Equal(tt.server, server.String(), "%v", tt.given)
+
+		var clientStrings []string
+		for _, selector := range client {
+			clientStrings = append(clientStrings, selector.String())
+		}
+		suite.Equal(tt.client, clientStrings, "%v", tt.given)
+	}
+}
+
+func (suite *Suite) TestParseNamespaceScope() {
+	for _, tt := range []struct {
+		given    string
+		expected []string
+	}{
+		{"", []string{""}},
+		{"default", []string{"default"}},
+		{"default,testing", []string{"default", "testing"}},
+		{" default , testing ,", []string{"default", "testing"}},
+		{" , ", []string{""}},
+	} {
+		suite.Equal(tt.expected, ParseNamespaceScope(tt.given), tt.given)
+	}
+}
+
 func (suite *Suite) TestParseWeekdays() {
 	for _, tt := range []struct {
 		given    string
@@ -245,6 +352,26 @@ func (suite *Suite) TestParseWeekdays() {
 	}
 }
 
+func (suite *Suite) TestParseWeekdayDurations() {
+	for _, tt := range []struct {
+		given    string
+		expected map[time.Weekday]time.Duration
+	}{
+		{"", map[time.Weekday]time.Duration{}},
+		{"mon=2h", map[time.Weekday]time.Duration{time.Monday: 2 * time.Hour}},
+		{" Mon = 2h ,, FRI=30m ", map[time.Weekday]time.Duration{time.Monday: 2 * time.Hour, time.Friday: 30 * time.Minute}},
+	} {
+		durations, err := ParseWeekdayDurations(tt.given)
+		suite.Require().NoError(err)
+		suite.Equal(tt.expected, durations)
+	}
+
+	for _, given := range []string{"mon", "foo=2h", "mon=later", "mon=0s", "mon=-1h"} {
+		_, err := ParseWeekdayDurations(given)
+		suite.Error(err, given)
+	}
+}
+
 func (suite *Suite) TestParseTimePeriods() {
 	for _, tt := range []struct {
 		given    string
@@ -409,6 +536,8 @@ func (suite *Suite) TestNewNamespace() {
 }
 
 func (suite *Suite) TestRandomPodSublice() {
+	rnd := NewRand(0)
+
 	pods := []v1.Pod{
 		NewPod("default", "foo", v1.PodRunning),
 		NewPod("testing", "bar", v1.PodRunning),
@@ -426,11 +555,65 @@ func (suite *Suite) TestRandomPodSublice() {
 		{"maxKill > len(pods)", pods[0:1], 3, 1},
 		{"maxKill = 0 ", pods, 0, 0},
 	} {
-		results := RandomPodSubSlice(tt.in, tt.count)
+		results := RandomPodSubSlice(tt.in, tt.count, rnd)
 		suite.Assert().Equal(len(results), tt.expected, tt.name)
 	}
 }
 
+func (suite *Suite) TestWeightedPodSubSlice() {
+	rnd := NewRand(0)
+
+	pods := []v1.Pod{
+		NewPod("default", "foo", v1.PodRunning),
+		NewPod("testing", "bar", v1.PodRunning),
+		NewPod("test", "baz", v1.PodRunning),
+	}
+
+	weights := map[string]float64{"foo": 1, "bar": 0, "baz": 1000}
+	weight := func(pod v1.Pod) float64 { return weights[pod.Name] }
+
+	for _, tt := range []struct {
+		name     string
+		in       []v1.Pod
+		count    int
+		expected int
+	}{
+		{"count = len(pods)", pods, 3, 3},
+		{"empty pod list should return empty subslice", []v1.Pod{}, 3, 0},
+		{"count > len(pods)", pods[0:1], 3, 1},
+		{"count = 0", pods, 0, 0},
+	} {
+		results := WeightedPodSubSlice(tt.in, tt.count, weight, rnd)
+		suite.Equal(tt.expected, len(results), tt.name)
+	}
+
+	// pods without weight are picked last
+	results := WeightedPodSubSlice(pods, 2, weight, rnd)
+	suite.ElementsMatch([]string{"foo", "baz"}, []string{results[0].Name, results[1].Name})
+
+	// heavy pods are picked way more often
+	picks := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		picks[WeightedPodSubSlice(pods, 1, weight, rnd)[0].Name]++
+	}
+	suite.Greater(picks["baz"], 900)
+	suite.Zero(picks["bar"])
+}
+
+func (suite *Suite) TestNewRand() {
+	pods := []v1.Pod{
+		NewPod("default", "foo", v1.PodRunning),
+		NewPod("testing", "bar", v1.PodRunning),
+		NewPod("test", "baz", v1.PodRunning),
+		NewPod("test", "qux", v1.PodRunning),
+	}
+
+	// the same seed yields the same selection
+	first := RandomPodSubSlice(append([]v1.Pod{}, pods...), 2, NewRand(42))
+	second := RandomPodSubSlice(append([]v1.Pod{}, pods...), 2, NewRand(42))
+	suite.Equal(first, second)
+}
+
 func TestSuite(t *testing.T) {
 	suite.Run(t, new(Suite))
 }
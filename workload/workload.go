@@ -0,0 +1,353 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Workload is the top-level controller of a pod, e.g. the Deployment owning the
+// ReplicaSet that owns the pod.
+type Workload struct {
+	Kind       string
+	APIVersion string
+	Namespace  string
+	Name       string
+	UID        types.UID
+	// the fetched object, e.g. *appsv1.Deployment, or nil if its kind isn't known
+	Object metav1.Object
+}
+
+// Resolver resolves pods to their top-level workloads. It caches the workloads
+// it resolved, so a Resolver is meant to be used for a single tick only, unless
+// created with a time to live, see NewCachingResolver. It is safe for concurrent use.
+type Resolver struct {
+	client kubernetes.Interface
+	ttl    time.Duration
+	now    func() time.Time
+
+	mutex sync.Mutex
+	cache map[types.UID]cacheEntry
+}
+
+// cacheEntry is a resolved workload and when it expires, or the zero time if it never does.
+type cacheEntry struct {
+	workload *Workload
+	expires  time.Time
+}
+
+// NewResolver creates and returns a Resolver object.
+func NewResolver(client kubernetes.Interface) *Resolver {
+	return NewCachingResolver(client, 0)
+}
+
+// NewCachingResolver creates and returns a Resolver object that keeps the workloads it
+// resolved for the given time to live, so that it can be shared across ticks. Workloads
+// rarely change their owners, so a few minutes of staleness are acceptable.
+func NewCachingResolver(client kubernetes.Interface, ttl time.Duration) *Resolver {
+	return &Resolver{
+		client: client,
+		ttl:    ttl,
+		now:    time.Now,
+		cache:  map[types.UID]cacheEntry{},
+	}
+}
+
+// Resolve walks up the controller references of the given pod, e.g. Pod→ReplicaSet→Deployment
+// or Pod→Job→CronJob, and returns the top-level workload. It returns nil if the pod isn't
+// controlled by anything.
+func (r *Resolver) Resolve(ctx context.Context, pod v1.Pod) (*Workload, error) {
+	ref := metav1.GetControllerOf(&pod)
+	if ref == nil {
+		return nil, nil
+	}
+
+	return r.resolve(ctx, pod.Namespace, *ref)
+}
+
+func (r *Resolver) resolve(ctx context.Context, namespace string, ref metav1.OwnerReference) (*Workload, error) {
+	if w, ok := r.cached(ref.UID); ok {
+		return w, nil
+	}
+
+	object, err := r.get(ctx, namespace, ref)
+	if apierrors.IsNotFound(err) {
+		object, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workload{
+		Kind:       ref.Kind,
+		APIVersion: ref.APIVersion,
+		Namespace:  namespace,
+		Name:       ref.Name,
+		UID:        ref.UID,
+		Object:     object,
+	}
+
+	// continue with the object's own controller, if there is one
+	if object != nil {
+		if parent := metav1.GetControllerOfNoCopy(object); parent != nil {
+			w, err = r.resolve(ctx, namespace, *parent)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	r.store(ref.UID, w)
+	return w, nil
+}
+
+// cached returns the cached workload of the given owner, if it hasn't expired yet.
+func (r *Resolver) cached(uid types.UID) (*Workload, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry, ok := r.cache[uid]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expires.IsZero() && !r.now().Before(entry.expires) {
+		delete(r.cache, uid)
+		return nil, false
+	}
+	return entry.workload, true
+}
+
+// store caches the workload of the given owner for the Resolver's time to live.
+func (r *Resolver) store(uid types.UID, w *Workload) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := cacheEntry{workload: w}
+	if r.ttl > 0 {
+		entry.expires = r.now().Add(r.ttl)
+	}
+	r.cache[uid] = entry
+}
+
+// get fetches the object referenced by ref. It returns nil for kinds it doesn't know about.
+func (r *Resolver) get(ctx context.Context, namespace string, ref metav1.OwnerReference) (metav1.Object, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	group := gv.Group
+
+	switch {
+	case group == "apps" && ref.Kind == "ReplicaSet":
+		return r.client.AppsV1().ReplicaSets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case group == "apps" && ref.Kind == "Deployment":
+		return r.client.AppsV1().Deployments(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case group == "apps" && ref.Kind == "StatefulSet":
+		return r.client.AppsV1().StatefulSets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case group == "apps" && ref.Kind == "DaemonSet":
+		return r.client.AppsV1().DaemonSets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case group == "batch" && ref.Kind == "Job":
+		return r.client.BatchV1().Jobs(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case group == "batch" && ref.Kind == "CronJob":
+		return r.client.BatchV1().CronJobs(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	}
+
+	return nil, nil
+}
+
+// Annotate merges the given annotations into the metadata of the given workload. It does
+// nothing for kinds the Resolver doesn't know about.
+func Annotate(ctx context.Context, client kubernetes.Interface, w *Workload, annotations map[string]string) error {
+	gv, err := schema.ParseGroupVersion(w.APIVersion)
+	if err != nil {
+		return err
+	}
+	group := gv.Group
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case group == "apps" && w.Kind == "ReplicaSet":
+		_, err = client.AppsV1().ReplicaSets(w.Namespace).Patch(ctx, w.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case group == "apps" && w.Kind == "Deployment":
+		_, err = client.AppsV1().Deployments(w.Namespace).Patch(ctx, w.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case group == "apps" && w.Kind == "StatefulSet":
+		_, err = client.AppsV1().StatefulSets(w.Namespace).Patch(ctx, w.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case group == "apps" && w.Kind == "DaemonSet":
+		_, err = client.AppsV1().DaemonSets(w.Namespace).Patch(ctx, w.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case group == "batch" && w.Kind == "Job":
+		_, err = client.BatchV1().Jobs(w.Namespace).Patch(ctx, w.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case group == "batch" && w.Kind == "CronJob":
+		_, err = client.BatchV1().CronJobs(w.Namespace).Patch(ctx, w.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	}
+
+	return err
+}
+
+// RestartedAtAnnotation is the pod template annotation kubectl rollout restart sets to
+// trigger a rollout.
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// Restart triggers a rollout of the given workload like kubectl rollout restart, by setting
+// the restartedAt annotation on its pod template. Only Deployments, StatefulSets and
+// DaemonSets can be restarted.
+func Restart(ctx context.Context, client kubernetes.Interface, w *Workload, at time.Time) error {
+	gv, err := schema.ParseGroupVersion(w.APIVersion)
+	if err != nil {
+		return err
+	}
+	group := gv.Group
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{RestartedAtAnnotation: at.Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case group == "apps" && w.Kind == "Deployment":
+		_, err = client.AppsV1().Deployments(w.Namespace).Patch(ctx, w.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case group == "apps" && w.Kind == "StatefulSet":
+		_, err = client.AppsV1().StatefulSets(w.Namespace).Patch(ctx, w.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case group == "apps" && w.Kind == "DaemonSet":
+		_, err = client.AppsV1().DaemonSets(w.Namespace).Patch(ctx, w.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("cannot restart %s %s/%s", w.Kind, w.Namespace, w.Name)
+	}
+
+	return err
+}
+
+// Replicas returns the desired number of replicas of the given workload. Only Deployments and
+// StatefulSets have replicas.
+func Replicas(w *Workload) (int32, error) {
+	replicas := func(r *int32) int32 {
+		// the API server defaults unset replicas to 1
+		if r == nil {
+			return 1
+		}
+		return *r
+	}
+
+	switch object := w.Object.(type) {
+	case *appsv1.Deployment:
+		return replicas(object.Spec.Replicas), nil
+	case *appsv1.StatefulSet:
+		return replicas(object.Spec.Replicas), nil
+	}
+
+	return 0, fmt.Errorf("cannot scale %s %s/%s", w.Kind, w.Namespace, w.Name)
+}
+
+// ReadyReplicas returns the number of ready pods of the given workload. It returns false for
+// kinds without a notion of readiness, e.g. Jobs, or whose object wasn't fetched.
+func ReadyReplicas(w *Workload) (int32, bool) {
+	switch object := w.Object.(type) {
+	case *appsv1.Deployment:
+		return object.Status.ReadyReplicas, true
+	case *appsv1.StatefulSet:
+		return object.Status.ReadyReplicas, true
+	case *appsv1.ReplicaSet:
+		return object.Status.ReadyReplicas, true
+	case *appsv1.DaemonSet:
+		return object.Status.NumberReady, true
+	}
+
+	return 0, false
+}
+
+// RolloutInProgress returns true iff the given Deployment, StatefulSet or DaemonSet is rolling out
+// a change, like kubectl rollout status would wait for. Other kinds and workloads whose object
+// wasn't fetched are never in progress.
+func RolloutInProgress(w *Workload) bool {
+	switch object := w.Object.(type) {
+	case *appsv1.Deployment:
+		replicas := int32(1)
+		if object.Spec.Replicas != nil {
+			replicas = *object.Spec.Replicas
+		}
+		status := object.Status
+		return status.ObservedGeneration < object.Generation ||
+			status.UpdatedReplicas < replicas ||
+			status.Replicas > status.UpdatedReplicas ||
+			status.AvailableReplicas < status.UpdatedReplicas
+	case *appsv1.StatefulSet:
+		replicas := int32(1)
+		if object.Spec.Replicas != nil {
+			replicas = *object.Spec.Replicas
+		}
+		status := object.Status
+		return status.ObservedGeneration < object.Generation ||
+			status.UpdateRevision != status.CurrentRevision ||
+			status.ReadyReplicas < replicas
+	case *appsv1.DaemonSet:
+		status := object.Status
+		return status.ObservedGeneration < object.Generation ||
+			status.UpdatedNumberScheduled < status.DesiredNumberScheduled ||
+			status.NumberAvailable < status.DesiredNumberScheduled
+	}
+
+	return false
+}
+
+// Scale sets the desired number of replicas of the given workload. Only Deployments and
+// StatefulSets can be scaled.
+func Scale(ctx context.Context, client kubernetes.Interface, w *Workload, replicas int32) error {
+	return ScaleAnnotated(ctx, client, w, replicas, nil)
+}
+
+// ScaleAnnotated sets the desired number of replicas of the given workload and, in the same
+// update, the given annotations, where nil values remove an annotation. Only Deployments and
+// StatefulSets can be scaled.
+func ScaleAnnotated(ctx context.Context, client kubernetes.Interface, w *Workload, replicas int32, annotations map[string]*string) error {
+	gv, err := schema.ParseGroupVersion(w.APIVersion)
+	if err != nil {
+		return err
+	}
+	group := gv.Group
+
+	changes := map[string]interface{}{
+		"spec": map[string]interface{}{"replicas": replicas},
+	}
+	if len(annotations) > 0 {
+		changes["metadata"] = map[string]interface{}{"annotations": annotations}
+	}
+
+	patch, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case group == "apps" && w.Kind == "Deployment":
+		_, err = client.AppsV1().Deployments(w.Namespace).Patch(ctx, w.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case group == "apps" && w.Kind == "StatefulSet":
+		_, err = client.AppsV1().StatefulSets(w.Namespace).Patch(ctx, w.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("cannot scale %s %s/%s", w.Kind, w.Namespace, w.Name)
+	}
+
+	return err
+}
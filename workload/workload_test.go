@@ -0,0 +1,278 @@
+package workload
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/linki/chaoskube/internal/testutil"
+	"github.com/linki/chaoskube/util"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResolverSuite struct {
+	testutil.TestSuite
+}
+
+func (suite *ResolverSuite) TestResolve() {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", UID: "deployment-uid"}}
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace:       "default",
+		Name:            "foo-abc",
+		UID:             "replicaset-uid",
+		OwnerReferences: []metav1.OwnerReference{controllerRef("apps/v1", "Deployment", "foo", "deployment-uid")},
+	}}
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bar", UID: "cronjob-uid"}}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Namespace:       "default",
+		Name:            "bar-123",
+		UID:             "job-uid",
+		OwnerReferences: []metav1.OwnerReference{controllerRef("batch/v1", "CronJob", "bar", "cronjob-uid")},
+	}}
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "baz", UID: "statefulset-uid"}}
+
+	for _, tt := range []struct {
+		name     string
+		owner    *metav1.OwnerReference
+		expected *Workload
+	}{
+		{
+			name:     "pod without controller",
+			owner:    nil,
+			expected: nil,
+		},
+		{
+			name:     "pod owned by a deployment's replicaset",
+			owner:    ptr(controllerRef("apps/v1", "ReplicaSet", "foo-abc", "replicaset-uid")),
+			expected: &Workload{Kind: "Deployment", APIVersion: "apps/v1", Namespace: "default", Name: "foo", UID: "deployment-uid"},
+		},
+		{
+			name:     "pod owned by a cronjob's job",
+			owner:    ptr(controllerRef("batch/v1", "Job", "bar-123", "job-uid")),
+			expected: &Workload{Kind: "CronJob", APIVersion: "batch/v1", Namespace: "default", Name: "bar", UID: "cronjob-uid"},
+		},
+		{
+			name:     "pod owned by a statefulset",
+			owner:    ptr(controllerRef("apps/v1", "StatefulSet", "baz", "statefulset-uid")),
+			expected: &Workload{Kind: "StatefulSet", APIVersion: "apps/v1", Namespace: "default", Name: "baz", UID: "statefulset-uid"},
+		},
+		{
+			name:     "pod owned by an unknown kind",
+			owner:    ptr(controllerRef("example.com/v1", "Custom", "qux", "custom-uid")),
+			expected: &Workload{Kind: "Custom", APIVersion: "example.com/v1", Namespace: "default", Name: "qux", UID: "custom-uid"},
+		},
+		{
+			name:     "pod owned by a missing replicaset",
+			owner:    ptr(controllerRef("apps/v1", "ReplicaSet", "gone", "gone-uid")),
+			expected: &Workload{Kind: "ReplicaSet", APIVersion: "apps/v1", Namespace: "default", Name: "gone", UID: "gone-uid"},
+		},
+	} {
+		client := fake.NewSimpleClientset([]runtime.Object{deployment, replicaSet, cronJob, job, statefulSet}...)
+
+		pod := util.NewPod("default", "pod", v1.PodRunning)
+		if tt.owner != nil {
+			pod.OwnerReferences = []metav1.OwnerReference{*tt.owner}
+		}
+
+		w, err := NewResolver(client).Resolve(context.Background(), pod)
+		suite.Require().NoError(err, tt.name)
+
+		if tt.expected == nil {
+			suite.Nil(w, tt.name)
+			continue
+		}
+		suite.Require().NotNil(w, tt.name)
+		suite.Equal(tt.expected.Kind, w.Kind, tt.name)
+		suite.Equal(tt.expected.APIVersion, w.APIVersion, tt.name)
+		suite.Equal(tt.expected.Namespace, w.Namespace, tt.name)
+		suite.Equal(tt.expected.Name, w.Name, tt.name)
+		suite.Equal(tt.expected.UID, w.UID, tt.name)
+	}
+}
+
+func (suite *ResolverSuite) TestResolveCaches() {
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-abc", UID: "replicaset-uid"}}
+	client := fake.NewSimpleClientset(replicaSet)
+	resolver := NewResolver(client)
+
+	pod := util.NewPod("default", "pod", v1.PodRunning)
+	pod.OwnerReferences = []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "foo-abc", "replicaset-uid")}
+
+	for i := 0; i < 3; i++ {
+		w, err := resolver.Resolve(context.Background(), pod)
+		suite.Require().NoError(err)
+		suite.Equal("foo-abc", w.Name)
+		suite.NotNil(w.Object)
+	}
+
+	suite.Len(client.Actions(), 1)
+}
+
+func (suite *ResolverSuite) TestCachingResolverExpires() {
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo-abc", UID: "replicaset-uid"}}
+	client := fake.NewSimpleClientset(replicaSet)
+	resolver := NewCachingResolver(client, time.Minute)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	resolver.now = func() time.Time { return now }
+
+	pod := util.NewPod("default", "pod", v1.PodRunning)
+	pod.OwnerReferences = []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "foo-abc", "replicaset-uid")}
+
+	for _, tt := range []struct {
+		elapsed time.Duration
+		actions int
+	}{
+		{0, 1},
+		{30 * time.Second, 1},
+		{time.Minute, 2},
+	} {
+		now = now.Add(tt.elapsed)
+
+		_, err := resolver.Resolve(context.Background(), pod)
+		suite.Require().NoError(err)
+		suite.Len(client.Actions(), tt.actions)
+	}
+}
+
+func (suite *ResolverSuite) TestAnnotate() {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        "foo",
+		UID:         "deployment-uid",
+		Annotations: map[string]string{"existing": "annotation"},
+	}}
+	client := fake.NewSimpleClientset(deployment)
+
+	w := &Workload{Kind: "Deployment", APIVersion: "apps/v1", Namespace: "default", Name: "foo", UID: "deployment-uid"}
+	suite.Require().NoError(Annotate(context.Background(), client, w, map[string]string{"foo": "bar"}))
+
+	patched, err := client.AppsV1().Deployments("default").Get(context.Background(), "foo", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.Equal(map[string]string{"existing": "annotation", "foo": "bar"}, patched.Annotations)
+
+	// unknown kinds are left alone
+	unknown := &Workload{Kind: "Rollout", APIVersion: "argoproj.io/v1alpha1", Namespace: "default", Name: "foo"}
+	suite.NoError(Annotate(context.Background(), client, unknown, map[string]string{"foo": "bar"}))
+}
+
+func (suite *ResolverSuite) TestRestart() {
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", UID: "statefulset-uid"}}
+	client := fake.NewSimpleClientset(statefulSet)
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	w := &Workload{Kind: "StatefulSet", APIVersion: "apps/v1", Namespace: "default", Name: "foo", UID: "statefulset-uid"}
+	suite.Require().NoError(Restart(context.Background(), client, w, at))
+
+	patched, err := client.AppsV1().StatefulSets("default").Get(context.Background(), "foo", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.Equal(map[string]string{RestartedAtAnnotation: "2024-01-02T03:04:05Z"}, patched.Spec.Template.Annotations)
+
+	// jobs can't be restarted
+	job := &Workload{Kind: "Job", APIVersion: "batch/v1", Namespace: "default", Name: "bar"}
+	suite.EqualError(Restart(context.Background(), client, job, at), "cannot restart Job default/bar")
+}
+
+func (suite *ResolverSuite) TestReadyReplicas() {
+	for _, tt := range []struct {
+		object   metav1.Object
+		expected int32
+		ok       bool
+	}{
+		{&appsv1.Deployment{Status: appsv1.DeploymentStatus{ReadyReplicas: 2}}, 2, true},
+		{&appsv1.StatefulSet{Status: appsv1.StatefulSetStatus{ReadyReplicas: 3}}, 3, true},
+		{&appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{NumberReady: 4}}, 4, true},
+		{&batchv1.Job{}, 0, false},
+		{nil, 0, false},
+	} {
+		ready, ok := ReadyReplicas(&Workload{Object: tt.object})
+		suite.Equal(tt.expected, ready)
+		suite.Equal(tt.ok, ok)
+	}
+}
+
+func (suite *ResolverSuite) TestRolloutInProgress() {
+	replicas := int32(3)
+	for _, tt := range []struct {
+		name     string
+		object   metav1.Object
+		expected bool
+	}{
+		{"complete deployment", &appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+		}, false},
+		{"deployment with old replicas", &appsv1.Deployment{
+			Spec:   appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3},
+		}, true},
+		{"unobserved deployment", &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+		}, true},
+		{"complete stateful set", &appsv1.StatefulSet{
+			Spec:   appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status: appsv1.StatefulSetStatus{ReadyReplicas: 3, CurrentRevision: "a", UpdateRevision: "a"},
+		}, false},
+		{"updating stateful set", &appsv1.StatefulSet{
+			Spec:   appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status: appsv1.StatefulSetStatus{ReadyReplicas: 3, CurrentRevision: "a", UpdateRevision: "b"},
+		}, true},
+		{"updating daemon set", &appsv1.DaemonSet{
+			Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberAvailable: 3},
+		}, true},
+		{"job", &batchv1.Job{}, false},
+		{"unfetched", nil, false},
+	} {
+		suite.Equal(tt.expected, RolloutInProgress(&Workload{Object: tt.object}), tt.name)
+	}
+}
+
+func (suite *ResolverSuite) TestScale() {
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", UID: "deployment-uid"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	client := fake.NewSimpleClientset(deployment)
+
+	w := &Workload{Kind: "Deployment", APIVersion: "apps/v1", Namespace: "default", Name: "foo", UID: "deployment-uid", Object: deployment}
+	current, err := Replicas(w)
+	suite.Require().NoError(err)
+	suite.Equal(int32(3), current)
+
+	suite.Require().NoError(Scale(context.Background(), client, w, 0))
+
+	scaled, err := client.AppsV1().Deployments("default").Get(context.Background(), "foo", metav1.GetOptions{})
+	suite.Require().NoError(err)
+	suite.Equal(int32(0), *scaled.Spec.Replicas)
+
+	// daemon sets can't be scaled
+	daemonSet := &Workload{Kind: "DaemonSet", APIVersion: "apps/v1", Namespace: "default", Name: "bar", Object: &appsv1.DaemonSet{}}
+	_, err = Replicas(daemonSet)
+	suite.EqualError(err, "cannot scale DaemonSet default/bar")
+	suite.EqualError(Scale(context.Background(), client, daemonSet, 0), "cannot scale DaemonSet default/bar")
+}
+
+func TestResolverSuite(t *testing.T) {
+	suite.Run(t, new(ResolverSuite))
+}
+
+func controllerRef(apiVersion, kind, name string, uid types.UID) metav1.OwnerReference {
+	controller := true
+	return metav1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: name, UID: uid, Controller: &controller}
+}
+
+func ptr(ref metav1.OwnerReference) *metav1.OwnerReference {
+	return &ref
+}